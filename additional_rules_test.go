@@ -0,0 +1,110 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdditionalRules_Modifiers(t *testing.T) {
+	hc := &mockHolidayChecker{holidays: map[string]bool{"2024-01-03": true}}
+	tests := []struct {
+		value string
+		time  time.Time
+		want  State
+	}{
+		// Wednesday, January 3 is a holiday
+		{"Mo-Fr 08:00-18:00, PH closed", time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), StateClosed},
+		{"Mo-Fr 08:00-18:00, PH closed", time.Date(2024, 1, 4, 12, 0, 0, 0, time.UTC), StateOpen},
+		{"Mo-Fr 08:00-18:00, PH off", time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), StateClosed},
+		{"Mo-Sa 10:00-18:00, Su unknown", time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC), StateUnknown},
+		{"Mo-Sa 10:00-18:00, Su unknown", time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC), StateOpen},
+		{`Mo-Sa 10:00-18:00, Su unknown "by appointment"`, time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC), StateUnknown},
+		{"Mo-Fr 08:00-18:00, Su off, Sa open", time.Date(2024, 1, 6, 20, 0, 0, 0, time.UTC), StateOpen},
+		{"Mo-Fr 08:00-18:00, We 12:00-14:00 off", time.Date(2024, 1, 10, 13, 0, 0, 0, time.UTC), StateClosed},
+		{"Mo-Fr 08:00-18:00, We 12:00-14:00 off", time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC), StateOpen},
+		// The first rule of the expression overrides earlier rules on its days
+		{"Mo-Fr 08:00-18:00; We 10:00-12:00, PH closed", time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC), StateClosed},
+		{"Mo-Fr 08:00-18:00; We 10:00-12:00, PH closed", time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC), StateOpen},
+		{"Mo-Fr 08:00-18:00; We 10:00-12:00, PH closed", time.Date(2024, 1, 3, 11, 0, 0, 0, time.UTC), StateClosed},
+		{"Mo-Fr 08:00-18:00; We 10:00-12:00, PH closed", time.Date(2024, 1, 4, 14, 0, 0, 0, time.UTC), StateOpen},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value, WithHolidayChecker(hc))
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if got, _ := oh.StateAt(tt.time); got != tt.want {
+			t.Errorf("%q at %s: got %v, want %v", tt.value, tt.time.Format("Mon 2006-01-02 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestAdditionalRules_Lists(t *testing.T) {
+	// Commas of weekday lists and time lists don't separate rules
+	for value, want := range map[string]int{
+		"Mo,Th 10:00-12:00":                  1,
+		"Mo 10:00-12:00,14:00-16:00":         1,
+		"PH,SH off":                          1,
+		"Mo-Fr 08:00-18:00, PH off":          2,
+		"Mo-Fr 08:00-18:00, Sa open, Su off": 3,
+		`Mo-Fr 08:00-18:00, Su "call us"`:    2,
+		"PH off, Mo-Fr 08:00-18:00":          2,
+	} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if got := len(oh.Rules()); got != want {
+			t.Errorf("%q: got %d rules, want %d", value, got, want)
+		}
+		if got := oh.PrettifyValue(); got != value {
+			t.Errorf("%q: PrettifyValue = %q", value, got)
+		}
+	}
+}
+
+func TestAdditionalRules_TimeModifiers(t *testing.T) {
+	oh, err := New(`Mo-Fr 10:00-12:00 open, 14:00-16:00 unknown "call us"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Monday
+	intervals := oh.GetOpenIntervals(from, from.AddDate(0, 0, 1))
+	want := []Interval{
+		{Start: from.Add(10 * time.Hour), End: from.Add(12 * time.Hour), State: StateOpen},
+		{Start: from.Add(14 * time.Hour), End: from.Add(16 * time.Hour), State: StateUnknown, Unknown: true, Comment: "call us"},
+	}
+	if len(intervals) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %+v", len(intervals), len(want), intervals)
+	}
+	for i, iv := range intervals {
+		if !iv.Start.Equal(want[i].Start) || !iv.End.Equal(want[i].End) || iv.State != want[i].State || iv.Comment != want[i].Comment {
+			t.Errorf("interval %d = %+v, want %+v", i, iv, want[i])
+		}
+	}
+	// The second time range has the weekdays of the first
+	if oh.GetUnknown(time.Date(2024, 1, 6, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected closed on Saturday")
+	}
+
+	tests := map[string]string{
+		"10:00-12:00 open, 14:00-16:00 unknown":      "10:00-12:00 open, 14:00-16:00 unknown",
+		"Mo 10:00-12:00 off, 14:00-16:00":            "Mo 10:00-12:00 off, Mo 14:00-16:00",
+		"Mo 10:00-12:00,14:00-16:00 unknown":         "Mo 10:00-12:00,14:00-16:00 unknown",
+		`PH 10:00-12:00 "guided tour", sunset-22:00`: `PH 10:00-12:00 "guided tour", PH sunset-22:00`,
+	}
+	for value, want := range tests {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		got := oh.PrettifyValue()
+		if got != want {
+			t.Errorf("%q: PrettifyValue = %q, want %q", value, got, want)
+		}
+		if reparsed, err := New(got); err != nil || !reparsed.IsEqualTo(oh) {
+			t.Errorf("%q: prettified value %q doesn't evaluate the same (%v)", value, got, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package openinghours
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateAPI = flag.Bool("update-api", false, "rewrite testdata/api.txt with the current API surface")
+
+const apiFile = "testdata/api.txt"
+
+// apiSurface returns one line per exported symbol of the package (excluding tests),
+// including signatures, so that incompatible changes show up as removed lines
+func apiSurface(t *testing.T) []string {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("failed to parse package: %v", err)
+	}
+
+	format := func(node any) string {
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fset, node); err != nil {
+			t.Fatalf("failed to print node: %v", err)
+		}
+		return strings.Join(strings.Fields(buf.String()), " ")
+	}
+
+	var lines []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if !d.Name.IsExported() {
+						continue
+					}
+					if d.Recv != nil {
+						recv := format(d.Recv.List[0].Type)
+						if !ast.IsExported(strings.TrimPrefix(recv, "*")) {
+							continue
+						}
+						lines = append(lines, fmt.Sprintf("method (%s) %s%s", recv, d.Name.Name, strings.TrimPrefix(format(d.Type), "func")))
+					} else {
+						lines = append(lines, fmt.Sprintf("func %s%s", d.Name.Name, strings.TrimPrefix(format(d.Type), "func")))
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						switch s := spec.(type) {
+						case *ast.TypeSpec:
+							if !s.Name.IsExported() {
+								continue
+							}
+							if st, ok := s.Type.(*ast.StructType); ok {
+								lines = append(lines, fmt.Sprintf("type %s struct", s.Name.Name))
+								for _, field := range st.Fields.List {
+									for _, name := range field.Names {
+										if name.IsExported() {
+											lines = append(lines, fmt.Sprintf("field %s.%s %s", s.Name.Name, name.Name, format(field.Type)))
+										}
+									}
+								}
+							} else if it, ok := s.Type.(*ast.InterfaceType); ok {
+								lines = append(lines, fmt.Sprintf("type %s interface", s.Name.Name))
+								for _, m := range it.Methods.List {
+									for _, name := range m.Names {
+										lines = append(lines, fmt.Sprintf("imethod %s.%s%s", s.Name.Name, name.Name, strings.TrimPrefix(format(m.Type), "func")))
+									}
+								}
+							} else {
+								lines = append(lines, fmt.Sprintf("type %s %s", s.Name.Name, format(s.Type)))
+							}
+						case *ast.ValueSpec:
+							kind := "var"
+							if d.Tok == token.CONST {
+								kind = "const"
+							}
+							for _, name := range s.Names {
+								if name.IsExported() {
+									lines = append(lines, fmt.Sprintf("%s %s", kind, name.Name))
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Strings(lines)
+	return lines
+}
+
+// TestAPICompatibility fails when an exported symbol recorded in testdata/api.txt
+// was removed or changed. New symbols are allowed; record them with go generate.
+func TestAPICompatibility(t *testing.T) {
+	current := apiSurface(t)
+
+	if *updateAPI {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatalf("failed to create testdata: %v", err)
+		}
+		if err := os.WriteFile(apiFile, []byte(strings.Join(current, "\n")+"\n"), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", apiFile, err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(apiFile)
+	if err != nil {
+		t.Fatalf("failed to read %s (run go generate): %v", apiFile, err)
+	}
+
+	have := make(map[string]bool, len(current))
+	for _, line := range current {
+		have[line] = true
+	}
+
+	recorded := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		recorded[line] = true
+		if !have[line] {
+			t.Errorf("incompatible API change, removed or changed: %s", line)
+		}
+	}
+
+	for _, line := range current {
+		if !recorded[line] {
+			t.Logf("new API not yet recorded in %s: %s", apiFile, line)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package openinghours
+
+import "time"
+
+// GetStates returns the state at each of times, combining GetState and
+// GetUnknown. The rules are evaluated once per day (see GetDaySchedule), and
+// consecutive times on the same day share that evaluation, so sorted times are
+// evaluated fastest.
+func (oh *OpeningHours) GetStates(times []time.Time) []State {
+	states := make([]State, len(times))
+	var day DaySchedule
+	var dayEnd time.Time
+	for i, t := range times {
+		t = startOfMinute(oh.inLocation(t))
+		if day.Date.IsZero() || t.Location() != day.Date.Location() || t.Before(day.Date) || !t.Before(dayEnd) {
+			day = oh.GetDaySchedule(t)
+			dayEnd = time.Date(day.Date.Year(), day.Date.Month(), day.Date.Day()+1, 0, 0, 0, 0, day.Date.Location())
+		}
+		states[i] = day.stateAt(t)
+	}
+	return states
+}
+
+// GetWeekMatrix returns the states of the 7 days starting at the day of
+// weekStart, e.g. to render a weekly widget. Row i is the day i days after
+// weekStart; column j is the state at j*resolution after midnight, wall clock
+// time. States change at whole minutes, so a resolution below a minute,
+// including zero and negative ones, is treated as a minute.
+func (oh *OpeningHours) GetWeekMatrix(weekStart time.Time, resolution time.Duration) [7][]State {
+	resolution = max(resolution, time.Minute)
+	slots := int((24*time.Hour + resolution - 1) / resolution)
+	weekStart = oh.inLocation(weekStart)
+
+	var matrix [7][]State
+	for i := range matrix {
+		day := oh.GetDaySchedule(time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day()+i, 0, 0, 0, 0, weekStart.Location()))
+		row := make([]State, slots)
+		for j := range row {
+			row[j] = day.stateAt(wallClockTime(day.Date, int(time.Duration(j)*resolution/time.Minute)))
+		}
+		matrix[i] = row
+	}
+	return matrix
+}
+
+// stateAt returns the state at t, which must be on the day of d
+func (d DaySchedule) stateAt(t time.Time) State {
+	for _, iv := range d.Intervals {
+		if !t.Before(iv.Start) && t.Before(iv.End) {
+			if iv.Unknown {
+				return StateUnknown
+			}
+			return StateOpen
+		}
+	}
+	return StateClosed
+}
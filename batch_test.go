@@ -0,0 +1,100 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// singleState returns the state at t from the single-time evaluation methods
+func singleState(oh *OpeningHours, t time.Time) State {
+	switch {
+	case oh.GetState(t):
+		return StateOpen
+	case oh.GetUnknown(t):
+		return StateUnknown
+	}
+	return StateClosed
+}
+
+func TestGetStates(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-12:00,13:00-18:00; Sa 10:00-14:00 unknown; Su 22:00-02:00`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	for m := 0; m < 8*24*60; m += 37 {
+		times = append(times, start.Add(time.Duration(m)*time.Minute+29*time.Second))
+	}
+	// Unsorted times and times in other locations
+	times = append(times, start.Add(10*time.Hour), start.In(tokyo), start.Add(-time.Hour), start.Add(30*time.Hour))
+
+	states := oh.GetStates(times)
+	if len(states) != len(times) {
+		t.Fatalf("got %d states, want %d", len(states), len(times))
+	}
+	seen := make(map[State]bool)
+	for i, at := range times {
+		if want := singleState(oh, at); states[i] != want {
+			t.Errorf("state at %v = %v, want %v", at, states[i], want)
+		}
+		seen[states[i]] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected open, closed and unknown states, got %v", seen)
+	}
+
+	if states := oh.GetStates(nil); len(states) != 0 {
+		t.Errorf("expected no states, got %v", states)
+	}
+}
+
+func TestGetWeekMatrix(t *testing.T) {
+	berlin := loadBerlin(t)
+	oh, err := New("Mo-Fr 09:00-17:30; Sa 10:00-14:00 unknown; Su 01:00-04:00", WithTimezone(berlin))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Monday Mar 25, 2024; Sunday Mar 31 has no 02:00-03:00 in Berlin
+	monday := time.Date(2024, 3, 25, 12, 0, 0, 0, berlin)
+	matrix := oh.GetWeekMatrix(monday, time.Hour)
+	for i, row := range matrix {
+		if len(row) != 24 {
+			t.Fatalf("row %d has %d slots, want 24", i, len(row))
+		}
+		for j, state := range row {
+			at := wallClockTime(time.Date(2024, 3, 25+i, 0, 0, 0, 0, berlin), j*60)
+			if want := singleState(oh, at); state != want {
+				t.Errorf("day %d slot %d (%v) = %v, want %v", i, j, at, state, want)
+			}
+		}
+	}
+	if matrix[0][9] != StateOpen || matrix[0][17] != StateOpen || matrix[0][18] != StateClosed {
+		t.Errorf("Monday = %v", matrix[0])
+	}
+	if matrix[5][11] != StateUnknown {
+		t.Errorf("Saturday 11:00 = %v, want unknown", matrix[5][11])
+	}
+
+	if got := len(oh.GetWeekMatrix(monday, 90*time.Minute)[0]); got != 16 {
+		t.Errorf("got %d slots at 90 minutes, want 16", got)
+	}
+	if got := len(oh.GetWeekMatrix(monday, 7*time.Hour)[0]); got != 4 {
+		t.Errorf("got %d slots at 7 hours, want 4", got)
+	}
+	if got := len(oh.GetWeekMatrix(monday, 30*time.Second)[0]); got != 24*60 {
+		t.Errorf("got %d slots at 30 seconds, want %d", got, 24*60)
+	}
+
+	for _, resolution := range []time.Duration{0, -time.Hour} {
+		if got := len(oh.GetWeekMatrix(monday, resolution)[0]); got != 24*60 {
+			t.Errorf("got %d slots at %v, want %d", got, resolution, 24*60)
+		}
+	}
+}
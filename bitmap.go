@@ -0,0 +1,34 @@
+package openinghours
+
+import "time"
+
+// MinutesPerWeek is the number of minutes in a week, the size of a weekly bitmap
+const MinutesPerWeek = 7 * 24 * 60
+
+// weeklyBitmapStart is the Monday used as the canonical week for week-stable values
+var weeklyBitmapStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// WeeklyBitmap returns the open minutes of a canonical week, starting on Monday 00:00.
+// Index i is the minute i minutes after Monday 00:00 (e.g., Tuesday 09:30 is 1440+570).
+// Unknown minutes are not marked open.
+// The second return value is false if the value is not week stable; see IsWeekStable.
+func (oh *OpeningHours) WeeklyBitmap() ([MinutesPerWeek]bool, bool) {
+	var bitmap [MinutesPerWeek]bool
+	if !oh.IsWeekStable() {
+		return bitmap, false
+	}
+
+	end := weeklyBitmapStart.Add(MinutesPerWeek * time.Minute)
+	for _, iv := range oh.GetOpenIntervals(weeklyBitmapStart, end) {
+		if iv.Unknown {
+			continue
+		}
+		from := int(iv.Start.Sub(weeklyBitmapStart) / time.Minute)
+		to := int(iv.End.Sub(weeklyBitmapStart) / time.Minute)
+		for m := from; m < to; m++ {
+			bitmap[m] = true
+		}
+	}
+
+	return bitmap, true
+}
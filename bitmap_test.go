@@ -0,0 +1,76 @@
+package openinghours
+
+import "testing"
+
+func TestWeeklyBitmap_WeekStable(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; Sa 10:00-12:00 unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	bitmap, ok := oh.WeeklyBitmap()
+	if !ok {
+		t.Fatalf("expected week-stable value to produce a bitmap")
+	}
+
+	open := 0
+	for _, b := range bitmap {
+		if b {
+			open++
+		}
+	}
+	if open != 5*8*60 {
+		t.Errorf("open minutes = %d, want %d", open, 5*8*60)
+	}
+
+	tests := []struct {
+		minute int
+		want   bool
+		desc   string
+	}{
+		{9 * 60, true, "Mo 09:00"},
+		{9*60 - 1, false, "Mo 08:59"},
+		{17*60 - 1, true, "Mo 16:59"},
+		{17 * 60, false, "Mo 17:00"},
+		{4*1440 + 12*60, true, "Fr 12:00"},
+		{5*1440 + 11*60, false, "Sa 11:00 (unknown)"},
+		{6*1440 + 12*60, false, "Su 12:00"},
+	}
+
+	for _, tt := range tests {
+		if bitmap[tt.minute] != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.desc, bitmap[tt.minute], tt.want)
+		}
+	}
+}
+
+func TestWeeklyBitmap_WrapsAroundWeek(t *testing.T) {
+	oh, err := New("Su 22:00-02:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	bitmap, ok := oh.WeeklyBitmap()
+	if !ok {
+		t.Fatalf("expected week-stable value to produce a bitmap")
+	}
+
+	// Sunday 22:00 is at the end of the canonical week, Monday 01:00 at the start
+	if !bitmap[6*1440+22*60] || !bitmap[MinutesPerWeek-1] {
+		t.Errorf("expected Sunday 22:00-24:00 to be open")
+	}
+	if !bitmap[60] || bitmap[2*60] {
+		t.Errorf("expected Monday 00:00-02:00 to be open and 02:00 closed")
+	}
+}
+
+func TestWeeklyBitmap_NotWeekStable(t *testing.T) {
+	oh, err := New("Jan-Mar Mo 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if _, ok := oh.WeeklyBitmap(); ok {
+		t.Errorf("expected no bitmap for a seasonal value")
+	}
+}
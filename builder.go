@@ -0,0 +1,238 @@
+package openinghours
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Selector selects the days of a Builder rule, like WeekdaySelector(time.Sunday),
+// MonthSelector(time.December) or PHSelector
+type Selector struct {
+	value string
+	err   error
+}
+
+// PHSelector selects public holidays
+var PHSelector = Selector{value: "PH"}
+
+// SHSelector selects school holidays
+var SHSelector = Selector{value: "SH"}
+
+// WeekdaySelector selects weekdays, written like PrettifyValue does, e.g.
+// "Mo-Fr" or "Mo,We"
+func WeekdaySelector(days ...time.Weekday) Selector {
+	weekdays := make([]bool, 7)
+	for _, d := range days {
+		if d < time.Sunday || d > time.Saturday {
+			return Selector{err: fmt.Errorf("invalid weekday: %d", d)}
+		}
+		weekdays[d] = true
+	}
+	return Selector{value: prettifyOptions{}.prettifyWeekdays(weekdays, nil)}
+}
+
+// MonthSelector selects months, like "Jun,Jul,Aug"
+func MonthSelector(months ...time.Month) Selector {
+	names := make([]string, len(months))
+	for i, m := range months {
+		if m < time.January || m > time.December {
+			return Selector{err: fmt.Errorf("invalid month: %d", m)}
+		}
+		names[i] = defaultMonthNames[m-1]
+	}
+	return Selector{value: strings.Join(names, ",")}
+}
+
+// DateSelector selects a day of the year, like "Dec 25"
+func DateSelector(month time.Month, day int) Selector {
+	if month < time.January || month > time.December || day < 1 || day > maxDaysInMonth[month] {
+		return Selector{err: fmt.Errorf("invalid date: %d-%d", month, day)}
+	}
+	return Selector{value: prettifyOptions{}.monthDay(int(month), day)}
+}
+
+// Builder constructs a value rule by rule without string concatenation, e.g.
+// for editors that let shop owners change their hours:
+//
+//	oh, err := NewBuilder().
+//		Weekdays(time.Monday, time.Tuesday).Times("09:00", "17:00").
+//		Closed(PHSelector).
+//		Build()
+//
+// A selector method called after the times or the state of a rule starts the
+// next rule. Errors like invalid times are returned by Build; String and Build
+// leave out rules disabled with Disable.
+type Builder struct {
+	rules []builderRule
+	err   error
+}
+
+type builderRule struct {
+	selectors []string
+	times     []string
+	state     string
+	comment   string
+	disabled  bool
+}
+
+// NewBuilder returns an empty Builder
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// On adds selectors to the current rule, or starts a new rule with them
+func (b *Builder) On(selectors ...Selector) *Builder {
+	r := b.current()
+	if len(r.times) > 0 || r.state != "" || r.comment != "" {
+		b.rules = append(b.rules, builderRule{})
+		r = b.current()
+	}
+	for _, s := range selectors {
+		if s.err != nil {
+			b.fail(s.err)
+			continue
+		}
+		r.selectors = append(r.selectors, s.value)
+	}
+	return b
+}
+
+// Weekdays selects weekdays, see On and WeekdaySelector
+func (b *Builder) Weekdays(days ...time.Weekday) *Builder {
+	return b.On(WeekdaySelector(days...))
+}
+
+// Months selects months, see On and MonthSelector
+func (b *Builder) Months(months ...time.Month) *Builder {
+	return b.On(MonthSelector(months...))
+}
+
+// Times adds a time range to the current rule. Times are "HH:MM" or variable
+// times like "sunset" or "(sunrise+01:00)"; ends after midnight like "02:00"
+// or "26:00" continue into the next day, starts must be before 24:00. The
+// range is written like PrettifyValue does, e.g. "9:00" as "09:00".
+func (b *Builder) Times(start, end string) *Builder {
+	tr, err := parseTimeRange(start+"-"+end, nil, start+"-"+end)
+	if err != nil {
+		b.fail(err)
+		return b
+	}
+	if tr.startVar == "" && tr.start >= 24*60 {
+		b.fail(fmt.Errorf("invalid start time: %s", start))
+		return b
+	}
+	r := b.current()
+	r.times = append(r.times, prettifyOptions{}.prettifyTimeRange(tr))
+	return b
+}
+
+// Open sets the current rule's state to open, or starts an open rule with
+// selectors
+func (b *Builder) Open(selectors ...Selector) *Builder {
+	return b.withState("open", selectors)
+}
+
+// Closed sets the current rule's state to closed, or starts a closed rule
+// with selectors like Closed(PHSelector)
+func (b *Builder) Closed(selectors ...Selector) *Builder {
+	return b.withState("off", selectors)
+}
+
+// Unknown sets the current rule's state to unknown, or starts an unknown rule
+// with selectors
+func (b *Builder) Unknown(selectors ...Selector) *Builder {
+	return b.withState("unknown", selectors)
+}
+
+func (b *Builder) withState(state string, selectors []Selector) *Builder {
+	if len(selectors) > 0 {
+		b.On(selectors...)
+	}
+	b.current().state = state
+	return b
+}
+
+// Comment sets the comment of the current rule, like "by appointment".
+// Comments can not contain double quotes.
+func (b *Builder) Comment(text string) *Builder {
+	if strings.Contains(text, `"`) {
+		b.fail(fmt.Errorf("invalid comment: %s", text))
+		return b
+	}
+	b.current().comment = text
+	return b
+}
+
+// Disable leaves the rule at index out of the value, e.g. for rules switched
+// off in an editor, keeping it for Enable
+func (b *Builder) Disable(index int) *Builder {
+	return b.setDisabled(index, true)
+}
+
+// Enable adds the rule at index disabled by Disable back to the value
+func (b *Builder) Enable(index int) *Builder {
+	return b.setDisabled(index, false)
+}
+
+func (b *Builder) setDisabled(index int, disabled bool) *Builder {
+	if index < 0 || index >= len(b.rules) {
+		b.fail(fmt.Errorf("invalid rule index: %d", index))
+		return b
+	}
+	b.rules[index].disabled = disabled
+	return b
+}
+
+// String returns the value of the enabled rules, like
+// "Mo-Tu 09:00-17:00; PH off"
+func (b *Builder) String() string {
+	var rules []string
+	for _, r := range b.rules {
+		if r.disabled {
+			continue
+		}
+		parts := append([]string(nil), r.selectors...)
+		if len(r.times) > 0 {
+			parts = append(parts, strings.Join(r.times, ","))
+		}
+		if r.state != "" && (r.state != "open" || len(parts) == 0) {
+			parts = append(parts, r.state)
+		}
+		if r.comment != "" {
+			parts = append(parts, `"`+r.comment+`"`)
+		}
+		if len(parts) > 0 {
+			rules = append(rules, strings.Join(parts, " "))
+		}
+	}
+	return strings.Join(rules, "; ")
+}
+
+// Build parses the value of the enabled rules with opts, like New
+func (b *Builder) Build(opts ...Option) (*OpeningHours, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	value := b.String()
+	if value == "" {
+		return nil, errors.New("no rules")
+	}
+	return New(value, opts...)
+}
+
+// current returns the rule being built, starting the first one
+func (b *Builder) current() *builderRule {
+	if len(b.rules) == 0 {
+		b.rules = append(b.rules, builderRule{})
+	}
+	return &b.rules[len(b.rules)-1]
+}
+
+// fail records the first error for Build
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,126 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    string
+	}{
+		{
+			"weekdays and holidays",
+			NewBuilder().
+				Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).Times("09:00", "17:00").
+				Weekdays(time.Saturday).Times("10:00", "12:00").Times("14:00", "16:00").
+				Closed(PHSelector),
+			"Mo-Fr 09:00-17:00; Sa 10:00-12:00,14:00-16:00; PH off",
+		},
+		{
+			"weekday range",
+			NewBuilder().Weekdays(time.Monday, time.Tuesday).Times("09:00", "17:00"),
+			"Mo-Tu 09:00-17:00",
+		},
+		{
+			"weekday list",
+			NewBuilder().Weekdays(time.Wednesday, time.Monday).Times("09:00", "17:00"),
+			"Mo,We 09:00-17:00",
+		},
+		{
+			"months, dates and comments",
+			NewBuilder().
+				Months(time.June, time.July, time.August).Weekdays(time.Sunday).Times("sunrise", "(sunset-01:00)").
+				Closed(DateSelector(time.December, 25)).Comment("Christmas").
+				Unknown(SHSelector).Comment("call ahead"),
+			`Jun,Jul,Aug Su sunrise-(sunset-01:00); Dec 25 off "Christmas"; SH unknown "call ahead"`,
+		},
+		{
+			"open without selectors",
+			NewBuilder().Open().Closed(WeekdaySelector(time.Sunday)),
+			"open; Su off",
+		},
+		{
+			"canonical times",
+			NewBuilder().Weekdays(time.Friday).Times("9:00", "2:00").Times("22:00", "26:00"),
+			"Fr 09:00-02:00,22:00-26:00",
+		},
+		{
+			"disabled rule",
+			NewBuilder().
+				Weekdays(time.Monday).Times("09:00", "12:00").
+				Weekdays(time.Tuesday).Times("09:00", "12:00").
+				Disable(0),
+			"Tu 09:00-12:00",
+		},
+		{
+			"enabled again",
+			NewBuilder().Weekdays(time.Monday).Times("09:00", "12:00").Disable(0).Enable(0),
+			"Mo 09:00-12:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.builder.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			oh, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build(): %v", err)
+			}
+			want, err := New(tt.want)
+			if err != nil {
+				t.Fatalf("New(%q): %v", tt.want, err)
+			}
+			if !oh.IsEqualTo(want) {
+				t.Errorf("Build() = %q, want %q", oh.PrettifyValue(), want.PrettifyValue())
+			}
+		})
+	}
+}
+
+func TestBuilder_Build(t *testing.T) {
+	holidays := &mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}}
+	oh, err := NewBuilder().
+		Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).Times("09:00", "17:00").
+		Closed(PHSelector).
+		Build(WithHolidayChecker(holidays))
+	if err != nil {
+		t.Fatalf("Build(): %v", err)
+	}
+	if !oh.GetState(time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected open on Tuesday")
+	}
+	if oh.GetState(time.Date(2024, 12, 25, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected closed on the holiday")
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+	}{
+		{"invalid time", NewBuilder().Weekdays(time.Monday).Times("09:00", "17:75")},
+		{"start at midnight", NewBuilder().Weekdays(time.Monday).Times("24:00", "26:00")},
+		{"start after midnight", NewBuilder().Weekdays(time.Monday).Times("25:00", "26:00")},
+		{"time list", NewBuilder().Weekdays(time.Monday).Times("09:00,10:00", "17:00")},
+		{"invalid weekday", NewBuilder().Weekdays(time.Weekday(7)).Times("09:00", "17:00")},
+		{"invalid month", NewBuilder().Months(time.Month(13))},
+		{"invalid date", NewBuilder().Closed(DateSelector(time.February, 30))},
+		{"quote in comment", NewBuilder().Weekdays(time.Monday).Comment(`say "hi"`)},
+		{"invalid index", NewBuilder().Weekdays(time.Monday).Disable(1)},
+		{"no rules", NewBuilder()},
+		{"all disabled", NewBuilder().Weekdays(time.Monday).Disable(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); err == nil {
+				t.Errorf("expected an error for %q", tt.builder.String())
+			}
+		})
+	}
+}
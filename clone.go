@@ -0,0 +1,55 @@
+package openinghours
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of oh. Rules, warnings and settings are copied, so
+// the clone can be configured independently, e.g. with other coordinates or
+// holiday checkers per tenant, while oh is shared by other goroutines.
+// Holiday checkers, normalizers, variable dates and the timezone are shared
+// with oh, since they are set by the caller and not modified by OpeningHours.
+func (oh *OpeningHours) Clone() *OpeningHours {
+	c := *oh
+	c.rules = cloneRules(oh.rules)
+	c.fallbackGroups = nil
+	for _, group := range oh.fallbackGroups {
+		c.fallbackGroups = append(c.fallbackGroups, cloneRules(group))
+	}
+	c.ruleOrder = slices.Clone(oh.ruleOrder)
+	c.fallbackOrders = nil
+	for _, order := range oh.fallbackOrders {
+		c.fallbackOrders = append(c.fallbackOrders, slices.Clone(order))
+	}
+	c.commaGroups = nil
+	for _, group := range oh.commaGroups {
+		c.commaGroups = append(c.commaGroups, slices.Clone(group))
+	}
+	c.warnings = slices.Clone(oh.warnings)
+	c.normalizers = slices.Clone(oh.normalizers)
+	c.normalizationChanges = slices.Clone(oh.normalizationChanges)
+	c.variableDates = slices.Clone(oh.variableDates)
+	c.closures = slices.Clone(oh.closures)
+	if oh.sunCache != nil {
+		c.sunCache = newDayCache[sunKey, sunMinutes]()
+	}
+	return &c
+}
+
+// cloneRules deep-copies rules, including their selector and time range slices
+func cloneRules(rules []rule) []rule {
+	if rules == nil {
+		return nil
+	}
+	cloned := make([]rule, len(rules))
+	for i, r := range rules {
+		r.weekdays = slices.Clone(r.weekdays)
+		r.weekdayConstraints = slices.Clone(r.weekdayConstraints)
+		r.weekConstraints = slices.Clone(r.weekConstraints)
+		r.timeRanges = slices.Clone(r.timeRanges)
+		r.metadata = maps.Clone(r.metadata)
+		cloned[i] = r
+	}
+	return cloned
+}
@@ -0,0 +1,52 @@
+package openinghours
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClone_Equal(t *testing.T) {
+	oh, err := New("Mo-Fr 9-17; PH off || unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetCoordinates(52.52, 13.405)
+
+	c := oh.Clone()
+	if !reflect.DeepEqual(oh, c) {
+		t.Errorf("clone differs from original")
+	}
+	if !c.IsEqualTo(oh) {
+		t.Errorf("clone should be equal to original")
+	}
+}
+
+func TestClone_Independent(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off || Sa 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	c := oh.Clone()
+
+	// Settings of the clone don't affect the original
+	c.SetCoordinates(52.52, 13.405)
+	c.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-01-15": true}})
+	if oh.hasCoordinates || oh.holidayChecker != nil {
+		t.Errorf("settings of the clone changed the original")
+	}
+	monday := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if !oh.GetState(monday) || c.GetState(monday) {
+		t.Errorf("expected original open and clone closed on the clone's holiday")
+	}
+
+	// Rules don't share memory
+	c.rules[0].weekdays[1] = false
+	c.rules[0].timeRanges[0].start = 0
+	c.fallbackGroups[0][0].weekdays[6] = false
+	c.warnings = append(c.warnings, Warning{Message: "changed"})
+	if !oh.rules[0].weekdays[1] || oh.rules[0].timeRanges[0].start != 9*60 ||
+		!oh.fallbackGroups[0][0].weekdays[6] || len(oh.warnings) != 0 {
+		t.Errorf("changing the clone's rules changed the original")
+	}
+}
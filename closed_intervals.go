@@ -0,0 +1,118 @@
+package openinghours
+
+import "time"
+
+// ClosedReason explains why a closed interval is closed
+type ClosedReason int
+
+const (
+	ClosedNone         ClosedReason = iota // not a closed interval
+	ClosedOutsideHours                     // no rule opens at this time
+	ClosedByRule                           // an "off"/"closed" rule matches
+	ClosedOnHoliday                        // a public holiday replaces the regular hours
+	ClosedTemporarily                      // a closure added by AddClosure
+)
+
+// GetClosedIntervals returns the closed intervals between from and to, the
+// complement of GetOpenIntervals. Each interval carries the reason for the
+// closure and, for closing rules, the rule and its comment. Adjacent closed
+// time with different reasons, rules or comments is split into separate intervals.
+func (oh *OpeningHours) GetClosedIntervals(from, to time.Time) []Interval {
+	var intervals []Interval
+	for _, iv := range oh.GetIntervals(from, to, WithClosed()) {
+		if iv.State == StateClosed {
+			intervals = append(intervals, iv)
+		}
+	}
+	return intervals
+}
+
+// IntervalOption configures GetIntervals
+type IntervalOption func(*intervalOptions)
+
+type intervalOptions struct {
+	closed bool
+}
+
+// WithClosed makes GetIntervals return the closed intervals as well
+func WithClosed() IntervalOption {
+	return func(o *intervalOptions) {
+		o.closed = true
+	}
+}
+
+// GetIntervals returns the intervals between from and to in order: the open
+// and unknown intervals of GetOpenIntervals and, with WithClosed, the closed
+// intervals of GetClosedIntervals in between, so that they cover from to to.
+// Closed intervals have State StateClosed and the reason, rule and comment of
+// the closure, e.g. "Christmas" for "Dec 25 off \"Christmas\"".
+func (oh *OpeningHours) GetIntervals(from, to time.Time, opts ...IntervalOption) []Interval {
+	var o intervalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	from, to = oh.inLocation(from), oh.inLocation(to)
+	open := oh.GetOpenIntervals(from, to)
+	if !o.closed || !from.Before(to) {
+		return open
+	}
+
+	var intervals []Interval
+	current := from
+	for _, iv := range open {
+		if current.Before(iv.Start) {
+			intervals = append(intervals, oh.closedIntervals(current, iv.Start)...)
+		}
+		intervals = append(intervals, iv)
+		current = iv.End
+	}
+	if current.Before(to) {
+		intervals = append(intervals, oh.closedIntervals(current, to)...)
+	}
+	return intervals
+}
+
+// closedIntervals splits the closed time between from and to by closure reason.
+// Reasons can only change at the change times of the rules, see changeTimes.
+func (oh *OpeningHours) closedIntervals(from, to time.Time) []Interval {
+	var intervals []Interval
+	start := from
+	last := oh.closedIntervalAt(from)
+
+	for _, t := range oh.changeTimes(from, to) {
+		iv := oh.closedIntervalAt(t)
+		if iv.Reason != last.Reason || iv.Rule != last.Rule || iv.Comment != last.Comment {
+			last.Start, last.End = start, t
+			intervals = append(intervals, last)
+			start, last = t, iv
+		}
+	}
+
+	last.Start, last.End = start, to
+	return append(intervals, last)
+}
+
+// closedIntervalAt describes why the schedule is closed at t. Only State,
+// Reason, Rule and Comment are set.
+func (oh *OpeningHours) closedIntervalAt(t time.Time) Interval {
+	if c, ok := oh.closureAt(t); ok {
+		return Interval{State: StateClosed, Reason: ClosedTemporarily, Comment: c.comment}
+	}
+	if r, ok := oh.closingRule(t); ok {
+		return Interval{State: StateClosed, Reason: ClosedByRule, Rule: prettifyRule(r, prettifyOptions{}), Comment: r.comment}
+	}
+	if oh.holidayChecker != nil && oh.hasHolidayRuleFor(t) && oh.holidayChecker.IsHoliday(t) {
+		return Interval{State: StateClosed, Reason: ClosedOnHoliday}
+	}
+	return Interval{State: StateClosed, Reason: ClosedOutsideHours}
+}
+
+// closingRule returns the rule with a closed state that decides t, see evaluate
+func (oh *OpeningHours) closingRule(t time.Time) (rule, bool) {
+	e := oh.evaluate(t)
+	if e.index < 0 || e.state != StateClosed {
+		return rule{}, false
+	}
+	group, _ := oh.rulesOf(e.group)
+	return group[e.index], true
+}
@@ -0,0 +1,120 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetClosedIntervals_Reasons(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00; Sa off "weekend"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Friday Jan 19 to Sunday Jan 21, 2024
+	from := time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)
+	got := oh.GetClosedIntervals(from, to)
+
+	want := []Interval{
+		{Start: from, End: time.Date(2024, 1, 19, 9, 0, 0, 0, time.UTC), Reason: ClosedOutsideHours},
+		{Start: time.Date(2024, 1, 19, 17, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), Reason: ClosedOutsideHours},
+		{Start: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), End: to, Reason: ClosedByRule, Rule: `Sa off "weekend"`, Comment: "weekend"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d intervals %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) ||
+			got[i].Reason != want[i].Reason || got[i].Rule != want[i].Rule || got[i].Comment != want[i].Comment {
+			t.Errorf("interval %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGetClosedIntervals_Holiday(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-01-15": true}})
+
+	// Monday Jan 15, 2024 is a holiday
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	got := oh.GetClosedIntervals(from, to)
+
+	if len(got) != 2 {
+		t.Fatalf("got %d intervals %+v, want 2", len(got), got)
+	}
+	for _, iv := range got {
+		if iv.Reason != ClosedOnHoliday {
+			t.Errorf("interval %v-%v: got reason %v, want ClosedOnHoliday", iv.Start, iv.End, iv.Reason)
+		}
+	}
+	if !got[0].End.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) ||
+		!got[1].Start.Equal(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected holiday intervals %+v", got)
+	}
+}
+
+func TestGetClosedIntervals_ComplementsOpenIntervals(t *testing.T) {
+	oh, err := New("Mo-Fr 08:00-12:00,13:00-18:00; Su off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 15, 6, 30, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	var total time.Duration
+	for _, iv := range oh.GetOpenIntervals(from, to) {
+		total += iv.End.Sub(iv.Start)
+	}
+	for _, iv := range oh.GetClosedIntervals(from, to) {
+		if iv.Reason == ClosedNone {
+			t.Errorf("closed interval %v-%v has no reason", iv.Start, iv.End)
+		}
+		if oh.GetState(iv.Start) {
+			t.Errorf("closed interval starts at open time %v", iv.Start)
+		}
+		total += iv.End.Sub(iv.Start)
+	}
+	if total != to.Sub(from) {
+		t.Errorf("open and closed intervals cover %v, want %v", total, to.Sub(from))
+	}
+}
+
+func TestGetIntervals_WithClosed(t *testing.T) {
+	oh, err := New(`Mo-Su 10:00-18:00; Dec 25 off "Christmas"; Dec 24 10:00-14:00 unknown "call ahead"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return time.Date(2024, 12, day, hour, 0, 0, 0, time.UTC) }
+
+	// Without WithClosed, only the open and unknown intervals are returned
+	if got, want := oh.GetIntervals(from, to), oh.GetOpenIntervals(from, to); len(got) != len(want) {
+		t.Errorf("GetIntervals without options = %+v, want %+v", got, want)
+	}
+
+	want := []Interval{
+		{Start: at(24, 0), End: at(24, 10), State: StateClosed, Reason: ClosedOutsideHours},
+		{Start: at(24, 10), End: at(24, 14), State: StateUnknown, Unknown: true, Comment: "call ahead"},
+		{Start: at(24, 14), End: at(24, 18), State: StateOpen},
+		{Start: at(24, 18), End: at(25, 0), State: StateClosed, Reason: ClosedOutsideHours},
+		{Start: at(25, 0), End: at(26, 0), State: StateClosed, Reason: ClosedByRule, Rule: `Dec 25 off "Christmas"`, Comment: "Christmas"},
+	}
+	got := oh.GetIntervals(from, to, WithClosed())
+	if len(got) != len(want) {
+		t.Fatalf("got %d intervals %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("interval %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
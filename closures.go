@@ -0,0 +1,38 @@
+package openinghours
+
+import (
+	"fmt"
+	"time"
+)
+
+// closure is a temporary closure added by AddClosure
+type closure struct {
+	from, to time.Time
+	comment  string
+}
+
+// AddClosure closes oh from from until to whatever the value says, e.g. for
+// renovations or strikes, without editing the value. During the closure the
+// evaluation methods report the closed state with comment, and
+// GetClosedIntervals reports ClosedTemporarily. Closures are kept when the
+// value is replaced with UnmarshalText. Like the other setters, AddClosure must
+// not be called concurrently with evaluations.
+func (oh *OpeningHours) AddClosure(from, to time.Time, comment string) error {
+	if !from.Before(to) {
+		return fmt.Errorf("closure %q ends before it starts", comment)
+	}
+	oh.closures = append(oh.closures, closure{from: from, to: to, comment: comment})
+	// The precomputed week can not represent closures
+	oh.weekStates = nil
+	return nil
+}
+
+// closureAt returns the first closure containing t
+func (oh *OpeningHours) closureAt(t time.Time) (closure, bool) {
+	for _, c := range oh.closures {
+		if !t.Before(c.from) && t.Before(c.to) {
+			return c, true
+		}
+	}
+	return closure{}, false
+}
@@ -0,0 +1,80 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddClosure(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	from := time.Date(2024, 1, 16, 12, 0, 0, 0, time.UTC) // Tuesday noon
+	to := time.Date(2024, 1, 18, 0, 0, 0, 0, time.UTC)    // Thursday midnight
+	if err := oh.AddClosure(from, to, "strike"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !oh.GetState(time.Date(2024, 1, 16, 11, 0, 0, 0, time.UTC)) {
+		t.Error("expected open before the closure")
+	}
+	if oh.GetState(time.Date(2024, 1, 17, 11, 0, 0, 0, time.UTC)) {
+		t.Error("expected closed during the closure")
+	}
+	if got := oh.GetComment(time.Date(2024, 1, 17, 11, 0, 0, 0, time.UTC)); got != "strike" {
+		t.Errorf("GetComment() = %q, want %q", got, "strike")
+	}
+	if !oh.GetState(time.Date(2024, 1, 18, 9, 0, 0, 0, time.UTC)) {
+		t.Error("expected open after the closure")
+	}
+	if got := oh.GetNextChange(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)); !got.Equal(from) {
+		t.Errorf("GetNextChange() = %v, want %v", got, from)
+	}
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if open, _ := oh.GetOpenDuration(monday, monday.AddDate(0, 0, 7)); open != 27*time.Hour {
+		t.Errorf("GetOpenDuration() = %v, want 27h", open)
+	}
+
+	var closures []Interval
+	for _, iv := range oh.GetClosedIntervals(monday, monday.AddDate(0, 0, 7)) {
+		if iv.Reason == ClosedTemporarily {
+			closures = append(closures, iv)
+		}
+	}
+	if len(closures) != 1 || !closures[0].Start.Equal(from) || !closures[0].End.Equal(to) || closures[0].Comment != "strike" {
+		t.Errorf("temporary closed intervals = %+v, want %v-%v \"strike\"", closures, from, to)
+	}
+}
+
+func TestAddClosure_KeptByUnmarshalText(t *testing.T) {
+	oh, err := New("24/7")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	from := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	if err := oh.AddClosure(from, from.AddDate(0, 0, 1), "inventory"); err != nil {
+		t.Fatal(err)
+	}
+	if err := oh.UnmarshalText([]byte("Mo-Su 08:00-20:00")); err != nil {
+		t.Fatal(err)
+	}
+	if oh.GetState(from.Add(12 * time.Hour)) {
+		t.Error("expected the closure to be kept")
+	}
+	if oh.Clone().GetState(from.Add(12 * time.Hour)) {
+		t.Error("expected the clone to keep the closure")
+	}
+}
+
+func TestAddClosure_Invalid(t *testing.T) {
+	oh, err := New("24/7")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	at := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	if err := oh.AddClosure(at, at, "empty"); err == nil {
+		t.Error("expected an error for an empty closure")
+	}
+}
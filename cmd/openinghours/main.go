@@ -0,0 +1,211 @@
+// Command openinghours parses an opening_hours value and prints its state,
+// next change, warnings, prettified form and intervals, e.g. for shell scripts
+// and OSM QA pipelines:
+//
+//	openinghours --at 2024-06-03T12:00:00+02:00 --country DE-BY "Mo-Fr 09:00-17:00; PH off"
+//	openinghours --json --from 2024-06-03 --to 2024-06-10 "Mo-Fr 09:00-17:00"
+//
+// Times are RFC 3339 or "2006-01-02 15:04" and "2006-01-02" in the timezone of
+// --tz (default local). Intervals are listed from --from (default --at) to --to
+// (default a week later). The exit status is 1 if the value doesn't parse and 2
+// for invalid flags.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	openinghours "github.com/Daquisu/opening_hours.go"
+	"github.com/Daquisu/opening_hours.go/holidays"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, time.Now()))
+}
+
+// output is the result printed for a value, as JSON with --json
+type output struct {
+	Value      string                 `json:"value"`
+	Prettified string                 `json:"prettified"`
+	At         time.Time              `json:"at"`
+	State      openinghours.State     `json:"state"`
+	Comment    string                 `json:"comment,omitempty"`
+	NextChange *time.Time             `json:"next_change,omitempty"`
+	Warnings   []openinghours.Warning `json:"warnings,omitempty"`
+	Intervals  []interval             `json:"intervals"`
+}
+
+// interval is an open or unknown interval
+type interval struct {
+	Start   time.Time          `json:"start"`
+	End     time.Time          `json:"end"`
+	State   openinghours.State `json:"state"`
+	Comment string             `json:"comment,omitempty"`
+}
+
+// run runs the command with args and returns the exit status; now is the
+// default for --at
+func run(args []string, stdout, stderr io.Writer, now time.Time) int {
+	fs := flag.NewFlagSet("openinghours", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: openinghours [flags] value")
+		fs.PrintDefaults()
+	}
+	at := fs.String("at", "", "time to evaluate (default now)")
+	from := fs.String("from", "", "start of the listed intervals (default --at)")
+	to := fs.String("to", "", "end of the listed intervals (default a week after --from)")
+	lat := fs.Float64("lat", 0, "latitude for sunrise and sunset times")
+	lon := fs.Float64("lon", 0, "longitude for sunrise and sunset times")
+	country := fs.String("country", "", `country and optional region for public holidays, e.g. "DE" or "DE-BY"`)
+	tz := fs.String("tz", "", `IANA timezone of the venue, e.g. "Europe/Berlin" (default local)`)
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	// Unquoted values arrive as several arguments
+	value := strings.Join(fs.Args(), " ")
+
+	usageError := func(err error) int {
+		fmt.Fprintf(stderr, "openinghours: %v\n", err)
+		return 2
+	}
+
+	loc := time.Local
+	var opts []openinghours.Option
+	if *tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(*tz); err != nil {
+			return usageError(err)
+		}
+		opts = append(opts, openinghours.WithTimezone(loc))
+	}
+	coordinates := false
+	fs.Visit(func(f *flag.Flag) {
+		coordinates = coordinates || f.Name == "lat" || f.Name == "lon"
+	})
+	if coordinates {
+		opts = append(opts, openinghours.WithCoordinates(*lat, *lon))
+	}
+	if *country != "" {
+		code, region, _ := strings.Cut(*country, "-")
+		var regions []string
+		if region != "" {
+			regions = append(regions, region)
+		}
+		cal, err := holidays.New(code, regions...)
+		if err != nil {
+			return usageError(err)
+		}
+		opts = append(opts, openinghours.WithHolidayChecker(cal))
+	}
+
+	atTime := now.In(loc)
+	if *at != "" {
+		var err error
+		if atTime, err = parseTime(*at, loc); err != nil {
+			return usageError(fmt.Errorf("--at: %w", err))
+		}
+	}
+	fromTime := atTime
+	if *from != "" {
+		var err error
+		if fromTime, err = parseTime(*from, loc); err != nil {
+			return usageError(fmt.Errorf("--from: %w", err))
+		}
+	}
+	toTime := fromTime.AddDate(0, 0, 7)
+	if *to != "" {
+		var err error
+		if toTime, err = parseTime(*to, loc); err != nil {
+			return usageError(fmt.Errorf("--to: %w", err))
+		}
+	}
+
+	oh, err := openinghours.New(value, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "openinghours: %v\n", err)
+		return 1
+	}
+	state, _ := oh.StateAt(atTime)
+
+	out := output{
+		Value:      value,
+		Prettified: oh.PrettifyValue(),
+		At:         atTime,
+		State:      state,
+		Comment:    oh.GetComment(atTime),
+		Warnings:   oh.GetWarningDetails(),
+		Intervals:  []interval{},
+	}
+	if next := oh.GetNextChange(atTime); !next.IsZero() {
+		out.NextChange = &next
+	}
+	for _, iv := range oh.GetOpenIntervals(fromTime, toTime) {
+		out.Intervals = append(out.Intervals, interval{Start: iv.Start, End: iv.End, State: iv.State, Comment: iv.Comment})
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(stderr, "openinghours: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	printText(stdout, out)
+	return 0
+}
+
+// printText prints out for reading in a terminal
+func printText(w io.Writer, out output) {
+	fmt.Fprintf(w, "value:       %s\n", out.Prettified)
+	state := out.State.String()
+	if out.Comment != "" {
+		state += fmt.Sprintf(" (%s)", out.Comment)
+	}
+	fmt.Fprintf(w, "state:       %s at %s\n", state, out.At.Format(time.RFC3339))
+	if out.NextChange != nil {
+		fmt.Fprintf(w, "next change: %s\n", out.NextChange.Format(time.RFC3339))
+	} else {
+		fmt.Fprintln(w, "next change: none")
+	}
+	for _, warning := range out.Warnings {
+		fmt.Fprintf(w, "warning:     %s\n", warning)
+	}
+	fmt.Fprintln(w, "intervals:")
+	for _, iv := range out.Intervals {
+		line := fmt.Sprintf("  %s - %s %s", iv.Start.Format(time.RFC3339), iv.End.Format(time.RFC3339), iv.State)
+		if iv.Comment != "" {
+			line += fmt.Sprintf(" %q", iv.Comment)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// timeLayouts are the accepted layouts of times without a zone
+var timeLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// parseTime parses an RFC 3339 time, or a time without a zone in loc
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New(`invalid time, expected RFC 3339, "2006-01-02 15:04" or "2006-01-02": ` + s)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	openinghours "github.com/Daquisu/opening_hours.go"
+)
+
+var testNow = time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+
+func TestRun_Text(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--tz", "UTC", "--to", "2024-06-05", "Mo-Fr", "9-17;", "Sa", "10:00-12:00"}, &stdout, &stderr, testNow)
+	if code != 0 {
+		t.Fatalf("exit status %d, stderr: %s", code, stderr.String())
+	}
+
+	want := `value:       Mo-Fr 09:00-17:00; Sa 10:00-12:00
+state:       open at 2024-06-03T12:00:00Z
+next change: 2024-06-03T17:00:00Z
+warning:     Abbreviated time format: use HH:MM instead of H
+intervals:
+  2024-06-03T12:00:00Z - 2024-06-03T17:00:00Z open
+  2024-06-04T09:00:00Z - 2024-06-04T17:00:00Z open
+`
+	if got := stdout.String(); got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"--json", "--tz", "Europe/Berlin", "--country", "DE-BY", "--at", "2024-10-03 12:00",
+		"--from", "2024-10-03", "--to", "2024-10-05", "Mo-Fr 09:00-17:00; PH off"}
+	if code := run(args, &stdout, &stderr, testNow); code != 0 {
+		t.Fatalf("exit status %d, stderr: %s", code, stderr.String())
+	}
+
+	var out output
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	// October 3 is German Unity Day
+	if out.State != openinghours.StateClosed {
+		t.Errorf("state = %v, want closed", out.State)
+	}
+	if out.NextChange == nil || out.NextChange.Format(time.RFC3339) != "2024-10-04T09:00:00+02:00" {
+		t.Errorf("next change = %v, want 2024-10-04T09:00:00+02:00", out.NextChange)
+	}
+	if len(out.Intervals) != 1 || out.Intervals[0].Start.Format(time.RFC3339) != "2024-10-04T09:00:00+02:00" {
+		t.Errorf("intervals = %+v, want one on October 4", out.Intervals)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"Mo-Xx 09:00-17:00"}, 1, "invalid time range"},
+		{[]string{}, 2, "usage"},
+		{[]string{"--unknown", "24/7"}, 2, "flag provided but not defined"},
+		{[]string{"--country", "XX", "24/7"}, 2, "unsupported country"},
+		{[]string{"--at", "tomorrow", "24/7"}, 2, "--at: invalid time"},
+		{[]string{"--tz", "Nowhere/City", "24/7"}, 2, "unknown time zone"},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(tt.args, &stdout, &stderr, testNow); code != tt.code {
+			t.Errorf("%q: exit status %d, want %d", tt.args, code, tt.code)
+		}
+		if !strings.Contains(stderr.String(), tt.want) {
+			t.Errorf("%q: stderr %q doesn't contain %q", tt.args, stderr.String(), tt.want)
+		}
+	}
+}
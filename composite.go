@@ -0,0 +1,127 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Composite holds the opening hours of the services of an object under names,
+// like the opening_hours, opening_hours:delivery and happy_hours tags of an OSM
+// object, so that apps can consult a single object. Names keep the order in
+// which they were added.
+type Composite struct {
+	names []string
+	hours map[string]*OpeningHours
+}
+
+// NewComposite returns an empty Composite
+func NewComposite() *Composite {
+	return &Composite{hours: make(map[string]*OpeningHours)}
+}
+
+// NewCompositeFromTags parses the opening hours tags of an OSM object with opts:
+// "opening_hours" is named "default", "opening_hours:<service>" is named after
+// the service (e.g. "delivery" or "drive_through"), and other keys ending in
+// "_hours" or "_times" like "happy_hours" or "service_times" keep their key.
+// Other tags are ignored. "default" comes first, then the other tags in order
+// of their keys.
+func NewCompositeFromTags(tags map[string]string, opts ...Option) (*Composite, error) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	c := NewComposite()
+	for _, key := range append([]string{"opening_hours"}, keys...) {
+		value, ok := tags[key]
+		name := compositeName(key)
+		if !ok || name == "" || c.Get(name) != nil {
+			continue
+		}
+		if err := c.Add(name, value, opts...); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return c, nil
+}
+
+// compositeName returns the name of the service of an OSM tag key, or "" if the
+// key isn't an opening hours tag, see NewCompositeFromTags
+func compositeName(key string) string {
+	switch {
+	case key == "opening_hours":
+		return "default"
+	case strings.HasPrefix(key, "opening_hours:"):
+		return strings.TrimPrefix(key, "opening_hours:")
+	case strings.HasSuffix(key, "_hours"), strings.HasSuffix(key, "_times"):
+		return key
+	}
+	return ""
+}
+
+// Add parses value with opts and sets it as the opening hours of name
+func (c *Composite) Add(name, value string, opts ...Option) error {
+	oh, err := New(value, opts...)
+	if err != nil {
+		return err
+	}
+	c.Set(name, oh)
+	return nil
+}
+
+// Set sets the opening hours of name, replacing earlier ones; nil removes name
+func (c *Composite) Set(name string, oh *OpeningHours) {
+	if oh == nil {
+		delete(c.hours, name)
+		c.names = slices.DeleteFunc(c.names, func(n string) bool { return n == name })
+		return
+	}
+	if _, ok := c.hours[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.hours[name] = oh
+}
+
+// Get returns the opening hours of name, or nil if there are none
+func (c *Composite) Get(name string) *OpeningHours {
+	return c.hours[name]
+}
+
+// Names returns the names in the order they were added
+func (c *Composite) Names() []string {
+	return slices.Clone(c.names)
+}
+
+// GetState returns true if the service name is open at t, see
+// OpeningHours.GetState. It returns an error if there is no service name.
+func (c *Composite) GetState(name string, t time.Time) (bool, error) {
+	oh := c.hours[name]
+	if oh == nil {
+		return false, fmt.Errorf("unknown service: %s", name)
+	}
+	return oh.GetState(t), nil
+}
+
+// Available returns the names of the services open at t, in order. Services
+// whose state is unknown are not included, see States.
+func (c *Composite) Available(t time.Time) []string {
+	var names []string
+	for _, name := range c.names {
+		if c.hours[name].GetState(t) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// States returns the state of each service at t, like GetStateInfo
+func (c *Composite) States(t time.Time) map[string]State {
+	states := make(map[string]State, len(c.names))
+	for _, name := range c.names {
+		states[name] = c.hours[name].GetStateInfo(t).State
+	}
+	return states
+}
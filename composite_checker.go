@@ -0,0 +1,110 @@
+package openinghours
+
+import (
+	"fmt"
+	"time"
+)
+
+// CompositeChecker is a HolidayChecker and SchoolHolidayChecker merging several
+// sources, e.g. a holidays.Calendar, a SchoolHolidayTable and the closure days
+// of a venue. A day is a public holiday if any holiday checker or closure says
+// so, and a school holiday if any school holiday checker does. Closures count
+// as public holidays, so "PH off" closes on them and GetComment returns their
+// comment. Names come from the first source that knows one.
+//
+// Sources must be added before the checker is set on an OpeningHours, which
+// caches holidays per day; see ClearCache.
+type CompositeChecker struct {
+	holidays       []HolidayChecker
+	schoolHolidays []SchoolHolidayChecker
+	closures       []schoolHolidayDays // closure days, named by their comment
+}
+
+// NewCompositeChecker returns a CompositeChecker without sources
+func NewCompositeChecker() *CompositeChecker {
+	return &CompositeChecker{}
+}
+
+// AddHolidayChecker adds a source of public holidays
+func (c *CompositeChecker) AddHolidayChecker(hc HolidayChecker) {
+	c.holidays = append(c.holidays, hc)
+}
+
+// AddSchoolHolidayChecker adds a source of school holidays
+func (c *CompositeChecker) AddSchoolHolidayChecker(shc SchoolHolidayChecker) {
+	c.schoolHolidays = append(c.schoolHolidays, shc)
+}
+
+// AddClosure adds the days from from to to (inclusive) as closure days, like
+// renovations or strikes. Only the dates are used.
+func (c *CompositeChecker) AddClosure(from, to time.Time, comment string) error {
+	days := schoolHolidayDays{name: comment, start: dateKey(from), end: dateKey(to)}
+	if days.end < days.start {
+		return fmt.Errorf("closure %q ends before it starts", comment)
+	}
+	c.closures = append(c.closures, days)
+	return nil
+}
+
+// IsHoliday reports whether t is a public holiday of a source or a closure day
+func (c *CompositeChecker) IsHoliday(t time.Time) bool {
+	if _, ok := c.closureName(t); ok {
+		return true
+	}
+	for _, hc := range c.holidays {
+		if hc.IsHoliday(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// HolidayName returns the comment of the closure of t, or the name of the
+// public holiday from the first source that is a HolidayNamer and knows it
+func (c *CompositeChecker) HolidayName(t time.Time) (string, bool) {
+	if name, ok := c.closureName(t); ok {
+		return name, true
+	}
+	for _, hc := range c.holidays {
+		if namer, ok := hc.(HolidayNamer); ok {
+			if name, ok := namer.HolidayName(t); ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// IsSchoolHoliday reports whether t is a school holiday of a source
+func (c *CompositeChecker) IsSchoolHoliday(t time.Time) bool {
+	for _, shc := range c.schoolHolidays {
+		if shc.IsSchoolHoliday(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// SchoolHolidayName returns the name of the school holiday period of t from
+// the first source that is a SchoolHolidayNamer and knows it
+func (c *CompositeChecker) SchoolHolidayName(t time.Time) string {
+	for _, shc := range c.schoolHolidays {
+		if namer, ok := shc.(SchoolHolidayNamer); ok {
+			if name := namer.SchoolHolidayName(t); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// closureName returns the comment of the first closure containing the date of t
+func (c *CompositeChecker) closureName(t time.Time) (string, bool) {
+	key := dateKey(t)
+	for _, days := range c.closures {
+		if key >= days.start && key <= days.end {
+			return days.name, true
+		}
+	}
+	return "", false
+}
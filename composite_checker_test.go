@@ -0,0 +1,65 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompositeChecker(t *testing.T) {
+	table, err := NewSchoolHolidayTable(SchoolHolidayPeriod{
+		Name:  "Sommerferien 2024",
+		Start: time.Date(2024, 7, 22, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := NewCompositeChecker()
+	checker.AddHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}})
+	checker.AddHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-08-15": true}})
+	checker.AddSchoolHolidayChecker(table)
+	if err := checker.AddClosure(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), "renovation"); err != nil {
+		t.Fatal(err)
+	}
+
+	oh, err := New("Mo-Fr 09:00-17:00; SH Mo-Fr 10:00-14:00; PH off",
+		WithHolidayChecker(checker), WithSchoolHolidayChecker(checker))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		at      time.Time
+		state   State
+		comment string
+	}{
+		{time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), StateOpen, ""},             // regular Friday
+		{time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC), StateClosed, "renovation"}, // closure
+		{time.Date(2024, 3, 7, 12, 0, 0, 0, time.UTC), StateOpen, ""},             // after the closure
+		{time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), StateClosed, ""},         // first holiday source
+		{time.Date(2024, 8, 15, 12, 0, 0, 0, time.UTC), StateClosed, ""},          // second holiday source
+		{time.Date(2024, 8, 14, 9, 30, 0, 0, time.UTC), StateClosed, ""},          // school holidays
+		{time.Date(2024, 8, 14, 12, 0, 0, 0, time.UTC), StateOpen, "Sommerferien 2024"},
+	}
+	for _, tt := range tests {
+		info := oh.GetStateInfo(tt.at)
+		if info.State != tt.state || info.Comment != tt.comment {
+			t.Errorf("%v: GetStateInfo() = %v %q, want %v %q", tt.at, info.State, info.Comment, tt.state, tt.comment)
+		}
+	}
+
+	if name, ok := checker.HolidayName(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)); !ok || name != "renovation" {
+		t.Errorf("HolidayName() = %q, %v, want %q, true", name, ok, "renovation")
+	}
+	if _, ok := checker.HolidayName(time.Date(2024, 3, 7, 12, 0, 0, 0, time.UTC)); ok {
+		t.Error("HolidayName() ok after the closure")
+	}
+}
+
+func TestCompositeChecker_InvalidClosure(t *testing.T) {
+	checker := NewCompositeChecker()
+	err := checker.AddClosure(time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), "renovation")
+	if err == nil {
+		t.Error("expected an error for a closure ending before it starts")
+	}
+}
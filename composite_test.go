@@ -0,0 +1,86 @@
+package openinghours
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComposite_FromTags(t *testing.T) {
+	c, err := NewCompositeFromTags(map[string]string{
+		"opening_hours":               "Mo-Sa 10:00-22:00",
+		"opening_hours:kitchen":       "Mo-Sa 12:00-14:00,18:00-21:00",
+		"opening_hours:drive_through": "Mo-Su 07:00-23:00",
+		"happy_hours":                 `Mo-Fr 17:00-19:00; Sa unknown "ask staff"`,
+		"name":                        "The Tavern",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := c.Names(), []string{"default", "happy_hours", "drive_through", "kitchen"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names = %q, want %q", got, want)
+	}
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		want []string
+	}{
+		{monday.Add(8 * time.Hour), []string{"drive_through"}},
+		{monday.Add(13 * time.Hour), []string{"default", "drive_through", "kitchen"}},
+		{monday.Add(18 * time.Hour), []string{"default", "happy_hours", "drive_through", "kitchen"}},
+		{monday.Add(23*time.Hour + 30*time.Minute), nil},
+	}
+	for _, tt := range tests {
+		if got := c.Available(tt.at); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Available(%v) = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+
+	saturday := monday.AddDate(0, 0, 5).Add(18 * time.Hour)
+	want := map[string]State{"default": StateOpen, "drive_through": StateOpen, "happy_hours": StateUnknown, "kitchen": StateOpen}
+	if got := c.States(saturday); !reflect.DeepEqual(got, want) {
+		t.Errorf("States = %v, want %v", got, want)
+	}
+
+	if open, err := c.GetState("kitchen", monday.Add(15*time.Hour)); err != nil || open {
+		t.Errorf("GetState(kitchen) = %v, %v, want false, nil", open, err)
+	}
+	if _, err := c.GetState("delivery", monday); err == nil {
+		t.Errorf("expected an error for an unknown service")
+	}
+}
+
+func TestComposite_SetAndErrors(t *testing.T) {
+	c := NewComposite()
+	if err := c.Add("delivery", "Mo-Fr 11:00-21:00"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Add("default", "Mo-Fr 09:00-17:00"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Add("default", "Mo-Fr 08:00-18:00"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := c.Names(), []string{"delivery", "default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names = %q, want %q", got, want)
+	}
+	if got := c.Get("default").PrettifyValue(); got != "Mo-Fr 08:00-18:00" {
+		t.Errorf("default = %q, want the replaced value", got)
+	}
+
+	c.Set("delivery", nil)
+	if got := c.Names(); !reflect.DeepEqual(got, []string{"default"}) || c.Get("delivery") != nil {
+		t.Errorf("Names after removing delivery = %q", got)
+	}
+
+	if err := c.Add("broken", "Mo-Xx"); err == nil {
+		t.Errorf("expected a parse error")
+	}
+	_, err := NewCompositeFromTags(map[string]string{"opening_hours": "24/7", "opening_hours:delivery": "Mo-Xx"})
+	if err == nil || !strings.HasPrefix(err.Error(), "opening_hours:delivery: ") {
+		t.Errorf("error = %v, want one naming the tag", err)
+	}
+}
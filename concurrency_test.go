@@ -0,0 +1,99 @@
+package openinghours
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentEvaluation(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-12:00,13:00-18:00; Sa sunrise-sunset; Su 22:00-02:00; PH off; SH 10:00-12:00 "holidays" || unknown`,
+		WithCoordinates(52.52, 13.40),
+		WithHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-01-01": true}}),
+		WithSchoolHolidayChecker(&mockSchoolHolidayChecker{holidays: map[string]bool{"2024-01-03": true}}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	wantIntervals := oh.GetOpenIntervals(from, to)
+	wantNext := oh.GetNextChange(from)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				at := from.Add(time.Duration(g*50+i) * 17 * time.Minute)
+				oh.GetState(at)
+				oh.GetUnknown(at)
+				oh.GetComment(at)
+				oh.GetMatchingRule(at)
+				if got := oh.GetNextChange(from); !got.Equal(wantNext) {
+					t.Errorf("GetNextChange = %v, want %v", got, wantNext)
+					return
+				}
+			}
+			if got := oh.GetOpenIntervals(from, to); len(got) != len(wantIntervals) {
+				t.Errorf("got %d intervals, want %d", len(got), len(wantIntervals))
+			}
+			oh.GetWeekSchedule(WithReferenceDate(from))
+			oh.PrettifyValue()
+			oh.Rules()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentWeekStable(t *testing.T) {
+	// Three primary rules leave spare capacity in the slice of rules, which
+	// must not be shared when the fallback group is added to it
+	oh, err := New(`Mo 10:00-12:00; Tu 10:00-12:00; We 10:00-12:00 || Th-Fr 14:00-16:00 "afternoons"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	wantSimplified := oh.Simplify()
+	callers := []func() error{
+		func() error {
+			if !oh.IsWeekStable() {
+				return fmt.Errorf("IsWeekStable = false, want true")
+			}
+			return nil
+		},
+		func() error {
+			if _, ok := oh.WeeklyBitmap(); !ok {
+				return fmt.Errorf("WeeklyBitmap is not available for a week stable value")
+			}
+			return nil
+		},
+		func() error {
+			if got := oh.Simplify(); got != wantSimplified {
+				return fmt.Errorf("Simplify = %q, want %q", got, wantSimplified)
+			}
+			return nil
+		},
+		func() error {
+			if oh.computeWeekStates() == nil {
+				return fmt.Errorf("computeWeekStates = nil for a week stable value")
+			}
+			return nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := callers[g%len(callers)](); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
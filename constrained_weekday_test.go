@@ -394,3 +394,194 @@ func TestConstrainedWeekday_CombinedWithRegularWeekdays(t *testing.T) {
 		}
 	}
 }
+
+func TestConstrainedWeekday_SpaceSeparatedList(t *testing.T) {
+	// "Mo[1] Tu[2]" is accepted like "Mo[1],Tu[2]" but warned about
+	oh, err := New("Mo[1] Tu[2] 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// January 2024: first Monday is Jan 1, second Tuesday is Jan 9
+	tests := []struct {
+		day  int
+		want bool
+		desc string
+	}{
+		{1, true, "Jan 1 (first Monday)"},
+		{8, false, "Jan 8 (second Monday)"},
+		{2, false, "Jan 2 (first Tuesday)"},
+		{9, true, "Jan 9 (second Tuesday)"},
+	}
+
+	for _, tt := range tests {
+		tm := time.Date(2024, 1, tt.day, 10, 0, 0, 0, time.UTC)
+		if got := oh.GetState(tm); got != tt.want {
+			t.Errorf("%s at 10:00: got %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+
+	if len(oh.GetWarnings()) != 1 {
+		t.Errorf("expected one warning about space-separated weekdays, got %v", oh.GetWarnings())
+	}
+}
+
+func TestConstrainedWeekday_CommaSpaceSeparatedList(t *testing.T) {
+	oh, err := New("Mo[1], Tu[2] 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if !oh.GetState(time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Jan 9 (second Tuesday) at 10:00: expected open")
+	}
+	if len(oh.GetWarnings()) != 0 {
+		t.Errorf("expected no warnings for comma-separated weekdays, got %v", oh.GetWarnings())
+	}
+}
+
+func TestConstrainedWeekday_SpaceSeparatedPlainWeekdays(t *testing.T) {
+	oh, err := New("Sa Su 10:00-14:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 13, 2024 is Saturday, Jan 14 is Sunday, Jan 15 is Monday
+	if !oh.GetState(time.Date(2024, 1, 13, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Sa 11:00: expected open")
+	}
+	if !oh.GetState(time.Date(2024, 1, 14, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Su 11:00: expected open")
+	}
+	if oh.GetState(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Mo 11:00: expected closed")
+	}
+}
+
+func TestConstrainedWeekday_DayOffset(t *testing.T) {
+	tests := []struct {
+		value string
+		open  []time.Time
+	}{
+		// The Sunday after the last Saturday, in the next month for Aug 31
+		{"Sa[-1] +1 day 10:00-12:00", []time.Time{
+			time.Date(2024, 6, 30, 11, 0, 0, 0, time.UTC),
+			time.Date(2024, 7, 28, 11, 0, 0, 0, time.UTC),
+			time.Date(2024, 9, 1, 11, 0, 0, 0, time.UTC),
+		}},
+		// The Friday before the first Sunday, in the previous month for Sep 1
+		{"Su[1] -2 days 10:00-12:00", []time.Time{
+			time.Date(2024, 7, 5, 11, 0, 0, 0, time.UTC),
+			time.Date(2024, 8, 2, 11, 0, 0, 0, time.UTC),
+			time.Date(2024, 8, 30, 11, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := oh.PrettifyValue(); got != tt.value {
+				t.Errorf("PrettifyValue() = %q, want %q", got, tt.value)
+			}
+
+			from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+			intervals := oh.GetOpenIntervals(from, from.AddDate(0, 3, 15))
+			if len(intervals) != len(tt.open) {
+				t.Fatalf("GetOpenIntervals() = %v, want %d intervals", intervals, len(tt.open))
+			}
+			for i, want := range tt.open {
+				if !intervals[i].Start.Before(want) || !intervals[i].End.After(want) {
+					t.Errorf("interval %d = %v-%v, want it to contain %v", i, intervals[i].Start, intervals[i].End, want)
+				}
+			}
+		})
+	}
+}
+
+// TestConstrainedWeekday_MonthAndYear tests constrained weekdays combined with
+// year and month selectors and day offsets, like rules for the days of the
+// daylight saving time changes. Selectors are matched on the day of the
+// occurrence, before the offset is applied.
+func TestConstrainedWeekday_MonthAndYear(t *testing.T) {
+	tests := []struct {
+		value string
+		from  time.Time
+		to    time.Time
+		open  []time.Time // starts of the open intervals
+	}{
+		{"Mar Su[-1] 02:00-03:00",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2024, 3, 31, 2, 0, 0, 0, time.UTC), time.Date(2025, 3, 30, 2, 0, 0, 0, time.UTC)}},
+		{"2025 Mar,Oct Su[-1] 02:00-03:00",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2025, 3, 30, 2, 0, 0, 0, time.UTC), time.Date(2025, 10, 26, 2, 0, 0, 0, time.UTC)}},
+		// Sunday Oct 31, 2027 is the last Sunday of October: the Monday after is in November
+		{"Oct Su[-1] +1 day 10:00-12:00",
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2026, 10, 26, 10, 0, 0, 0, time.UTC), time.Date(2027, 11, 1, 10, 0, 0, 0, time.UTC)}},
+		// Saturday Nov 1, 2025 is the first Saturday of November: the Friday before is in October
+		{"2025 Nov Sa[1] -1 day 10:00-12:00",
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2025, 10, 31, 10, 0, 0, 0, time.UTC)}},
+		{"Mar-Apr Su[1-2] 10:00-12:00",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 7, 10, 0, 0, 0, time.UTC), time.Date(2024, 4, 14, 10, 0, 0, 0, time.UTC),
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := oh.PrettifyValue(); got != tt.value {
+				t.Errorf("PrettifyValue() = %q, want %q", got, tt.value)
+			}
+			if warnings := oh.GetWarnings(); len(warnings) > 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+
+			intervals := oh.GetOpenIntervals(tt.from, tt.to)
+			if len(intervals) != len(tt.open) {
+				t.Fatalf("GetOpenIntervals() = %v, want %d intervals", intervals, len(tt.open))
+			}
+			for i, want := range tt.open {
+				if !intervals[i].Start.Equal(want) {
+					t.Errorf("interval %d starts at %v, want %v", i, intervals[i].Start, want)
+				}
+			}
+		})
+	}
+}
+
+// TestConstrainedWeekday_DaylightSavingTime tests the hours around the
+// daylight saving time changes, which fall on the last Sundays of March and
+// October in the EU
+func TestConstrainedWeekday_DaylightSavingTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+	oh, err := New("Mar,Oct Su[-1] 01:00-04:00", WithTimezone(berlin))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	intervals := oh.GetOpenIntervals(time.Date(2024, 1, 1, 0, 0, 0, 0, berlin), time.Date(2025, 1, 1, 0, 0, 0, 0, berlin))
+	if len(intervals) != 2 {
+		t.Fatalf("GetOpenIntervals() = %v, want 2 intervals", intervals)
+	}
+	// The clocks skip an hour in March and repeat one in October
+	if d := intervals[0].End.Sub(intervals[0].Start); d != 2*time.Hour {
+		t.Errorf("March interval lasts %v, want 2h", d)
+	}
+	if d := intervals[1].End.Sub(intervals[1].Start); d != 4*time.Hour {
+		t.Errorf("October interval lasts %v, want 4h", d)
+	}
+}
@@ -0,0 +1,131 @@
+package openinghours
+
+import (
+	"context"
+	"time"
+)
+
+// HolidayCheckerCtx is a context-aware HolidayChecker, e.g. for database-backed lookups.
+// It is queried lazily, only when a rule needs to know whether a day is a holiday.
+type HolidayCheckerCtx interface {
+	IsHolidayCtx(ctx context.Context, t time.Time) bool
+}
+
+// SchoolHolidayCheckerCtx is a context-aware SchoolHolidayChecker
+type SchoolHolidayCheckerCtx interface {
+	IsSchoolHolidayCtx(ctx context.Context, t time.Time) bool
+}
+
+// boundHolidayChecker adapts a HolidayCheckerCtx to HolidayChecker using a fixed context
+type boundHolidayChecker struct {
+	ctx context.Context
+	hc  HolidayCheckerCtx
+}
+
+func (b boundHolidayChecker) IsHoliday(t time.Time) bool {
+	return b.hc.IsHolidayCtx(b.ctx, t)
+}
+
+// HolidayName forwards to hc if it is a HolidayNamer
+func (b boundHolidayChecker) HolidayName(t time.Time) (string, bool) {
+	if namer, ok := b.hc.(HolidayNamer); ok {
+		return namer.HolidayName(t)
+	}
+	return "", false
+}
+
+// boundSchoolHolidayChecker adapts a SchoolHolidayCheckerCtx to SchoolHolidayChecker using a fixed context
+type boundSchoolHolidayChecker struct {
+	ctx context.Context
+	shc SchoolHolidayCheckerCtx
+}
+
+func (b boundSchoolHolidayChecker) IsSchoolHoliday(t time.Time) bool {
+	return b.shc.IsSchoolHolidayCtx(b.ctx, t)
+}
+
+// SchoolHolidayName forwards to shc if it is a SchoolHolidayNamer
+func (b boundSchoolHolidayChecker) SchoolHolidayName(t time.Time) string {
+	if namer, ok := b.shc.(SchoolHolidayNamer); ok {
+		return namer.SchoolHolidayName(t)
+	}
+	return ""
+}
+
+// SetHolidayCheckerCtx sets a context-aware holiday checker.
+// The *Ctx evaluation methods pass their context to it; the other methods use context.Background().
+func (oh *OpeningHours) SetHolidayCheckerCtx(hc HolidayCheckerCtx) {
+	oh.holidayCheckerCtx = hc
+	oh.holidayChecker = withDayCache(boundHolidayChecker{ctx: context.Background(), hc: hc})
+}
+
+// SetSchoolHolidayCheckerCtx sets a context-aware school holiday checker.
+// The *Ctx evaluation methods pass their context to it; the other methods use context.Background().
+func (oh *OpeningHours) SetSchoolHolidayCheckerCtx(shc SchoolHolidayCheckerCtx) {
+	oh.schoolHolidayCheckerCtx = shc
+	oh.schoolHolidayChecker = withSchoolDayCache(boundSchoolHolidayChecker{ctx: context.Background(), shc: shc})
+}
+
+// withContext returns a shallow copy of oh whose context-aware checkers are bound to ctx.
+// Plain HolidayChecker and SchoolHolidayChecker implementations are used unchanged.
+func (oh *OpeningHours) withContext(ctx context.Context) *OpeningHours {
+	if oh.holidayCheckerCtx == nil && oh.schoolHolidayCheckerCtx == nil {
+		return oh
+	}
+	bound := *oh
+	if oh.holidayCheckerCtx != nil {
+		bound.holidayChecker = boundHolidayChecker{ctx: ctx, hc: oh.holidayCheckerCtx}
+	}
+	if oh.schoolHolidayCheckerCtx != nil {
+		bound.schoolHolidayChecker = boundSchoolHolidayChecker{ctx: ctx, shc: oh.schoolHolidayCheckerCtx}
+	}
+	return &bound
+}
+
+// GetStateCtx is like GetState but passes ctx to context-aware holiday checkers
+func (oh *OpeningHours) GetStateCtx(ctx context.Context, t time.Time) bool {
+	return oh.withContext(ctx).GetState(t)
+}
+
+// GetUnknownCtx is like GetUnknown but passes ctx to context-aware holiday checkers
+func (oh *OpeningHours) GetUnknownCtx(ctx context.Context, t time.Time) bool {
+	return oh.withContext(ctx).GetUnknown(t)
+}
+
+// GetStateStringCtx is like GetStateString but passes ctx to context-aware holiday checkers
+//
+// Deprecated: Use StateAtCtx.
+func (oh *OpeningHours) GetStateStringCtx(ctx context.Context, t time.Time) string {
+	return oh.withContext(ctx).GetStateString(t)
+}
+
+// StateAtCtx is like StateAt but passes ctx to context-aware holiday checkers.
+// It returns the error of ctx if ctx is done, as the checkers may not have
+// answered.
+func (oh *OpeningHours) StateAtCtx(ctx context.Context, t time.Time) (State, error) {
+	state, _ := oh.withContext(ctx).StateAt(t)
+	if err := ctx.Err(); err != nil {
+		return StateClosed, err
+	}
+	return state, nil
+}
+
+// GetCommentCtx is like GetComment but passes ctx to context-aware holiday checkers
+func (oh *OpeningHours) GetCommentCtx(ctx context.Context, t time.Time) string {
+	return oh.withContext(ctx).GetComment(t)
+}
+
+// GetNextChangeCtx is like GetNextChange but passes ctx to context-aware holiday checkers
+func (oh *OpeningHours) GetNextChangeCtx(ctx context.Context, t time.Time) time.Time {
+	return oh.withContext(ctx).GetNextChange(t)
+}
+
+// GetOpenIntervalsCtx is like GetOpenIntervals but passes ctx to context-aware holiday checkers
+func (oh *OpeningHours) GetOpenIntervalsCtx(ctx context.Context, from, to time.Time) []Interval {
+	return oh.withContext(ctx).GetOpenIntervals(from, to)
+}
+
+// GetStateInfoCtx is like GetStateInfo but passes ctx to context-aware holiday checkers
+func (oh *OpeningHours) GetStateInfoCtx(ctx context.Context, t time.Time) StateInfo {
+	return oh.withContext(ctx).GetStateInfo(t)
+}
@@ -0,0 +1,133 @@
+package openinghours
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxKey string
+
+// ctxHolidayChecker reads the holidays from the context, like a lookup
+// that needs request-scoped data
+type ctxHolidayChecker struct {
+	lookups int
+}
+
+func (c *ctxHolidayChecker) IsHolidayCtx(ctx context.Context, t time.Time) bool {
+	c.lookups++
+	holiday, _ := ctx.Value(ctxKey("holiday")).(string)
+	return holiday == t.Format("2006-01-02")
+}
+
+type ctxSchoolHolidayChecker struct{}
+
+func (ctxSchoolHolidayChecker) IsSchoolHolidayCtx(ctx context.Context, t time.Time) bool {
+	return ctx.Value(ctxKey("school")) == true
+}
+
+func TestContext_HolidayCheckerReceivesContext(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	hc := &ctxHolidayChecker{}
+	oh.SetHolidayCheckerCtx(hc)
+
+	// Jan 15, 2024 is Monday
+	monday := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), ctxKey("holiday"), "2024-01-15")
+
+	if oh.GetStateCtx(ctx, monday) {
+		t.Errorf("Mo 10:00 with holiday in context: expected closed")
+	}
+	if got := oh.GetStateStringCtx(ctx, monday); got != "closed" {
+		t.Errorf("Mo 10:00 with holiday in context: GetStateStringCtx = %q, want %q", got, "closed")
+	}
+	if !oh.GetStateCtx(context.Background(), monday) {
+		t.Errorf("Mo 10:00 without holiday in context: expected open")
+	}
+	// Non-context methods use context.Background()
+	if !oh.GetState(monday) {
+		t.Errorf("Mo 10:00 via GetState: expected open")
+	}
+	if hc.lookups == 0 {
+		t.Errorf("expected the context-aware checker to be queried")
+	}
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)
+	if intervals := oh.GetOpenIntervalsCtx(ctx, from, to); len(intervals) != 1 {
+		t.Errorf("expected only Tuesday to be open, got %v", intervals)
+	}
+}
+
+func TestContext_NotQueriedWithoutHolidayRules(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	hc := &ctxHolidayChecker{}
+	oh.SetHolidayCheckerCtx(hc)
+
+	oh.GetStateCtx(context.Background(), time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+	if hc.lookups != 0 {
+		t.Errorf("expected no lookups without PH rules, got %d", hc.lookups)
+	}
+}
+
+func TestContext_SchoolHolidayChecker(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; SH 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetSchoolHolidayCheckerCtx(ctxSchoolHolidayChecker{})
+
+	afternoon := time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), ctxKey("school"), true)
+
+	if oh.GetStateCtx(ctx, afternoon) {
+		t.Errorf("Mo 15:00 during school holidays: expected closed")
+	}
+	if !oh.GetStateCtx(context.Background(), afternoon) {
+		t.Errorf("Mo 15:00 outside school holidays: expected open")
+	}
+}
+
+// namedCtxSchoolHolidayChecker also knows the name of the school holidays
+type namedCtxSchoolHolidayChecker struct {
+	ctxSchoolHolidayChecker
+}
+
+func (namedCtxSchoolHolidayChecker) SchoolHolidayName(t time.Time) string {
+	return "Winter holidays"
+}
+
+func TestContext_SchoolHolidayName(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; SH 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetSchoolHolidayCheckerCtx(namedCtxSchoolHolidayChecker{})
+
+	morning := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), ctxKey("school"), true)
+
+	if got := oh.GetCommentCtx(ctx, morning); got != "Winter holidays" {
+		t.Errorf("Mo 11:00 during school holidays: GetCommentCtx = %q, want %q", got, "Winter holidays")
+	}
+}
+
+func TestContext_PlainCheckerStillSupported(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(HolidayCheckerFunc(func(t time.Time) bool {
+		return t.Day() == 15
+	}))
+
+	if oh.GetStateCtx(context.Background(), time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Mo 10:00 (holiday): expected closed")
+	}
+}
@@ -0,0 +1,151 @@
+package openinghours
+
+import (
+	"sync"
+	"time"
+)
+
+// maxDayCacheEntries bounds a dayCache; a full cache starts over
+const maxDayCacheEntries = 4096
+
+// dayCache memoizes per-day results that evaluation needs many times, like
+// whether a day is a holiday or when the sun rises. Holiday checkers set on an
+// OpeningHours are wrapped with their own cache, so replacing a checker drops
+// its entries; the sun times are cached per OpeningHours and cleared when the
+// coordinates, elevation or timezone change. A nil dayCache caches nothing.
+type dayCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]V
+}
+
+func newDayCache[K comparable, V any]() *dayCache[K, V] {
+	return &dayCache[K, V]{}
+}
+
+// get returns the cached value of key, computing and storing it if missing
+func (c *dayCache[K, V]) get(key K, compute func() V) V {
+	if c == nil {
+		return compute()
+	}
+	c.mu.Lock()
+	if v, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		return v
+	}
+	c.mu.Unlock()
+
+	// Checkers are user code, don't hold the lock while calling them
+	v := compute()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil || len(c.entries) >= maxDayCacheEntries {
+		c.entries = make(map[K]V)
+	}
+	c.entries[key] = v
+	return v
+}
+
+// clear removes all entries
+func (c *dayCache[K, V]) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// dayKey returns the yyyymmdd date of t in its location
+func dayKey(t time.Time) int {
+	year, month, day := t.Date()
+	return year*10000 + int(month)*100 + day
+}
+
+// ClearCache removes the memoized holiday lookups and sun times of oh, e.g. in
+// long-running processes to release the days of past years. Setting a checker,
+// the coordinates, the elevation or the timezone clears the affected entries
+// automatically.
+func (oh *OpeningHours) ClearCache() {
+	oh.sunCache.clear()
+	if c, ok := oh.holidayChecker.(cachedHolidayChecker); ok {
+		c.cache.clear()
+	}
+	if c, ok := oh.schoolHolidayChecker.(cachedSchoolHolidayChecker); ok {
+		c.cache.clear()
+	}
+}
+
+// cachedHolidayChecker memoizes IsHoliday of hc per day
+type cachedHolidayChecker struct {
+	hc    HolidayChecker
+	cache *dayCache[int, bool]
+}
+
+// withDayCache returns hc with IsHoliday memoized per day
+func withDayCache(hc HolidayChecker) HolidayChecker {
+	if hc == nil {
+		return nil
+	}
+	return cachedHolidayChecker{hc: hc, cache: newDayCache[int, bool]()}
+}
+
+func (c cachedHolidayChecker) IsHoliday(t time.Time) bool {
+	return c.cache.get(dayKey(t), func() bool { return c.hc.IsHoliday(t) })
+}
+
+// HolidayName forwards to hc if it is a HolidayNamer
+func (c cachedHolidayChecker) HolidayName(t time.Time) (string, bool) {
+	if namer, ok := c.hc.(HolidayNamer); ok {
+		return namer.HolidayName(t)
+	}
+	return "", false
+}
+
+// cachedSchoolHolidayChecker memoizes IsSchoolHoliday of shc per day
+type cachedSchoolHolidayChecker struct {
+	shc   SchoolHolidayChecker
+	cache *dayCache[int, bool]
+}
+
+// withSchoolDayCache returns shc with IsSchoolHoliday memoized per day
+func withSchoolDayCache(shc SchoolHolidayChecker) SchoolHolidayChecker {
+	if shc == nil {
+		return nil
+	}
+	return cachedSchoolHolidayChecker{shc: shc, cache: newDayCache[int, bool]()}
+}
+
+func (c cachedSchoolHolidayChecker) IsSchoolHoliday(t time.Time) bool {
+	return c.cache.get(dayKey(t), func() bool { return c.shc.IsSchoolHoliday(t) })
+}
+
+// SchoolHolidayName forwards to shc if it is a SchoolHolidayNamer
+func (c cachedSchoolHolidayChecker) SchoolHolidayName(t time.Time) string {
+	if namer, ok := c.shc.(SchoolHolidayNamer); ok {
+		return namer.SchoolHolidayName(t)
+	}
+	return ""
+}
+
+// sunKey identifies a variable time on a day in a location
+type sunKey struct {
+	date     int // yyyymmdd
+	location *time.Location
+	event    string
+}
+
+// sunMinutes is a result of variableTimeMinutes
+type sunMinutes struct {
+	minutes int
+	polar   bool
+}
+
+// cachedVariableTimeMinutes returns variableTimeMinutes(t, varType), memoized per day
+func (oh *OpeningHours) cachedVariableTimeMinutes(t time.Time, varType string) (minutes int, polar bool) {
+	key := sunKey{date: dayKey(t), location: t.Location(), event: varType}
+	m := oh.sunCache.get(key, func() sunMinutes {
+		minutes, polar := oh.variableTimeMinutes(t, varType)
+		return sunMinutes{minutes: minutes, polar: polar}
+	})
+	return m.minutes, m.polar
+}
@@ -0,0 +1,82 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// countingHolidayChecker counts IsHoliday calls
+type countingHolidayChecker struct {
+	calls    int
+	holidays map[string]bool
+}
+
+func (c *countingHolidayChecker) IsHoliday(t time.Time) bool {
+	c.calls++
+	return c.holidays[t.Format("2006-01-02")]
+}
+
+func TestDayCache_HolidayCheckedOncePerDay(t *testing.T) {
+	hc := &countingHolidayChecker{holidays: map[string]bool{"2024-12-25": true}}
+	oh, err := New("Mo-Fr 09:00-17:00; PH off", WithHolidayChecker(hc))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	day := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+	for hour := range 24 {
+		if oh.GetState(day.Add(time.Duration(hour) * time.Hour)) {
+			t.Fatalf("expected closed on Christmas at %02d:00", hour)
+		}
+	}
+	if hc.calls != 1 {
+		t.Errorf("IsHoliday called %d times, want 1", hc.calls)
+	}
+
+	oh.ClearCache()
+	oh.GetState(day.Add(12 * time.Hour))
+	if hc.calls != 2 {
+		t.Errorf("IsHoliday called %d times after ClearCache, want 2", hc.calls)
+	}
+
+	// A new checker doesn't see the results of the old one
+	oh.SetHolidayChecker(&countingHolidayChecker{})
+	if !oh.GetState(day.Add(12 * time.Hour)) {
+		t.Error("expected open on Christmas with a checker without holidays")
+	}
+}
+
+func TestDayCache_SunTimesFollowCoordinates(t *testing.T) {
+	oh, err := New("sunrise-sunset", WithCoordinates(52.52, 13.405)) // Berlin
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// At 17:00 UTC in January the sun has set in Berlin but not in Madrid
+	at := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+	if oh.GetState(at) {
+		t.Fatal("expected closed after sunset in Berlin")
+	}
+	oh.SetCoordinates(40.4168, -3.7038) // Madrid
+	if !oh.GetState(at) {
+		t.Error("expected open before sunset in Madrid after SetCoordinates")
+	}
+
+	clone := oh.Clone()
+	clone.SetCoordinates(52.52, 13.405)
+	if clone.GetState(at) == oh.GetState(at) {
+		t.Error("expected the clone to have its own sun times")
+	}
+}
+
+func TestDayCache_Nil(t *testing.T) {
+	var c *dayCache[int, bool]
+	calls := 0
+	for range 2 {
+		c.get(1, func() bool { calls++; return true })
+	}
+	c.clear()
+	if calls != 2 {
+		t.Errorf("compute called %d times, want 2 without a cache", calls)
+	}
+}
@@ -0,0 +1,11 @@
+// Package openinghours implements the OpenStreetMap opening_hours specification.
+//
+// The exported API follows semantic versioning. The recorded API surface in
+// testdata/api.txt is checked by TestAPICompatibility; after an intended,
+// compatible addition regenerate it with "go generate".
+package openinghours
+
+//go:generate go test -run TestAPICompatibility -update-api
+
+// Version is the semantic version of this module
+const Version = "1.0.0"
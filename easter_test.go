@@ -437,3 +437,88 @@ func TestEaster_EdgeCases(t *testing.T) {
 		t.Errorf("expected closed on March 30, 2024 at 12:00 (Easter -1 day), got open")
 	}
 }
+
+// TestEaster_FixedDateEndpoint tests Easter ranges starting or ending on a fixed date
+func TestEaster_FixedDateEndpoint(t *testing.T) {
+	// Easter 2024: March 31, Easter 2025: April 20
+	tests := []struct {
+		value string
+		date  time.Time
+		want  bool
+		desc  string
+	}{
+		{"Mo-Su 10:00-18:00; Jan 06-easter off", time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC), true, "day before the range"},
+		{"Mo-Su 10:00-18:00; Jan 06-easter off", time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC), false, "first day"},
+		{"Mo-Su 10:00-18:00; Jan 06-easter off", time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), false, "Easter 2024"},
+		{"Mo-Su 10:00-18:00; Jan 06-easter off", time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), true, "Easter Monday 2024"},
+		{"Mo-Su 10:00-18:00; Jan 06-easter off", time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC), false, "before Easter 2025"},
+		{"Mo-Su 10:00-18:00; Apr 01-easter +1 day off", time.Date(2025, 4, 21, 12, 0, 0, 0, time.UTC), false, "Easter Monday 2025"},
+		{"Mo-Su 10:00-18:00; Apr 01-easter +1 day off", time.Date(2025, 4, 22, 12, 0, 0, 0, time.UTC), true, "after Easter Monday 2025"},
+		{"Mo-Su 10:00-18:00; easter -2 days-Oct 31 off", time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC), false, "Good Friday 2024"},
+		{"Mo-Su 10:00-18:00; easter -2 days-Oct 31 off", time.Date(2024, 3, 28, 12, 0, 0, 0, time.UTC), true, "before Good Friday 2024"},
+		{"Mo-Su 10:00-18:00; easter -2 days-Oct 31 off", time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC), true, "after the range"},
+		// A fixed start after Easter ends at Easter of the next year
+		{"Mo-Su 10:00-18:00; Oct 01-easter off", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), false, "winter"},
+		{"Mo-Su 10:00-18:00; Oct 01-easter off", time.Date(2025, 4, 20, 12, 0, 0, 0, time.UTC), false, "Easter 2025"},
+		{"Mo-Su 10:00-18:00; Oct 01-easter off", time.Date(2025, 4, 21, 12, 0, 0, 0, time.UTC), true, "Easter Monday 2025"},
+		// A fixed start Easter can come before is empty in those years
+		{"Mo-Su 10:00-18:00; Apr 01-easter off", time.Date(2024, 4, 5, 12, 0, 0, 0, time.UTC), true, "after Easter 2024 in March"},
+		{"Mo-Su 10:00-18:00; Apr 01-easter off", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true, "winter 2024"},
+		{"Mo-Su 10:00-18:00; Apr 01-easter off", time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC), false, "before Easter 2025"},
+		{"Mo-Su 10:00-18:00; easter-Apr 10 off", time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC), true, "before Easter 2025 in late April"},
+		{"Mo-Su 10:00-18:00; easter-Apr 10 off", time.Date(2024, 4, 10, 12, 0, 0, 0, time.UTC), false, "Apr 10, 2024"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%q on %s (%s): got %v, want %v", tt.value, tt.date.Format("2006-01-02"), tt.desc, got, tt.want)
+		}
+	}
+
+	// In 2024 the range is empty, so the next change is the daily one
+	oh, err := New("Mo-Su 10:00-18:00; Apr 01-easter off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	at := time.Date(2024, 4, 5, 12, 0, 0, 0, time.UTC)
+	if got, want := oh.GetNextChange(at), time.Date(2024, 4, 5, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("GetNextChange(%v) = %v, want %v", at, got, want)
+	}
+	at = time.Date(2025, 3, 31, 20, 0, 0, 0, time.UTC)
+	if got, want := oh.GetNextChange(at), time.Date(2025, 4, 21, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("GetNextChange(%v) = %v, want %v", at, got, want)
+	}
+
+	prettify := map[string]string{
+		"Jan 06-easter off":                 "Jan 06-easter off",
+		"Jan 6 - easter off":                "Jan 06-easter off",
+		"Apr 01-easter +1 day off":          "Apr 01-easter +1 day off",
+		"easter -2 days-Oct 31 off":         "easter -2 days-Oct 31 off",
+		"2024 easter-Oct 31 Sa 10:00-12:00": "2024 easter-Oct 31 Sa 10:00-12:00",
+	}
+	for value, want := range prettify {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if got := oh.PrettifyValue(); got != want {
+			t.Errorf("%q: PrettifyValue = %q, want %q", value, got, want)
+		}
+	}
+
+	oh, err = New("Jan 06-easter off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	want := EasterRange{Date: "easter", StartMonth: time.January, StartDay: 6}
+	if got := oh.Rules()[0].Easter; got == nil || *got != want {
+		t.Errorf("Easter = %+v, want %+v", got, want)
+	}
+	if got, want := oh.GetHumanReadable(), "Closed from January 6 to Easter"; got != want {
+		t.Errorf("GetHumanReadable = %q, want %q", got, want)
+	}
+}
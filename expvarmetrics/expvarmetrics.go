@@ -0,0 +1,32 @@
+// Package expvarmetrics publishes the counters of the openinghours package as
+// an expvar.Map, served as JSON under /debug/vars by the expvar package:
+//
+//	openinghours.SetMetrics(expvarmetrics.New("opening_hours"))
+//
+// It is a separate package because importing expvar registers the /debug/vars
+// handler on http.DefaultServeMux.
+package expvarmetrics
+
+import (
+	"expvar"
+
+	openinghours "github.com/Daquisu/opening_hours.go"
+)
+
+// Metrics is an openinghours.Metrics implementation that counts into an
+// expvar.Map
+type Metrics struct {
+	vars *expvar.Map
+}
+
+// New publishes an expvar.Map with the given name and returns Metrics that
+// count into it. Like expvar.Publish, it panics if the name is already in use,
+// so it should be called once.
+func New(name string) *Metrics {
+	return &Metrics{vars: expvar.NewMap(name)}
+}
+
+// Inc increments the expvar counter named after c
+func (m *Metrics) Inc(c openinghours.Counter) {
+	m.vars.Add(c.String(), 1)
+}
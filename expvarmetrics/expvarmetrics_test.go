@@ -0,0 +1,25 @@
+package expvarmetrics
+
+import (
+	"expvar"
+	"testing"
+
+	openinghours "github.com/Daquisu/opening_hours.go"
+)
+
+// Metrics must be usable as the metrics receiver of openinghours
+var _ openinghours.Metrics = (*Metrics)(nil)
+
+func TestMetrics(t *testing.T) {
+	openinghours.SetMetrics(New("opening_hours_test"))
+	defer openinghours.SetMetrics(nil)
+
+	if _, err := openinghours.New("Mo-Fr 09:00-17:00"); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	vars := expvar.Get("opening_hours_test").(*expvar.Map)
+	if got := vars.Get("parses"); got == nil || got.String() != "1" {
+		t.Errorf("parses = %v, want 1", got)
+	}
+}
@@ -173,3 +173,221 @@ func TestFallback_NoFallback(t *testing.T) {
 		t.Errorf("Mo 18:00: should be closed")
 	}
 }
+
+func TestFallback_KeywordGroups(t *testing.T) {
+	// From the fallback section of the opening_hours.js test suite: single-keyword
+	// fallback groups resolve an unknown primary rule
+	monday := time.Date(2012, 10, 1, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2012, 10, 6, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		monday   string
+		saturday string
+	}{
+		{"Mo-Fr 09:00-17:00 unknown || closed", "closed", "closed"},
+		{"Mo-Fr 09:00-17:00 unknown || off", "closed", "closed"},
+		{"Mo-Fr 09:00-17:00 unknown || Closed", "closed", "closed"},
+		{"Mo-Fr 09:00-17:00 unknown || open", "open", "open"},
+		{"Mo-Fr 09:00-17:00 unknown || 24/7", "open", "open"},
+		{"Mo-Fr 09:00-17:00 || unknown", "open", "unknown"},
+		{"Mo-Fr 09:00-17:00 unknown || unknown || closed", "closed", "closed"},
+		{"Mo-Fr 09:00-17:00 unknown || Sa 10:00-14:00 || closed", "closed", "open"},
+		{"unknown", "unknown", "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := oh.GetStateString(monday); got != tt.monday {
+				t.Errorf("Monday 12:00: got %q, want %q", got, tt.monday)
+			}
+			if got := oh.GetStateString(saturday); got != tt.saturday {
+				t.Errorf("Saturday 12:00: got %q, want %q", got, tt.saturday)
+			}
+		})
+	}
+}
+
+func TestFallback_KeywordGroupComment(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00 unknown || closed \"call us\"")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// The fallback resolves the unknown primary rule and provides the comment
+	monday := time.Date(2012, 10, 1, 12, 0, 0, 0, time.UTC)
+	if got := oh.GetStateString(monday); got != "closed" {
+		t.Errorf("Monday 12:00: got %q, want %q", got, "closed")
+	}
+	if got := oh.GetComment(monday); got != "call us" {
+		t.Errorf("Monday 12:00: GetComment = %q, want %q", got, "call us")
+	}
+}
+
+func TestFallback_OutsideTimesOfMatchedDay(t *testing.T) {
+	// The primary rule claims Monday, but a fallback group applies wherever no
+	// primary rule matches, like in opening_hours.js
+	oh, err := New(`Mo 10:00-12:00 || unknown "call us"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		hour    int
+		state   string
+		comment string
+	}{
+		{9, "unknown", "call us"},
+		{11, "open", ""},
+		{14, "unknown", "call us"},
+	}
+	for _, tt := range tests {
+		at := time.Date(2024, 1, 15, tt.hour, 0, 0, 0, time.UTC) // Monday
+		if got := oh.GetStateString(at); got != tt.state {
+			t.Errorf("Mo %02d:00: GetStateString = %q, want %q", tt.hour, got, tt.state)
+		}
+		if got := oh.GetState(at); got != (tt.state == "open") {
+			t.Errorf("Mo %02d:00: GetState = %v, disagrees with %q", tt.hour, got, tt.state)
+		}
+		if got := oh.GetUnknown(at); got != (tt.state == "unknown") {
+			t.Errorf("Mo %02d:00: GetUnknown = %v, disagrees with %q", tt.hour, got, tt.state)
+		}
+		if got := oh.GetComment(at); got != tt.comment {
+			t.Errorf("Mo %02d:00: GetComment = %q, want %q", tt.hour, got, tt.comment)
+		}
+	}
+}
+
+func TestFallback_CommentOnly(t *testing.T) {
+	// A rule with only a comment is unknown, like in opening_hours.js
+	oh, err := New(`Mo-Fr 10:00-16:00 || "call us"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		time    time.Time
+		state   string
+		comment string
+	}{
+		{time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), "open", ""},
+		{time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC), "unknown", "call us"},
+		{time.Date(2024, 1, 20, 11, 0, 0, 0, time.UTC), "unknown", "call us"},
+	}
+	for _, tt := range tests {
+		if got := oh.GetStateString(tt.time); got != tt.state {
+			t.Errorf("%s: GetStateString = %q, want %q", tt.time.Format("Mon 15:04"), got, tt.state)
+		}
+		if got := oh.GetComment(tt.time); got != tt.comment {
+			t.Errorf("%s: GetComment = %q, want %q", tt.time.Format("Mon 15:04"), got, tt.comment)
+		}
+	}
+	if got := oh.PrettifyValue(); got != `Mo-Fr 10:00-16:00 || "call us"` {
+		t.Errorf("PrettifyValue = %q, want the comment-only fallback kept", got)
+	}
+
+	oh, err = New(`"by appointment"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	at := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	if got := oh.GetStateString(at); got != "unknown" {
+		t.Errorf("\"by appointment\": GetStateString = %q, want \"unknown\"", got)
+	}
+}
+
+func TestFallback_ResolvingRuleComment(t *testing.T) {
+	// The rule that resolves an unknown rule keeps its own comment
+	oh, err := New(`Mo-Fr unknown "call" || Mo-Fr 10:00-12:00 "mornings" || Mo-Fr 12:00-14:00`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		hour    int
+		state   string
+		comment string
+	}{
+		{11, "open", "mornings"},
+		{13, "open", "call"},
+		{18, "unknown", "call"},
+	}
+	for _, tt := range tests {
+		at := time.Date(2024, 1, 15, tt.hour, 0, 0, 0, time.UTC) // Monday
+		if got := oh.GetStateString(at); got != tt.state {
+			t.Errorf("Mo %02d:00: GetStateString = %q, want %q", tt.hour, got, tt.state)
+		}
+		if got := oh.GetComment(at); got != tt.comment {
+			t.Errorf("Mo %02d:00: GetComment = %q, want %q", tt.hour, got, tt.comment)
+		}
+	}
+}
+
+func TestFallback_ChainConsistency(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00 unknown || Mo-Fr 10:00-12:00 "mornings" || Mo-Fr 12:00-16:00 off "afternoons"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time { return monday.Add(time.Duration(hour*60+minute) * time.Minute) }
+
+	// Each level of the chain resolves the unknown primary rule where it matches
+	tests := []struct {
+		time    time.Time
+		state   string
+		comment string
+		rule    int
+	}{
+		{at(9, 30), "unknown", "", 0},
+		{at(11, 0), "open", "mornings", 1},
+		{at(13, 0), "closed", "afternoons", 2},
+		{at(16, 30), "unknown", "", 0},
+		{at(18, 0), "closed", "", -1},
+	}
+	for _, tt := range tests {
+		if got := oh.GetStateString(tt.time); got != tt.state {
+			t.Errorf("%s: GetStateString = %q, want %q", tt.time.Format("15:04"), got, tt.state)
+		}
+		if got := oh.GetComment(tt.time); got != tt.comment {
+			t.Errorf("%s: GetComment = %q, want %q", tt.time.Format("15:04"), got, tt.comment)
+		}
+		if got := oh.GetMatchingRule(tt.time); got != tt.rule {
+			t.Errorf("%s: GetMatchingRule = %d, want %d", tt.time.Format("15:04"), got, tt.rule)
+		}
+	}
+
+	// Intervals, durations and changes follow the same evaluation
+	intervals := oh.GetOpenIntervals(monday, monday.Add(24*time.Hour))
+	want := []Interval{
+		{Start: at(9, 0), End: at(10, 0), State: StateUnknown, Unknown: true},
+		{Start: at(10, 0), End: at(12, 0), Comment: "mornings"},
+		{Start: at(16, 0), End: at(17, 0), State: StateUnknown, Unknown: true},
+	}
+	if len(intervals) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %v", len(intervals), len(want), intervals)
+	}
+	for i := range want {
+		if intervals[i] != want[i] {
+			t.Errorf("interval %d = %+v, want %+v", i, intervals[i], want[i])
+		}
+	}
+
+	open, unknown := oh.GetOpenDuration(monday, monday.Add(24*time.Hour))
+	if open != 2*time.Hour || unknown != 2*time.Hour {
+		t.Errorf("GetOpenDuration = %v open, %v unknown, want 2h0m0s each", open, unknown)
+	}
+
+	changes := []time.Time{at(9, 0), at(10, 0), at(12, 0), at(16, 0), at(17, 0)}
+	from := monday
+	for _, want := range changes {
+		if got := oh.GetNextChange(from); !got.Equal(want) {
+			t.Errorf("GetNextChange(%s) = %s, want %s", from.Format("15:04"), got.Format("15:04"), want.Format("15:04"))
+		}
+		from = want
+	}
+}
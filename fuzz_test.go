@@ -0,0 +1,36 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// FuzzNew checks that New neither panics nor hangs on arbitrary input, and
+// that parsed values can be evaluated and prettified. Run it with
+// go test -fuzz=FuzzNew.
+func FuzzNew(f *testing.F) {
+	for _, seed := range []string{
+		"24/7",
+		"Mo-Fr 09:00-17:00; Sa 10:00-14:00; PH off",
+		"Mo-Fr 08:00-18:00 Sa 09:00-12:00",
+		"Jan-Mar,Oct-Dec Mo-Fr 10:00-16:00, Sa 10:00-12:00",
+		"2024,2026 Dec 24-26 off || \"by appointment\"",
+		"sunrise-(sunset-01:00); Su[1,-1] 10:00+; week 01-53/2 Tu 09:00-12:00",
+		"easter -2 days-easter +1 day off; SH Mo-Fr 10:00-14:00",
+		"Mo-Fr 10:00-16:00/01:30, 18:00-02:00 unknown \"late\"",
+	} {
+		f.Add(seed)
+	}
+
+	at := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, value string) {
+		oh, err := New(value)
+		if err != nil {
+			return
+		}
+		oh.GetState(at)
+		oh.GetNextChange(at)
+		oh.GetOpenIntervals(at, at.AddDate(0, 0, 7))
+		oh.PrettifyValue()
+	})
+}
@@ -0,0 +1,107 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetNextChange_DateWeekAndHolidayRules(t *testing.T) {
+	hc := &mockHolidayChecker{holidays: map[string]bool{"2024-05-01": true}}
+
+	tests := []struct {
+		value    string
+		from     time.Time
+		expected time.Time
+	}{
+		// Month-day range far ahead
+		{"Dec 24-26 10:00-18:00", time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC)},
+		{"Dec 24-26 10:00-18:00", time.Date(2024, 12, 26, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 12, 26, 18, 0, 0, 0, time.UTC)},
+		// A date-only rule changes at midnight
+		{"Mo-Su 00:00-24:00; Dec 25 off", time.Date(2024, 12, 20, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{"Dec 25 off", time.Date(2024, 12, 20, 12, 0, 0, 0, time.UTC), time.Time{}},
+		// Week numbers: week 10 of 2024 starts on Mar 04
+		{"week 10-12 Mo 10:00-12:00", time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)},
+		// Public holidays
+		{"PH 10:00-14:00", time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		// Easter 2025 is on Apr 20
+		{"easter 10:00-12:00", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 4, 20, 10, 0, 0, 0, time.UTC)},
+		// Years
+		{"2025 Mo-Fr 09:00-17:00", time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)},
+		// Fallback rules
+		{"Mo-Fr 09:00-17:00 || Sa 10:00-12:00 unknown", time.Date(2024, 1, 12, 18, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		oh.SetHolidayChecker(hc)
+		if got := oh.GetNextChange(tt.from); !got.Equal(tt.expected) {
+			t.Errorf("%q from %v: GetNextChange = %v, want %v", tt.value, tt.from, got, tt.expected)
+		}
+	}
+}
+
+func TestGetNextChange_MatchesMinuteScan(t *testing.T) {
+	hc := &mockHolidayChecker{holidays: map[string]bool{"2024-03-29": true, "2024-04-01": true}}
+	values := []string{
+		"Mo-Fr 09:00-17:00; PH off",
+		"Mo-Fr 09:00-17:00; PH 10:00-14:00",
+		"Mar 25-Apr 05 Mo-Sa 08:00-12:00",
+		"week 13 Tu 10:00-12:00; easter -2 days off",
+		"Mo-Fr 22:00-02:00",
+	}
+	from := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range values {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		oh.SetHolidayChecker(hc)
+
+		for at := from; at.Before(from.AddDate(0, 0, 13)); {
+			next := oh.GetNextChange(at)
+			if next.IsZero() {
+				t.Fatalf("%q from %v: no change found", value, at)
+			}
+
+			// The state must be constant up to next and differ at next
+			state := oh.GetStateString(at)
+			for m := at.Add(time.Minute); m.Before(next); m = m.Add(time.Minute) {
+				if oh.GetStateString(m) != state {
+					t.Fatalf("%q from %v: missed change at %v, got %v", value, at, m, next)
+				}
+			}
+			if oh.GetStateString(next) == state {
+				t.Fatalf("%q from %v: no change at %v", value, at, next)
+			}
+			at = next
+		}
+	}
+}
+
+func TestGetNextChangeWithMaxDate_DateRules(t *testing.T) {
+	oh, err := New("Dec 24-26 10:00-18:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	from := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	if got := oh.GetNextChangeWithMaxDate(from, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("expected no change before Dec 1, got %v", got)
+	}
+	expected := time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC)
+	if got := oh.GetNextChangeWithMaxDate(from, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)); !got.Equal(expected) {
+		t.Errorf("GetNextChangeWithMaxDate = %v, want %v", got, expected)
+	}
+}
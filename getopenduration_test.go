@@ -350,3 +350,73 @@ func TestGetOpenDuration_WithOffRule(t *testing.T) {
 		t.Errorf("GetOpenDuration with off rule: got unknown duration %v, want %v", unknownDuration, expectedUnknown)
 	}
 }
+
+// minuteScanDuration is the reference implementation that evaluates every minute
+func minuteScanDuration(oh *OpeningHours, from, to time.Time) (openDuration, unknownDuration time.Duration) {
+	for current := from; current.Before(to); current = current.Add(time.Minute) {
+		if oh.GetState(current) {
+			openDuration += time.Minute
+		} else if oh.GetUnknown(current) {
+			unknownDuration += time.Minute
+		}
+	}
+	return openDuration, unknownDuration
+}
+
+func TestGetOpenDuration_MatchesMinuteScan(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	values := []string{
+		"Mo-Fr 09:00-17:00; PH off",
+		"Mo-Fr 08:00-12:00,13:00-17:30; Sa 10:00-14:00 \"short\"; Su off",
+		"Fr-Sa 22:00-03:00; Su 10:00-26:00",
+		"Mo-Su 01:30-02:30; We 02:00-02:15 unknown",
+		"10:00-16:00/01:30",
+		"sunrise-sunset; Mo (sunset-01:00)-23:00",
+		"Su-Tu 11:00-01:00, We-Th 11:00-03:00",
+		"Mo-Fr 09:00-17:00 || \"by appointment\"",
+		"Mo[1] 10:00-12:00; Mar-Oct Sa 18:00+",
+		"24/7; easter -2 days-easter +1 day off",
+	}
+
+	// The ranges cover the DST changes of 2024 in Berlin (Mar 31 and Oct 27)
+	ranges := [][2]time.Time{
+		{time.Date(2024, 3, 25, 0, 0, 0, 0, berlin), time.Date(2024, 4, 8, 0, 0, 0, 0, berlin)},
+		{time.Date(2024, 10, 21, 6, 30, 0, 0, berlin), time.Date(2024, 10, 30, 0, 0, 0, 0, berlin)},
+		{time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, value := range values {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		oh.SetHolidayChecker(&yearlyHolidayChecker{})
+
+		for _, r := range ranges {
+			gotOpen, gotUnknown := oh.GetOpenDuration(r[0], r[1])
+			wantOpen, wantUnknown := minuteScanDuration(oh, r[0], r[1])
+			if gotOpen != wantOpen || gotUnknown != wantUnknown {
+				t.Errorf("%q from %v: got open %v unknown %v, want open %v unknown %v",
+					value, r[0], gotOpen, gotUnknown, wantOpen, wantUnknown)
+			}
+		}
+	}
+}
+
+func BenchmarkGetOpenDurationYear(b *testing.B) {
+	oh, err := New("Mo-Fr 08:00-12:00,13:00-17:30; Sa 10:00-14:00; PH off")
+	if err != nil {
+		b.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&yearlyHolidayChecker{})
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	for b.Loop() {
+		oh.GetOpenDuration(from, to)
+	}
+}
@@ -0,0 +1,175 @@
+package openinghours
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GooglePeriod is an entry of the "periods" array of Google Places opening
+// hours. A period may span midnight, e.g. open on Friday at 22:00 and close on
+// Saturday at 02:00. A business open 24/7 has a single period opening on
+// Sunday at 00:00 without Close.
+type GooglePeriod struct {
+	Open  GoogleTime  `json:"open"`
+	Close *GoogleTime `json:"close,omitempty"`
+}
+
+// GoogleTime is the open or close point of a GooglePeriod. Day is 0=Sunday to
+// 6=Saturday. The time is given as Time ("0930", Places API) or as Hour and
+// Minute (Places API (New)); both are set by ToGooglePeriods, and Time takes
+// precedence when reading.
+type GoogleTime struct {
+	Day    int    `json:"day"`
+	Time   string `json:"time,omitempty"`
+	Hour   int    `json:"hour"`
+	Minute int    `json:"minute"`
+}
+
+// newGoogleTime returns the GoogleTime of t
+func newGoogleTime(t time.Time) GoogleTime {
+	return GoogleTime{
+		Day:    int(t.Weekday()),
+		Time:   fmt.Sprintf("%02d%02d", t.Hour(), t.Minute()),
+		Hour:   t.Hour(),
+		Minute: t.Minute(),
+	}
+}
+
+// minutes returns the minutes since midnight of gt
+func (gt GoogleTime) minutes() (int, error) {
+	if gt.Day < 0 || gt.Day > 6 {
+		return 0, fmt.Errorf("invalid day: %d", gt.Day)
+	}
+	hour, minute := gt.Hour, gt.Minute
+	if gt.Time != "" {
+		if len(gt.Time) != 4 {
+			return 0, fmt.Errorf("invalid time: %s", gt.Time)
+		}
+		h, errH := strconv.Atoi(gt.Time[:2])
+		m, errM := strconv.Atoi(gt.Time[2:])
+		if errH != nil || errM != nil {
+			return 0, fmt.Errorf("invalid time: %s", gt.Time)
+		}
+		hour, minute = h, m
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour*60+minute > 24*60 {
+		return 0, fmt.Errorf("invalid time: %02d:%02d", hour, minute)
+	}
+	return hour*60 + minute, nil
+}
+
+// ToGooglePeriods exports the week starting at the day of weekStart as Google
+// Places periods. Hours spanning midnight become a single period, including
+// hours spanning the end of the week into its start, and a week open all the
+// time becomes the 24/7 period. Comments are dropped; unknown states cannot be
+// represented and return an error.
+func (oh *OpeningHours) ToGooglePeriods(weekStart time.Time) ([]GooglePeriod, error) {
+	weekStart = oh.inLocation(weekStart)
+	from := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
+	to := from.AddDate(0, 0, 7)
+
+	intervals := oh.GetOpenIntervals(from, to)
+	for _, iv := range intervals {
+		if iv.Unknown {
+			return nil, fmt.Errorf("unknown state cannot be represented in Google periods: %s", iv.Start.Format("2006-01-02 15:04"))
+		}
+	}
+	if len(intervals) == 0 {
+		return nil, nil
+	}
+	if len(intervals) == 1 && intervals[0].Start.Equal(from) && intervals[0].End.Equal(to) {
+		return []GooglePeriod{{Open: GoogleTime{Day: 0, Time: "0000"}}}, nil
+	}
+
+	// Hours spanning the end of the week continue at its start
+	last := len(intervals) - 1
+	var wrapEnd time.Time
+	if last > 0 && intervals[0].Start.Equal(from) && intervals[last].End.Equal(to) {
+		wrapEnd = intervals[0].End
+		intervals = intervals[1:]
+		last--
+	}
+
+	periods := make([]GooglePeriod, 0, len(intervals))
+	for i, iv := range intervals {
+		end := iv.End
+		if i == last && !wrapEnd.IsZero() {
+			end = wrapEnd
+		}
+		closeTime := newGoogleTime(end)
+		periods = append(periods, GooglePeriod{Open: newGoogleTime(iv.Start), Close: &closeTime})
+	}
+	return periods, nil
+}
+
+// FromGooglePeriods builds OpeningHours from Google Places periods. Periods
+// spanning midnight become ranges like "Fr 22:00-02:00"; periods longer than a
+// day are split at midnight. The single period without Close means 24/7.
+func FromGooglePeriods(periods []GooglePeriod, opts ...Option) (*OpeningHours, error) {
+	if len(periods) == 1 && periods[0].Close == nil {
+		start, err := periods[0].Open.minutes()
+		if err != nil {
+			return nil, err
+		}
+		if start != 0 {
+			return nil, fmt.Errorf("period without close must open at 00:00")
+		}
+		return New("24/7", opts...)
+	}
+
+	type span struct{ start, end int }
+	var days [7][]span
+	for _, p := range periods {
+		if p.Close == nil {
+			return nil, fmt.Errorf("period without close must be the only period")
+		}
+		start, err := p.Open.minutes()
+		if err != nil {
+			return nil, err
+		}
+		end, err := p.Close.minutes()
+		if err != nil {
+			return nil, err
+		}
+
+		// Duration within the week; a period closing when it opens lasts a week
+		duration := ((p.Close.Day-p.Open.Day+7)%7)*24*60 + end - start
+		if duration <= 0 {
+			duration += 7 * 24 * 60
+		}
+
+		day := p.Open.Day
+		if start+duration <= 24*60 || duration < 24*60 {
+			days[day] = append(days[day], span{start, start + duration})
+			continue
+		}
+		for duration > 0 {
+			length := min(duration, 24*60-start)
+			days[day] = append(days[day], span{start, start + length})
+			duration -= length
+			day = (day + 1) % 7
+			start = 0
+		}
+	}
+
+	var week [7][]string
+	for wd, spans := range days {
+		slices.SortFunc(spans, func(a, b span) int { return a.start - b.start })
+		for _, s := range spans {
+			end := s.end
+			if end > 24*60 {
+				end -= 24 * 60
+			}
+			week[wd] = append(week[wd], fmt.Sprintf("%02d:%02d-%02d:%02d", s.start/60, s.start%60, end/60, end%60))
+		}
+	}
+
+	rules := weekRules(week, "")
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no opening hours in periods")
+	}
+	return New(strings.Join(rules, ", "), opts...)
+}
@@ -0,0 +1,135 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// googleWeek starts on Monday, Jan 15 2024
+var googleWeek = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+func TestToGooglePeriods(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Mo 09:00-17:00",
+			`[{"open":{"day":1,"time":"0900","hour":9,"minute":0},"close":{"day":1,"time":"1700","hour":17,"minute":0}}]`},
+		// Midnight-spanning hours are a single period
+		{"Fr 22:00-02:00",
+			`[{"open":{"day":5,"time":"2200","hour":22,"minute":0},"close":{"day":6,"time":"0200","hour":2,"minute":0}}]`},
+		{"Sa 00:00-24:00",
+			`[{"open":{"day":6,"time":"0000","hour":0,"minute":0},"close":{"day":0,"time":"0000","hour":0,"minute":0}}]`},
+		// Hours spanning the end of the week continue at its start
+		{"Su 20:00-03:00",
+			`[{"open":{"day":0,"time":"2000","hour":20,"minute":0},"close":{"day":1,"time":"0300","hour":3,"minute":0}}]`},
+		{"24/7", `[{"open":{"day":0,"time":"0000","hour":0,"minute":0}}]`},
+		{"off", `null`},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		periods, err := oh.ToGooglePeriods(googleWeek)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.value, err)
+		}
+		data, err := json.Marshal(periods)
+		if err != nil {
+			t.Fatalf("%q: failed to marshal: %v", tc.value, err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("%q:\n got %s\nwant %s", tc.value, data, tc.expected)
+		}
+	}
+
+	oh, err := New("Mo 10:00-12:00 unknown")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if _, err := oh.ToGooglePeriods(googleWeek); err == nil {
+		t.Errorf("expected an error for unknown state")
+	}
+}
+
+func TestFromGooglePeriods(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected string
+	}{
+		{`[{"open":{"day":1,"time":"0900"},"close":{"day":1,"time":"1700"}},{"open":{"day":2,"time":"0900"},"close":{"day":2,"time":"1700"}}]`,
+			"Mo-Tu 09:00-17:00"},
+		// Places API (New) format
+		{`[{"open":{"day":5,"hour":22,"minute":30},"close":{"day":6,"hour":2,"minute":0}}]`,
+			"Fr 22:30-02:00"},
+		{`[{"open":{"day":6,"time":"0000"},"close":{"day":0,"time":"0000"}}]`,
+			"Sa 00:00-24:00"},
+		// Periods longer than a day are split at midnight
+		{`[{"open":{"day":5,"time":"1800"},"close":{"day":0,"time":"0200"}}]`,
+			"Fr 18:00-24:00, Sa 00:00-24:00, Su 00:00-02:00"},
+		{`[{"open":{"day":0,"time":"0000"}}]`, "24/7"},
+	}
+
+	for _, tc := range testCases {
+		var periods []GooglePeriod
+		if err := json.Unmarshal([]byte(tc.data), &periods); err != nil {
+			t.Fatalf("%s: failed to unmarshal: %v", tc.data, err)
+		}
+		oh, err := FromGooglePeriods(periods)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.data, err)
+		}
+		if got := oh.PrettifyValue(); got != tc.expected {
+			t.Errorf("%s: PrettifyValue = %q, want %q", tc.data, got, tc.expected)
+		}
+	}
+}
+
+func TestFromGooglePeriods_Errors(t *testing.T) {
+	close := &GoogleTime{Day: 1, Time: "1700"}
+	testCases := [][]GooglePeriod{
+		nil,
+		{{Open: GoogleTime{Day: 1, Time: "0900"}}},
+		{{Open: GoogleTime{Day: 7, Time: "0900"}, Close: close}},
+		{{Open: GoogleTime{Day: 1, Time: "9:00"}, Close: close}},
+		{{Open: GoogleTime{Day: 1, Time: "0900"}, Close: close}, {Open: GoogleTime{Day: 0, Time: "0000"}}},
+	}
+
+	for _, periods := range testCases {
+		if _, err := FromGooglePeriods(periods); err == nil {
+			t.Errorf("%+v: expected an error", periods)
+		}
+	}
+}
+
+func TestGooglePeriods_RoundTrip(t *testing.T) {
+	for _, value := range []string{
+		"Mo-Fr 09:00-12:00,13:00-17:00; Sa 10:00-14:00",
+		"Mo-Th 18:00-01:00; Fr-Sa 18:00-03:00",
+		"Su 20:00-03:00",
+		"Sa-Su 00:00-24:00",
+	} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", value, err)
+		}
+		periods, err := oh.ToGooglePeriods(googleWeek)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", value, err)
+		}
+		back, err := FromGooglePeriods(periods)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", value, err)
+		}
+
+		for at := googleWeek; at.Before(googleWeek.AddDate(0, 0, 14)); at = at.Add(30 * time.Minute) {
+			if oh.GetState(at) != back.GetState(at) {
+				t.Errorf("%q: state differs at %v, round trip %q", value, at, back.PrettifyValue())
+				break
+			}
+		}
+	}
+}
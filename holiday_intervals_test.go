@@ -0,0 +1,121 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// holidayIntervalsOptions sets up Christmas 2024 (Wednesday) as the only
+// public holiday and Dec 23-24, 27-28 as school holidays
+func holidayIntervalsOptions() []Option {
+	return []Option{
+		WithHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}}),
+		WithSchoolHolidayChecker(&mockSchoolHolidayChecker{holidays: map[string]bool{
+			"2024-12-23": true, "2024-12-24": true, "2024-12-27": true, "2024-12-28": true,
+		}}),
+	}
+}
+
+func TestHolidayIntervals(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2024, 12, d, hour, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		value string
+		want  [][2]time.Time
+	}{
+		{
+			"Mo-Fr 09:00-17:00; PH off; SH 10:00-14:00",
+			[][2]time.Time{
+				{day(23, 10), day(23, 14)},
+				{day(24, 10), day(24, 14)},
+				{day(26, 9), day(26, 17)},
+				{day(27, 10), day(27, 14)},
+				{day(28, 10), day(28, 14)},
+			},
+		},
+		{
+			// SH Mo-Fr leaves the Saturday of school holidays to the Sa rule,
+			// and without a PH rule Christmas is a regular Wednesday
+			"Mo-Fr 09:00-17:00; Sa 10:00-12:00; SH Mo-Fr 10:00-14:00",
+			[][2]time.Time{
+				{day(23, 10), day(23, 14)},
+				{day(24, 10), day(24, 14)},
+				{day(25, 9), day(25, 17)},
+				{day(26, 9), day(26, 17)},
+				{day(27, 10), day(27, 14)},
+				{day(28, 10), day(28, 12)},
+			},
+		},
+		{
+			// A school holiday without an SH rule is a regular day
+			"Mo-Fr 09:00-17:00; PH off",
+			[][2]time.Time{
+				{day(23, 9), day(23, 17)},
+				{day(24, 9), day(24, 17)},
+				{day(26, 9), day(26, 17)},
+				{day(27, 9), day(27, 17)},
+			},
+		},
+		{
+			// The range of the day before the holiday ends on the holiday
+			"Mo-Fr 09:00-17:00; PH off; PH +1 day 12:00-14:00; PH -1 day 20:00-02:00",
+			[][2]time.Time{
+				{day(23, 9), day(23, 17)},
+				{day(24, 20), day(25, 2)},
+				{day(26, 12), day(26, 14)},
+				{day(27, 9), day(27, 17)},
+			},
+		},
+		{
+			"PH 20:00-02:00",
+			[][2]time.Time{{day(25, 20), day(26, 2)}},
+		},
+		{
+			"Dec 24 20:00-02:00",
+			[][2]time.Time{{day(24, 20), day(25, 2)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value, holidayIntervalsOptions()...)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			got := oh.GetOpenIntervals(day(22, 0), day(29, 0))
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetOpenIntervals() = %v, want %d intervals", got, len(tt.want))
+			}
+			for i, iv := range got {
+				if !iv.Start.Equal(tt.want[i][0]) || !iv.End.Equal(tt.want[i][1]) {
+					t.Errorf("interval %d = %v-%v, want %v-%v", i, iv.Start, iv.End, tt.want[i][0], tt.want[i][1])
+				}
+			}
+
+			// GetNextChange stops at the same boundaries
+			at := day(22, 0)
+			for _, want := range tt.want {
+				for _, boundary := range want {
+					at = oh.GetNextChange(at)
+					if !at.Equal(boundary) {
+						t.Fatalf("GetNextChange() = %v, want %v", at, boundary)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestHolidayIntervals_StartAtMidnight checks that ranges starting at 24:00
+// don't carry over from day to day endlessly
+func TestHolidayIntervals_StartAtMidnight(t *testing.T) {
+	for _, value := range []string{"24:00-02:00", "Dec 24 24:00-02:00", "PH 24:00-02:00"} {
+		oh, err := New(value, holidayIntervalsOptions()...)
+		if err != nil {
+			t.Fatalf("New(%q): unexpected parse error: %v", value, err)
+		}
+		at := time.Date(2024, 12, 25, 1, 0, 0, 0, time.UTC)
+		oh.GetState(at)
+		oh.GetOpenIntervals(at, at.AddDate(0, 0, 2))
+	}
+}
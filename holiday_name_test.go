@@ -0,0 +1,63 @@
+package openinghours
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// namedHolidays is a HolidayChecker and HolidayNamer keyed by date
+type namedHolidays map[string]string
+
+func (h namedHolidays) IsHoliday(t time.Time) bool {
+	_, ok := h[t.Format("2006-01-02")]
+	return ok
+}
+
+func (h namedHolidays) HolidayName(t time.Time) (string, bool) {
+	name, ok := h[t.Format("2006-01-02")]
+	return name, ok
+}
+
+func (h namedHolidays) IsHolidayCtx(_ context.Context, t time.Time) bool {
+	return h.IsHoliday(t)
+}
+
+func TestHolidayName_Comment(t *testing.T) {
+	hc := namedHolidays{"2024-03-29": "Karfreitag", "2024-04-01": "Ostermontag"}
+	tests := []struct {
+		value string
+		time  time.Time
+		want  string
+	}{
+		{"Mo-Fr 09:00-17:00; PH off", time.Date(2024, 3, 29, 10, 0, 0, 0, time.UTC), "Karfreitag"},
+		{"Mo-Fr 09:00-17:00; PH off", time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC), ""},
+		{"Mo-Fr 09:00-17:00; PH 10:00-12:00", time.Date(2024, 4, 1, 11, 0, 0, 0, time.UTC), "Ostermontag"},
+		// A comment of the rule takes precedence
+		{`Mo-Fr 09:00-17:00; PH off "holiday"`, time.Date(2024, 3, 29, 10, 0, 0, 0, time.UTC), "holiday"},
+		// The day after a holiday is not the holiday
+		{"Mo-Sa 09:00-17:00; PH +1 day off", time.Date(2024, 3, 30, 10, 0, 0, 0, time.UTC), ""},
+		{"Mo-Fr,PH 10:00-12:00", time.Date(2024, 4, 1, 11, 0, 0, 0, time.UTC), "Ostermontag"},
+		{"Mo-Fr,PH 10:00-12:00", time.Date(2024, 4, 2, 11, 0, 0, 0, time.UTC), ""},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value, WithHolidayChecker(hc))
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if got := oh.GetComment(tt.time); got != tt.want {
+			t.Errorf("%q at %s: GetComment = %q, want %q", tt.value, tt.time.Format("2006-01-02"), got, tt.want)
+		}
+	}
+
+	// Context-aware checkers that know names are supported as well
+	oh, err := New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayCheckerCtx(hc)
+	if got := oh.GetCommentCtx(context.Background(), time.Date(2024, 3, 29, 10, 0, 0, 0, time.UTC)); got != "Karfreitag" {
+		t.Errorf("GetCommentCtx = %q, want Karfreitag", got)
+	}
+}
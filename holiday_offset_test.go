@@ -293,10 +293,11 @@ func TestHolidayOffset_MultipleOffsets(t *testing.T) {
 		t.Errorf("expected closed on Jan 2 (Tuesday, day before holiday) at 12:00, got open")
 	}
 
-	// Jan 3, 2024 (the Wednesday holiday) should be closed (no PH rule, but also no other matching rule)
+	// Jan 3, 2024 (the Wednesday holiday) keeps the regular hours: the offset
+	// rules only apply to the days around it
 	holiday := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
-	if oh.GetState(holiday) {
-		t.Errorf("expected closed on Jan 3 (Wednesday holiday) at 12:00, got open")
+	if !oh.GetState(holiday) {
+		t.Errorf("expected open on Jan 3 (Wednesday holiday) at 12:00, got closed")
 	}
 
 	// Jan 4, 2024 (Thursday, day after Wednesday holiday) at 12:00 should be open (PH +1 day hours)
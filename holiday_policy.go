@@ -0,0 +1,84 @@
+package openinghours
+
+import "time"
+
+// HolidayPolicy decides how PH rules are evaluated when no HolidayChecker is set
+type HolidayPolicy int
+
+const (
+	// HolidaysIgnore never matches PH rules, so the regular rules apply on
+	// every day. This is the default.
+	HolidaysIgnore HolidayPolicy = iota
+	// HolidaysUnknown reports the state as unknown on every day a PH rule
+	// could apply to, because the result depends on missing data
+	HolidaysUnknown
+)
+
+// WithHolidayPolicy sets the policy for PH rules without a HolidayChecker
+func WithHolidayPolicy(p HolidayPolicy) Option {
+	return func(oh *OpeningHours) {
+		oh.holidayPolicy = p
+	}
+}
+
+// SetHolidayPolicy sets the policy for PH rules without a HolidayChecker
+func (oh *OpeningHours) SetHolidayPolicy(p HolidayPolicy) {
+	oh.holidayPolicy = p
+}
+
+// RequiresHolidayData reports whether the value references public holidays
+// (PH) but no HolidayChecker is set, or school holidays (SH) but no
+// SchoolHolidayChecker is set. Without the data, PH and SH rules never match
+// unless HolidaysUnknown or SchoolHolidaysUnknown is set.
+func (oh *OpeningHours) RequiresHolidayData() bool {
+	return (oh.holidayChecker == nil && oh.hasPublicHolidayRule()) || oh.NeedsSchoolHolidayChecker()
+}
+
+func (oh *OpeningHours) hasPublicHolidayRule() bool {
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			if r.isPH {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// holidaysUnknown checks if the state at t is unknown because a PH rule could
+// apply to the day but there is no checker to tell (HolidaysUnknown)
+func (oh *OpeningHours) holidaysUnknown(t time.Time) bool {
+	if oh.holidayPolicy != HolidaysUnknown || oh.holidayChecker != nil {
+		return false
+	}
+
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			if r.isPH && r.couldApplyOnPublicHoliday(t, oh) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// couldApplyOnPublicHoliday checks the selectors of a PH rule other than PH
+// itself, e.g. the year of "2024 PH off". Days around holidays like
+// "PH +1 day" may be any day.
+func (r *rule) couldApplyOnPublicHoliday(t time.Time, oh *OpeningHours) bool {
+	other := *r
+	other.isPH, other.phOffset = false, 0
+	if other.holidayUnion {
+		// "Sa,PH" applies on every public holiday, whatever the weekday
+		other.holidayUnion, other.isSH = false, false
+		other.weekdays, other.weekdayConstraints = nil, nil
+	}
+	if other.weekdays == nil && !other.isSH && !other.isEaster && other.monthStart == 0 &&
+		other.yearStart == 0 && len(other.weekConstraints) == 0 && len(other.weekdayConstraints) == 0 {
+		// Plain "PH ..." could apply on any day
+		return true
+	}
+	return other.matchesSelectorWithOH(t, nil, oh)
+}
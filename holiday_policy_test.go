@@ -0,0 +1,89 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHolidayPolicy_Unknown(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		time  time.Time
+		want  string
+	}{
+		{"Mo-Fr 09:00-17:00; PH off", monday, "unknown"},
+		{"Mo-Fr 09:00-17:00", monday, "open"},
+		// Only days the PH rule could apply to are unknown
+		{"Mo-Fr 09:00-17:00; 2025 PH off", monday, "open"},
+		{"Mo-Fr 09:00-17:00; Dec PH off", monday, "open"},
+		{"Mo-Fr 09:00-17:00; Dec PH off", time.Date(2024, 12, 23, 10, 0, 0, 0, time.UTC), "unknown"},
+		{"Mo-Fr 09:00-17:00; PH +1 day off", monday, "unknown"},
+		{"Mo-Fr 09:00-17:00; Sa,PH 10:00-12:00", monday, "unknown"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value, WithHolidayPolicy(HolidaysUnknown))
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		if got := oh.GetStateString(tt.time); got != tt.want {
+			t.Errorf("%q at %v: got %q, want %q", tt.value, tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestHolidayPolicy_DefaultIgnores(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got := oh.GetStateString(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)); got != "open" {
+		t.Errorf("got %q, want open", got)
+	}
+}
+
+func TestHolidayPolicy_UnknownWithChecker(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off", WithHolidayPolicy(HolidaysUnknown))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&jsTestHolidayChecker{holidays: map[string]bool{"2024-01-16": true}})
+
+	if got := oh.GetStateString(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)); got != "open" {
+		t.Errorf("regular Monday: got %q, want open", got)
+	}
+	if got := oh.GetStateString(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)); got != "closed" {
+		t.Errorf("holiday: got %q, want closed", got)
+	}
+}
+
+func TestRequiresHolidayData(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"Mo-Fr 09:00-17:00", false},
+		{"Mo-Fr 09:00-17:00; PH off", true},
+		{"Mo-Fr 09:00-17:00; SH 10:00-12:00", true},
+		{"Mo-Fr 09:00-17:00 || PH unknown", true},
+	}
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		if got := oh.RequiresHolidayData(); got != tt.want {
+			t.Errorf("%q: RequiresHolidayData = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	oh, err := New("Mo-Fr 09:00-17:00; PH off; SH 10:00-12:00",
+		WithHolidayChecker(&jsTestHolidayChecker{}), WithSchoolHolidayChecker(&mockSchoolHolidayChecker{}))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if oh.RequiresHolidayData() {
+		t.Errorf("expected RequiresHolidayData to be false with both checkers")
+	}
+}
@@ -0,0 +1,102 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHolidayUnion_WeekdaysOrPH tests that "Mo-Fr,PH" applies on weekdays and on public holidays
+func TestHolidayUnion_WeekdaysOrPH(t *testing.T) {
+	hc := &mockHolidayChecker{holidays: map[string]bool{
+		"2024-12-25": true, // Wednesday
+		"2024-12-28": true, // Saturday
+	}}
+
+	testCases := []struct {
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		{"Mo-Fr,PH 09:00-17:00", time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC), true},  // Monday
+		{"Mo-Fr,PH 09:00-17:00", time.Date(2024, 12, 28, 12, 0, 0, 0, time.UTC), true},  // Saturday holiday
+		{"Mo-Fr,PH 09:00-17:00", time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), false}, // Saturday
+		{"Mo-Fr,PH 09:00-17:00", time.Date(2024, 12, 28, 18, 0, 0, 0, time.UTC), false},
+		{"PH,Su 10:00-12:00", time.Date(2024, 12, 22, 11, 0, 0, 0, time.UTC), true}, // Sunday
+		{"PH,Su 10:00-12:00", time.Date(2024, 12, 25, 11, 0, 0, 0, time.UTC), true}, // Wednesday holiday
+		{"PH,Su 10:00-12:00", time.Date(2024, 12, 23, 11, 0, 0, 0, time.UTC), false},
+		// The holiday rule takes over holidays from earlier rules
+		{"Mo-Fr 09:00-17:00; PH,Su 10:00-12:00", time.Date(2024, 12, 25, 14, 0, 0, 0, time.UTC), false},
+		{"Mo-Fr 09:00-17:00; PH,Su 10:00-12:00", time.Date(2024, 12, 24, 14, 0, 0, 0, time.UTC), true},
+		{"Mo-Sa 09:00-17:00; Su,PH off", time.Date(2024, 12, 28, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Sa 09:00-17:00; Su,PH off", time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), true},
+		// With a weekday after PH, PH is a prefix and only holidays on that weekday match
+		{"PH Sa 10:00-12:00", time.Date(2024, 12, 28, 11, 0, 0, 0, time.UTC), true},
+		{"PH Sa 10:00-12:00", time.Date(2024, 12, 25, 11, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		oh.SetHolidayChecker(hc)
+		if got := oh.GetState(tc.time); got != tc.expected {
+			t.Errorf("%q at %v: GetState = %v, want %v", tc.value, tc.time, got, tc.expected)
+		}
+	}
+}
+
+// TestHolidayUnion_PHOrSH tests that "PH,SH" applies on public and school holidays
+func TestHolidayUnion_PHOrSH(t *testing.T) {
+	oh, err := New("PH,SH 10:00-12:00")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	oh.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}})
+	oh.SetSchoolHolidayChecker(&mockSchoolHolidayChecker{holidays: map[string]bool{"2024-12-27": true}})
+
+	for day, expected := range map[int]bool{25: true, 26: false, 27: true} {
+		if got := oh.GetState(time.Date(2024, 12, day, 11, 0, 0, 0, time.UTC)); got != expected {
+			t.Errorf("Dec %d: GetState = %v, want %v", day, got, expected)
+		}
+	}
+}
+
+// TestHolidayUnion_NextChange tests that changes on holidays outside the weekdays are found
+func TestHolidayUnion_NextChange(t *testing.T) {
+	oh, err := New("Mo-Fr,PH 09:00-17:00")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	oh.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-28": true}})
+
+	next := oh.GetNextChange(time.Date(2024, 12, 27, 18, 0, 0, 0, time.UTC))
+	expected := time.Date(2024, 12, 28, 9, 0, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("GetNextChange = %v, want %v", next, expected)
+	}
+}
+
+// TestHolidayUnion_Prettify tests that lists and prefixes keep their meaning when prettified
+func TestHolidayUnion_Prettify(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Mo-Fr,PH 09:00-17:00", "Mo-Fr,PH 09:00-17:00"},
+		{"PH,Su 10:00-12:00", "Su,PH 10:00-12:00"},
+		{"PH,SH off", "PH,SH off"},
+		{"PH Su 10:00-12:00", "PH Su 10:00-12:00"},
+		{"SH Mo-Fr 10:00-12:00", "SH Mo-Fr 10:00-12:00"},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		if got := oh.PrettifyValue(); got != tc.expected {
+			t.Errorf("%q: PrettifyValue = %q, want %q", tc.value, got, tc.expected)
+		}
+	}
+}
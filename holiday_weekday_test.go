@@ -0,0 +1,64 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHolidayWeekday tests PH rules constrained by the weekday the holiday (or
+// the day around it) falls on: "PH Mo-Fr" matches holidays that are weekdays
+func TestHolidayWeekday(t *testing.T) {
+	// Dec 25-26, 2024 are Wednesday and Thursday; Dec 25-26, 2022 Sunday and Monday
+	hc := &mockHolidayChecker{holidays: map[string]bool{
+		"2022-12-25": true, "2022-12-26": true,
+		"2024-12-25": true, "2024-12-26": true,
+	}}
+
+	tests := []struct {
+		value string
+		date  string
+		want  string
+	}{
+		{"PH Mo-Fr 10:00-14:00", "2024-12-25 12:00", "open"},
+		{"PH Mo-Fr 10:00-14:00", "2022-12-25 12:00", "closed"},
+		{"PH Mo-Fr 10:00-14:00", "2022-12-26 12:00", "open"},
+		{"PH Sa,Su 10:00-14:00", "2022-12-25 12:00", "open"},
+		{"PH Sa,Su 10:00-14:00", "2024-12-25 12:00", "closed"},
+
+		// Regular rules apply to the holidays the PH rule doesn't select
+		{"Mo-Su 09:00-18:00; PH Mo-Fr off", "2024-12-25 12:00", "closed"},
+		{"Mo-Su 09:00-18:00; PH Mo-Fr off", "2022-12-25 12:00", "open"},
+		{"Mo-Su 09:00-18:00; PH Mo-Fr off", "2022-12-26 12:00", "closed"},
+		{"Mo-Su 09:00-18:00; PH Sa,Su 13:00-14:00", "2022-12-25 12:00", "closed"},
+		{"Mo-Su 09:00-18:00; PH Sa,Su 13:00-14:00", "2022-12-25 13:30", "open"},
+		{"Mo-Su 09:00-18:00; PH Sa,Su 13:00-14:00", "2024-12-25 12:00", "open"},
+		{"Mo-Su 09:00-18:00; PH off; PH Mo-Fr 13:00-14:00", "2022-12-25 13:30", "closed"},
+		{"Mo-Su 09:00-18:00; PH off; PH Mo-Fr 13:00-14:00", "2024-12-25 13:30", "open"},
+
+		// Offsets: the weekday of the day around the holiday counts
+		{"Mo-Su 09:00-18:00; PH +1 day Mo-Fr 13:00-14:00", "2024-12-27 12:00", "closed"},
+		{"Mo-Su 09:00-18:00; PH +1 day Mo-Fr 13:00-14:00", "2024-12-27 13:30", "open"},
+		{"Mo-Su 09:00-18:00; PH +1 day Mo-Fr 13:00-14:00", "2022-12-27 12:00", "closed"},
+		{"Mo-Su 09:00-18:00; PH +1 day Mo-Fr 13:00-14:00", "2024-12-25 12:00", "open"},
+		{"Mo-Su 09:00-18:00; PH +1 day Sa 13:00-14:00", "2024-12-27 12:00", "open"},
+		{"Mo-Su 09:00-18:00; PH -1 day Sa off", "2022-12-24 12:00", "closed"},
+		{"Mo-Su 09:00-18:00; PH -1 day Sa off", "2024-12-24 12:00", "open"},
+
+		// Unions match either: the first Saturday of the month or any holiday
+		{"Sa[1],PH 10:00-14:00", "2024-12-07 12:00", "open"},
+		{"Sa[1],PH 10:00-14:00", "2024-12-14 12:00", "closed"},
+		{"Sa[1],PH 10:00-14:00", "2024-12-25 12:00", "open"},
+		{"Sa[1],PH 10:00-14:00", "2022-12-25 12:00", "open"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value, WithHolidayChecker(hc))
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		at, _ := time.Parse("2006-01-02 15:04", tt.date)
+		if got := oh.GetStateString(at); got != tt.want {
+			t.Errorf("%q at %s: got %s, want %s", tt.value, tt.date, got, tt.want)
+		}
+	}
+}
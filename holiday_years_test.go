@@ -0,0 +1,131 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// yearlyHolidayChecker computes holidays per year, including moving feasts
+// (Good Friday and Easter Monday) and New Year's Eve/Day.
+type yearlyHolidayChecker struct{}
+
+func (c *yearlyHolidayChecker) IsHoliday(t time.Time) bool {
+	if t.Month() == time.January && t.Day() == 1 {
+		return true
+	}
+	if t.Month() == time.December && t.Day() == 31 {
+		return true
+	}
+	easter := calculateEaster(t.Year())
+	for _, offset := range []int{-2, 1} {
+		d := easter.AddDate(0, 0, offset)
+		if t.Month() == d.Month() && t.Day() == d.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHolidayYears_MovingFeasts(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&yearlyHolidayChecker{})
+
+	tests := []struct {
+		date string
+		want bool
+		desc string
+	}{
+		{"2024-03-29", false, "Good Friday 2024"},
+		{"2024-04-01", false, "Easter Monday 2024"},
+		{"2025-04-18", false, "Good Friday 2025"},
+		{"2025-04-21", false, "Easter Monday 2025"},
+		{"2025-03-28", true, "regular Friday 2025 (Good Friday in 2024 was Mar 29)"},
+		{"2024-04-18", true, "regular Thursday 2024 (Good Friday in 2025 is Apr 18)"},
+	}
+
+	for _, tt := range tests {
+		day, _ := time.Parse("2006-01-02", tt.date)
+		at := day.Add(10 * time.Hour)
+		if got := oh.GetState(at); got != tt.want {
+			t.Errorf("%s (%s) at 10:00: got %v, want %v", tt.desc, tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestHolidayYears_YearSelectorWithPH(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; 2024 PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&yearlyHolidayChecker{})
+
+	// Easter Monday 2024 (Apr 1) is covered by "2024 PH off"
+	if oh.GetState(time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Easter Monday 2024: expected closed")
+	}
+	// Easter Monday 2025 (Apr 21) is a holiday, but "2024 PH off" does not apply
+	if !oh.GetState(time.Date(2025, 4, 21, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Easter Monday 2025: expected open, the PH rule is limited to 2024")
+	}
+}
+
+func TestHolidayYears_OffsetAcrossYearBoundary(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off; PH +1 day 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&yearlyHolidayChecker{})
+
+	// Dec 31 and Jan 1 are both holidays, Jan 2 is the day after a holiday
+	// Jan 2, 2025 is Thursday
+	jan2 := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !oh.GetState(jan2.Add(11 * time.Hour)) {
+		t.Errorf("Jan 2, 2025 11:00: expected open (PH +1 day)")
+	}
+	if oh.GetState(jan2.Add(15 * time.Hour)) {
+		t.Errorf("Jan 2, 2025 15:00: expected closed (PH +1 day hours only)")
+	}
+
+	// Jan 1 itself is a holiday, not the day after one
+	if oh.GetState(time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Jan 1, 2025 11:00: expected closed (PH off)")
+	}
+}
+
+func TestHolidayYears_DayBeforeAcrossYearBoundary(t *testing.T) {
+	// Only Jan 1 is a holiday here, so Dec 31 is the day before a holiday
+	// and must be found by querying the next year's date
+	hc := HolidayCheckerFunc(func(t time.Time) bool {
+		return t.Month() == time.January && t.Day() == 1
+	})
+
+	oh, err := New("Mo-Fr 09:00-17:00; PH -1 day 09:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(hc)
+
+	// Dec 31, 2024 is Tuesday
+	if !oh.GetState(time.Date(2024, 12, 31, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Dec 31, 2024 11:00: expected open (PH -1 day)")
+	}
+	if oh.GetState(time.Date(2024, 12, 31, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Dec 31, 2024 14:00: expected closed (PH -1 day hours only)")
+	}
+}
+
+func TestHolidayYears_RegularRulesWithoutPHRule(t *testing.T) {
+	// Without a PH rule, holidays are ordinary days
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&yearlyHolidayChecker{})
+
+	if !oh.GetState(time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Easter Monday 2024: expected open without a PH rule")
+	}
+}
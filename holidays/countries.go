@@ -0,0 +1,216 @@
+package holidays
+
+import "time"
+
+var countries = map[string]country{
+	"AT": {rules: austria},
+	"DE": {
+		regions: []string{"BB", "BE", "BW", "BY", "HB", "HE", "HH", "MV", "NI", "NW", "RP", "SH", "SL", "SN", "ST", "TH"},
+		rules:   germany,
+	},
+	"FR": {
+		// Alsace-Moselle departments with additional holidays
+		regions: []string{"57", "67", "68"},
+		rules:   france,
+	},
+	"GB": {
+		// Without a region the holidays of England and Wales are used
+		regions: []string{"ENG", "NIR", "SCT", "WLS"},
+		rules:   unitedKingdom,
+	},
+	"US": {rules: unitedStates},
+}
+
+// in reports whether region is one of regions
+func in(region string, regions ...string) bool {
+	return containsString(regions, region)
+}
+
+func austria(year int, _ string) []Holiday {
+	e := easter(year)
+	return []Holiday{
+		{date(year, time.January, 1), "Neujahr"},
+		{date(year, time.January, 6), "Heilige Drei Könige"},
+		{e.AddDate(0, 0, 1), "Ostermontag"},
+		{date(year, time.May, 1), "Staatsfeiertag"},
+		{e.AddDate(0, 0, 39), "Christi Himmelfahrt"},
+		{e.AddDate(0, 0, 50), "Pfingstmontag"},
+		{e.AddDate(0, 0, 60), "Fronleichnam"},
+		{date(year, time.August, 15), "Mariä Himmelfahrt"},
+		{date(year, time.October, 26), "Nationalfeiertag"},
+		{date(year, time.November, 1), "Allerheiligen"},
+		{date(year, time.December, 8), "Mariä Empfängnis"},
+		{date(year, time.December, 25), "Christtag"},
+		{date(year, time.December, 26), "Stefanitag"},
+	}
+}
+
+func germany(year int, region string) []Holiday {
+	e := easter(year)
+	hs := []Holiday{
+		{date(year, time.January, 1), "Neujahr"},
+		{e.AddDate(0, 0, -2), "Karfreitag"},
+		{e.AddDate(0, 0, 1), "Ostermontag"},
+		{date(year, time.May, 1), "Tag der Arbeit"},
+		{e.AddDate(0, 0, 39), "Christi Himmelfahrt"},
+		{e.AddDate(0, 0, 50), "Pfingstmontag"},
+		{date(year, time.October, 3), "Tag der Deutschen Einheit"},
+		{date(year, time.December, 25), "1. Weihnachtstag"},
+		{date(year, time.December, 26), "2. Weihnachtstag"},
+	}
+
+	if in(region, "BW", "BY", "ST") {
+		hs = append(hs, Holiday{date(year, time.January, 6), "Heilige Drei Könige"})
+	}
+	if (region == "BE" && year >= 2019) || (region == "MV" && year >= 2023) {
+		hs = append(hs, Holiday{date(year, time.March, 8), "Internationaler Frauentag"})
+	}
+	if region == "BB" {
+		hs = append(hs, Holiday{e, "Ostersonntag"}, Holiday{e.AddDate(0, 0, 49), "Pfingstsonntag"})
+	}
+	if in(region, "BW", "BY", "HE", "NW", "RP", "SL") {
+		hs = append(hs, Holiday{e.AddDate(0, 0, 60), "Fronleichnam"})
+	}
+	if region == "SL" {
+		hs = append(hs, Holiday{date(year, time.August, 15), "Mariä Himmelfahrt"})
+	}
+	if region == "TH" && year >= 2019 {
+		hs = append(hs, Holiday{date(year, time.September, 20), "Weltkindertag"})
+	}
+	// Reformation Day was a nationwide holiday for its 500th anniversary in 2017
+	if year == 2017 || in(region, "BB", "MV", "SN", "ST", "TH") ||
+		(year >= 2018 && in(region, "HB", "HH", "NI", "SH")) {
+		hs = append(hs, Holiday{date(year, time.October, 31), "Reformationstag"})
+	}
+	if in(region, "BW", "BY", "NW", "RP", "SL") {
+		hs = append(hs, Holiday{date(year, time.November, 1), "Allerheiligen"})
+	}
+	if region == "SN" {
+		// Wednesday before November 23
+		d := date(year, time.November, 22)
+		for d.Weekday() != time.Wednesday {
+			d = d.AddDate(0, 0, -1)
+		}
+		hs = append(hs, Holiday{d, "Buß- und Bettag"})
+	}
+	return hs
+}
+
+func france(year int, region string) []Holiday {
+	e := easter(year)
+	hs := []Holiday{
+		{date(year, time.January, 1), "Jour de l'an"},
+		{e.AddDate(0, 0, 1), "Lundi de Pâques"},
+		{date(year, time.May, 1), "Fête du Travail"},
+		{date(year, time.May, 8), "Victoire 1945"},
+		{e.AddDate(0, 0, 39), "Ascension"},
+		{e.AddDate(0, 0, 50), "Lundi de Pentecôte"},
+		{date(year, time.July, 14), "Fête nationale"},
+		{date(year, time.August, 15), "Assomption"},
+		{date(year, time.November, 1), "Toussaint"},
+		{date(year, time.November, 11), "Armistice 1918"},
+		{date(year, time.December, 25), "Noël"},
+	}
+	if region != "" {
+		// Alsace-Moselle
+		hs = append(hs,
+			Holiday{e.AddDate(0, 0, -2), "Vendredi saint"},
+			Holiday{date(year, time.December, 26), "Saint Étienne"},
+		)
+	}
+	return hs
+}
+
+func unitedKingdom(year int, region string) []Holiday {
+	e := easter(year)
+	var fixed []Holiday // moved to the next free weekday when on a weekend
+	hs := []Holiday{
+		{nthWeekday(year, time.May, time.Monday, 1), "Early May bank holiday"},
+		{nthWeekday(year, time.May, time.Monday, -1), "Spring bank holiday"},
+		{e.AddDate(0, 0, -2), "Good Friday"},
+	}
+
+	fixed = append(fixed, Holiday{date(year, time.January, 1), "New Year's Day"})
+	if region == "SCT" {
+		fixed = append(fixed, Holiday{date(year, time.January, 2), "2nd January"})
+		hs = append(hs, Holiday{nthWeekday(year, time.August, time.Monday, 1), "Summer bank holiday"})
+	} else {
+		hs = append(hs,
+			Holiday{e.AddDate(0, 0, 1), "Easter Monday"},
+			Holiday{nthWeekday(year, time.August, time.Monday, -1), "Summer bank holiday"},
+		)
+	}
+	if region == "NIR" {
+		fixed = append(fixed,
+			Holiday{date(year, time.March, 17), "St Patrick's Day"},
+			Holiday{date(year, time.July, 12), "Battle of the Boyne"},
+		)
+	}
+	if region == "SCT" {
+		fixed = append(fixed, Holiday{date(year, time.November, 30), "St Andrew's Day"})
+	}
+	fixed = append(fixed,
+		Holiday{date(year, time.December, 25), "Christmas Day"},
+		Holiday{date(year, time.December, 26), "Boxing Day"},
+	)
+
+	return append(hs, withSubstituteDays(fixed, hs)...)
+}
+
+// withSubstituteDays adds a substitute day on the next free weekday for each
+// holiday that falls on a weekend, e.g. Christmas on a Saturday and Boxing Day
+// on a Sunday are substituted on Monday and Tuesday
+func withSubstituteDays(fixed, other []Holiday) []Holiday {
+	taken := make(map[time.Time]bool)
+	for _, h := range append(append([]Holiday(nil), fixed...), other...) {
+		taken[h.Date] = true
+	}
+
+	result := append([]Holiday(nil), fixed...)
+	for _, h := range fixed {
+		if !isWeekend(h.Date) {
+			continue
+		}
+		d := h.Date
+		for isWeekend(d) || taken[d] {
+			d = d.AddDate(0, 0, 1)
+		}
+		taken[d] = true
+		result = append(result, Holiday{d, h.Name + " (substitute day)"})
+	}
+	return result
+}
+
+func unitedStates(year int, _ string) []Holiday {
+	var hs []Holiday
+	for _, y := range []int{year, year + 1} {
+		fixed := []Holiday{
+			{date(y, time.January, 1), "New Year's Day"},
+			{date(y, time.July, 4), "Independence Day"},
+			{date(y, time.November, 11), "Veterans Day"},
+			{date(y, time.December, 25), "Christmas Day"},
+		}
+		if y >= 2021 {
+			fixed = append(fixed, Holiday{date(y, time.June, 19), "Juneteenth National Independence Day"})
+		}
+		for _, h := range fixed {
+			hs = append(hs, h)
+			// Observed on Friday before a Saturday and Monday after a Sunday
+			switch h.Date.Weekday() {
+			case time.Saturday:
+				hs = append(hs, Holiday{h.Date.AddDate(0, 0, -1), h.Name + " (observed)"})
+			case time.Sunday:
+				hs = append(hs, Holiday{h.Date.AddDate(0, 0, 1), h.Name + " (observed)"})
+			}
+		}
+	}
+
+	return append(hs,
+		Holiday{nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day"},
+		Holiday{nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday"},
+		Holiday{nthWeekday(year, time.May, time.Monday, -1), "Memorial Day"},
+		Holiday{nthWeekday(year, time.September, time.Monday, 1), "Labor Day"},
+		Holiday{nthWeekday(year, time.October, time.Monday, 2), "Columbus Day"},
+		Holiday{nthWeekday(year, time.November, time.Thursday, 4), "Thanksgiving Day"},
+	)
+}
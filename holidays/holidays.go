@@ -0,0 +1,185 @@
+// Package holidays provides public holiday calendars for common countries.
+// A Calendar implements openinghours.HolidayChecker, so values like
+// "Mo-Fr 09:00-17:00; PH off" can be evaluated without a hand-written checker:
+//
+//	cal, err := holidays.New("DE", "BY")
+//	if err != nil {
+//		// unsupported country or region
+//	}
+//	oh.SetHolidayChecker(cal)
+//
+// Only statutory public holidays are included; one-off holidays (e.g. royal
+// events) and holidays that apply to parts of a region only are not.
+package holidays
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Holiday is a public holiday on a specific date
+type Holiday struct {
+	Date time.Time // Midnight UTC of the holiday
+	Name string
+}
+
+// Calendar reports the public holidays of a country and optional region.
+// It is safe for concurrent use.
+type Calendar struct {
+	country string
+	region  string
+	rules   func(year int, region string) []Holiday
+
+	mu    sync.Mutex
+	years map[int]map[string]string // year -> "2006-01-02" -> name
+}
+
+// New returns the holiday calendar for an ISO 3166-1 alpha-2 country code and
+// an optional ISO 3166-2 subdivision code, with or without the country prefix
+// (e.g. New("DE", "BY") or New("DE", "DE-BY")). Supported countries are listed
+// by Countries.
+func New(country string, region ...string) (*Calendar, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	def, ok := countries[country]
+	if !ok {
+		return nil, fmt.Errorf("unsupported country: %s", country)
+	}
+
+	if len(region) > 1 {
+		return nil, fmt.Errorf("expected at most one region, got %d", len(region))
+	}
+	var r string
+	if len(region) == 1 {
+		r = strings.ToUpper(strings.TrimSpace(region[0]))
+		r = strings.TrimPrefix(r, country+"-")
+	}
+	if r != "" && !containsString(def.regions, r) {
+		return nil, fmt.Errorf("unsupported region for %s: %s", country, r)
+	}
+
+	return &Calendar{
+		country: country,
+		region:  r,
+		rules:   def.rules,
+		years:   make(map[int]map[string]string),
+	}, nil
+}
+
+// Countries returns the supported country codes in alphabetical order
+func Countries() []string {
+	codes := make([]string, 0, len(countries))
+	for code := range countries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// IsHoliday reports whether the calendar date of t (in t's location) is a public holiday
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	_, ok := c.year(t.Year())[t.Format("2006-01-02")]
+	return ok
+}
+
+// HolidayName returns the name of the public holiday on the calendar date of t
+// (in t's location), like "Karfreitag". ok is false if it is not a holiday.
+func (c *Calendar) HolidayName(t time.Time) (name string, ok bool) {
+	name, ok = c.year(t.Year())[t.Format("2006-01-02")]
+	return name, ok
+}
+
+// Holidays returns the public holidays of a year sorted by date
+func (c *Calendar) Holidays(year int) []Holiday {
+	var result []Holiday
+	for key, name := range c.year(year) {
+		date, _ := time.Parse("2006-01-02", key)
+		result = append(result, Holiday{Date: date, Name: name})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result
+}
+
+// year returns the holidays of a year keyed by date, computing them once
+func (c *Calendar) year(year int) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if days, ok := c.years[year]; ok {
+		return days
+	}
+	days := make(map[string]string)
+	for _, h := range c.rules(year, c.region) {
+		// Substitute days can move a holiday into the neighbouring year
+		if h.Date.Year() != year {
+			continue
+		}
+		key := h.Date.Format("2006-01-02")
+		if _, exists := days[key]; !exists {
+			days[key] = h.Name
+		}
+	}
+	c.years[year] = days
+	return days
+}
+
+// country describes the holidays of a country
+type country struct {
+	regions []string
+	rules   func(year int, region string) []Holiday
+}
+
+// date returns midnight UTC of the given day
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// easter returns Easter Sunday of the year
+// using the Anonymous Gregorian algorithm (Computus)
+func easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := ((h + l - 7*m + 114) % 31) + 1
+
+	return date(year, time.Month(month), day)
+}
+
+// nthWeekday returns the nth weekday of a month; n = -1 is the last one
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := date(year, month+1, 0)
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -offset)
+	}
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package holidays
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	openinghours "github.com/Daquisu/opening_hours.go"
+)
+
+// Calendar must be usable as a holiday checker that knows holiday names
+var (
+	_ openinghours.HolidayChecker = (*Calendar)(nil)
+	_ openinghours.HolidayNamer   = (*Calendar)(nil)
+)
+
+func TestCalendar_IsHoliday(t *testing.T) {
+	tests := []struct {
+		country string
+		region  string
+		date    string
+		want    bool
+		desc    string
+	}{
+		{"DE", "", "2024-10-03", true, "Tag der Deutschen Einheit"},
+		{"DE", "", "2024-03-29", true, "Karfreitag"},
+		{"DE", "", "2024-04-01", true, "Ostermontag"},
+		{"DE", "", "2024-05-09", true, "Christi Himmelfahrt"},
+		{"DE", "", "2024-05-20", true, "Pfingstmontag"},
+		{"DE", "BY", "2024-05-30", true, "Fronleichnam in Bavaria"},
+		{"DE", "BE", "2024-05-30", false, "no Fronleichnam in Berlin"},
+		{"DE", "BE", "2024-03-08", true, "Frauentag in Berlin"},
+		{"DE", "BE", "2018-03-08", false, "Frauentag in Berlin only since 2019"},
+		{"DE", "SN", "2024-11-20", true, "Buß- und Bettag in Saxony"},
+		{"DE", "BY", "2017-10-31", true, "Reformationstag 2017 nationwide"},
+		{"DE", "BY", "2024-10-31", false, "no Reformationstag in Bavaria"},
+		{"DE", "DE-BY", "2024-01-06", true, "region with country prefix"},
+		{"DE", "", "2024-10-04", false, "regular day"},
+		{"FR", "", "2024-07-14", true, "Fête nationale"},
+		{"FR", "", "2024-03-29", false, "no Good Friday outside Alsace-Moselle"},
+		{"FR", "57", "2024-03-29", true, "Good Friday in Moselle"},
+		{"GB", "", "2021-12-27", true, "Christmas substitute day"},
+		{"GB", "", "2021-12-28", true, "Boxing Day substitute day"},
+		{"GB", "ENG", "2024-08-26", true, "Summer bank holiday in England"},
+		{"GB", "SCT", "2024-08-05", true, "Summer bank holiday in Scotland"},
+		{"GB", "SCT", "2024-08-26", false, "no late summer bank holiday in Scotland"},
+		{"GB", "SCT", "2022-01-03", true, "New Year's Day substitute in Scotland"},
+		{"GB", "SCT", "2022-01-04", true, "2nd January substitute in Scotland"},
+		{"GB", "NIR", "2024-03-18", true, "St Patrick's Day substitute"},
+		{"US", "", "2021-12-31", true, "New Year's Day 2022 observed"},
+		{"US", "", "2024-11-28", true, "Thanksgiving"},
+		{"US", "", "2024-01-15", true, "Martin Luther King Jr. Day"},
+		{"US", "", "2020-06-19", false, "Juneteenth only since 2021"},
+		{"US", "", "2026-07-03", true, "Independence Day observed"},
+		{"AT", "", "2024-10-26", true, "Nationalfeiertag"},
+		{"AT", "", "2024-12-08", true, "Mariä Empfängnis"},
+		{"at", "", "2024-05-30", true, "lowercase country code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s-%s %s", tt.country, tt.region, tt.desc), func(t *testing.T) {
+			var regions []string
+			if tt.region != "" {
+				regions = append(regions, tt.region)
+			}
+			cal, err := New(tt.country, regions...)
+			if err != nil {
+				t.Fatalf("New(%q, %q) returned error: %v", tt.country, tt.region, err)
+			}
+			day, _ := time.Parse("2006-01-02", tt.date)
+			if got := cal.IsHoliday(day.Add(15 * time.Hour)); got != tt.want {
+				t.Errorf("IsHoliday(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_UsesLocalDate(t *testing.T) {
+	cal, err := New("DE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Oct 3, 00:30 in Berlin is still Oct 2 in UTC
+	berlin := time.FixedZone("CEST", 2*3600)
+	if !cal.IsHoliday(time.Date(2024, 10, 3, 0, 30, 0, 0, berlin)) {
+		t.Error("expected the calendar date in the time's location to be used")
+	}
+}
+
+func TestCalendar_Holidays(t *testing.T) {
+	cal, err := New("AT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hs := cal.Holidays(2024)
+	if len(hs) != 13 {
+		t.Fatalf("got %d holidays, want 13", len(hs))
+	}
+	if hs[0].Name != "Neujahr" || hs[len(hs)-1].Name != "Stefanitag" {
+		t.Errorf("holidays not sorted by date: first %q, last %q", hs[0].Name, hs[len(hs)-1].Name)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		country string
+		regions []string
+	}{
+		{"XX", nil},
+		{"DE", []string{"ZZ"}},
+		{"US", []string{"CA"}},
+		{"DE", []string{"BY", "BW"}},
+	}
+	for _, tt := range tests {
+		if _, err := New(tt.country, tt.regions...); err == nil {
+			t.Errorf("New(%q, %v) expected error", tt.country, tt.regions)
+		}
+	}
+}
+
+func TestCountries(t *testing.T) {
+	want := []string{"AT", "DE", "FR", "GB", "US"}
+	got := Countries()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Countries() = %v, want %v", got, want)
+	}
+}
+
+func TestCalendar_WithOpeningHours(t *testing.T) {
+	oh, err := openinghours.New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	cal, err := New("DE", "BY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	oh.SetHolidayChecker(cal)
+
+	// Thursday Oct 3, 2024 is a public holiday, Friday Oct 4 is not
+	if oh.GetState(time.Date(2024, 10, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected closed on Tag der Deutschen Einheit")
+	}
+	if !oh.GetState(time.Date(2024, 10, 4, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected open on a regular Friday")
+	}
+	if got := oh.GetComment(time.Date(2024, 10, 3, 10, 0, 0, 0, time.UTC)); got != "Tag der Deutschen Einheit" {
+		t.Errorf("GetComment on Oct 3 = %q, want the holiday name", got)
+	}
+	if got := oh.GetComment(time.Date(2024, 10, 4, 10, 0, 0, 0, time.UTC)); got != "" {
+		t.Errorf("GetComment on Oct 4 = %q, want none", got)
+	}
+}
+
+func TestCalendar_HolidayName(t *testing.T) {
+	cal, err := New("DE", "BY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name, ok := cal.HolidayName(time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC)); !ok || name != "Karfreitag" {
+		t.Errorf("HolidayName(2024-03-29) = %q, %v, want Karfreitag", name, ok)
+	}
+	if name, ok := cal.HolidayName(time.Date(2024, 3, 28, 12, 0, 0, 0, time.UTC)); ok || name != "" {
+		t.Errorf("HolidayName(2024-03-28) = %q, %v, want no holiday", name, ok)
+	}
+}
+
+func ExampleNew() {
+	cal, err := New("DE", "BY")
+	if err != nil {
+		panic(err)
+	}
+
+	oh, _ := openinghours.New("Mo-Fr 09:00-17:00; PH off")
+	oh.SetHolidayChecker(cal)
+
+	// Corpus Christi (Fronleichnam) 2024 is a holiday in Bavaria
+	fmt.Println(oh.GetState(time.Date(2024, 5, 30, 10, 0, 0, 0, time.UTC)))
+	// Output: false
+}
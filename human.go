@@ -0,0 +1,555 @@
+package openinghours
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale holds the words and phrases used by GetHumanReadable. EnglishLocale and
+// GermanLocale are provided; other languages can be added by filling a Locale.
+// Format strings take their arguments in the order given in the comments.
+type Locale struct {
+	Weekdays [7]string  // names for Sunday-Saturday
+	Months   [12]string // names for January-December
+
+	Open    string // state of open rules, e.g. "open"
+	Closed  string // state of closed rules, e.g. "closed"
+	Unknown string // state of unknown rules, e.g. "maybe open"
+
+	Always         string // for rules without selectors and times, e.g. "around the clock"
+	Daily          string // for rules with times only, e.g. "daily"
+	PublicHolidays string // e.g. "on public holidays"
+	PublicHoliday  string // reference for DayOffset, e.g. "public holidays"
+	SchoolHolidays string // e.g. "during school holidays"
+	Easter         string // name of Easter Sunday, used with On and DayOffset
+	Otherwise      string // before fallback rules, e.g. "otherwise"
+
+	And         string // last separator of lists, e.g. " and "
+	Range       string // range of weekdays, months or numbers: start, end, e.g. "%s to %s"
+	On          string // single day or date: day, e.g. "on %s"
+	In          string // single month: name, e.g. "in %s"
+	InYear      string // single year: year, e.g. "in %s"
+	FromTo      string // range of dates or years: start, end, e.g. "from %s to %s"
+	Since       string // open year range: year, e.g. "from %s"
+	Weeks       string // week numbers: numbers, e.g. "in week %s"
+	Every       string // interval: count, unit, e.g. "every %d %s"
+	Years       string // interval unit
+	WeeksUnit   string // interval unit
+	DaysUnit    string // interval unit
+	MinutesUnit string // interval unit
+	TimeRange   string // start, end, e.g. "from %s to %s"
+	OpenEnd     string // start, e.g. "from %s (open end)"
+	Midnight    string // end of day with the 12-hour clock, e.g. "midnight"
+
+	Events map[string]string // names of "sunrise", "sunset", "dawn" and "dusk"
+
+	MonthDay   func(month string, day, year int) string // a date like "December 24", year is 0 if not set; days count from the end of the month if negative
+	Ordinal    func(n int) string                       // weekday occurrence like "first" or "last" (-1)
+	NthWeekday func(ordinal, weekday string) string     // like "the last Sunday of the month"
+	DayOffset  func(days int, reference string) string  // like "the day after public holidays"
+
+	// NthWeekdayReference is the reference for DayOffset of a weekday occurrence
+	// like "Sa[-1] +1 day", e.g. "the last Saturday of the month". NthWeekday is
+	// used if nil.
+	NthWeekdayReference func(ordinal, weekday string) string
+
+	Clock12 bool // use the 12-hour clock by default, see With12HourClock
+}
+
+// EnglishLocale returns the English Locale, using the 12-hour clock
+func EnglishLocale() Locale {
+	ordinals := []string{"first", "second", "third", "fourth", "fifth"}
+	return Locale{
+		Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		Months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		Open:           "open",
+		Closed:         "closed",
+		Unknown:        "maybe open",
+		Always:         "around the clock",
+		Daily:          "daily",
+		PublicHolidays: "on public holidays",
+		PublicHoliday:  "public holidays",
+		SchoolHolidays: "during school holidays",
+		Easter:         "Easter",
+		Otherwise:      "otherwise",
+		And:            " and ",
+		Range:          "%s to %s",
+		On:             "on %s",
+		In:             "in %s",
+		InYear:         "in %s",
+		FromTo:         "from %s to %s",
+		Since:          "from %s",
+		Weeks:          "in week %s",
+		Every:          "every %d %s",
+		Years:          "years",
+		WeeksUnit:      "weeks",
+		DaysUnit:       "days",
+		MinutesUnit:    "minutes",
+		TimeRange:      "from %s to %s",
+		OpenEnd:        "from %s (open end)",
+		Midnight:       "midnight",
+		Events:         map[string]string{"sunrise": "sunrise", "sunset": "sunset", "dawn": "dawn", "dusk": "dusk"},
+		MonthDay: func(month string, day, year int) string {
+			switch {
+			case day == -1:
+				return "the last day of " + month
+			case day < 0:
+				return fmt.Sprintf("the %s to last day of %s", ordinalWord(ordinals, -day), month)
+			case year != 0:
+				return fmt.Sprintf("%s %d, %d", month, day, year)
+			}
+			return fmt.Sprintf("%s %d", month, day)
+		},
+		Ordinal: func(n int) string {
+			switch {
+			case n == -1:
+				return "last"
+			case n < -1:
+				return ordinalWord(ordinals, -n) + " to last"
+			}
+			return ordinalWord(ordinals, n)
+		},
+		NthWeekday: func(ordinal, weekday string) string {
+			return fmt.Sprintf("on the %s %s of the month", ordinal, weekday)
+		},
+		NthWeekdayReference: func(ordinal, weekday string) string {
+			return fmt.Sprintf("the %s %s of the month", ordinal, weekday)
+		},
+		DayOffset: func(days int, reference string) string {
+			switch days {
+			case 1:
+				return "the day after " + reference
+			case -1:
+				return "the day before " + reference
+			}
+			if days < 0 {
+				return fmt.Sprintf("%d days before %s", -days, reference)
+			}
+			return fmt.Sprintf("%d days after %s", days, reference)
+		},
+		Clock12: true,
+	}
+}
+
+// GermanLocale returns the German Locale, using the 24-hour clock
+func GermanLocale() Locale {
+	ordinals := []string{"ersten", "zweiten", "dritten", "vierten", "fünften"}
+	return Locale{
+		Weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		Open:           "geöffnet",
+		Closed:         "geschlossen",
+		Unknown:        "eventuell geöffnet",
+		Always:         "rund um die Uhr",
+		Daily:          "täglich",
+		PublicHolidays: "an Feiertagen",
+		PublicHoliday:  "Feiertagen",
+		SchoolHolidays: "in den Schulferien",
+		Easter:         "Ostern",
+		Otherwise:      "sonst",
+		And:            " und ",
+		Range:          "%s bis %s",
+		On:             "am %s",
+		In:             "im %s",
+		InYear:         "im Jahr %s",
+		FromTo:         "von %s bis %s",
+		Since:          "ab %s",
+		Weeks:          "in Kalenderwoche %s",
+		Every:          "alle %d %s",
+		Years:          "Jahre",
+		WeeksUnit:      "Wochen",
+		DaysUnit:       "Tage",
+		MinutesUnit:    "Minuten",
+		TimeRange:      "von %s bis %s",
+		OpenEnd:        "ab %s (Ende offen)",
+		Midnight:       "Mitternacht",
+		Events:         map[string]string{"sunrise": "Sonnenaufgang", "sunset": "Sonnenuntergang", "dawn": "Morgendämmerung", "dusk": "Abenddämmerung"},
+		MonthDay: func(month string, day, year int) string {
+			switch {
+			case day == -1:
+				return "letzten Tag im " + month
+			case day < 0:
+				return fmt.Sprintf("%s Tag vom Ende im %s", ordinalWord(ordinals, -day), month)
+			case year != 0:
+				return fmt.Sprintf("%d. %s %d", day, month, year)
+			}
+			return fmt.Sprintf("%d. %s", day, month)
+		},
+		Ordinal: func(n int) string {
+			switch {
+			case n == -1:
+				return "letzten"
+			case n < -1:
+				return ordinalWord(ordinals, -n) + " vom Ende"
+			}
+			return ordinalWord(ordinals, n)
+		},
+		NthWeekday: func(ordinal, weekday string) string {
+			return fmt.Sprintf("am %s %s im Monat", ordinal, weekday)
+		},
+		NthWeekdayReference: func(ordinal, weekday string) string {
+			return fmt.Sprintf("dem %s %s im Monat", ordinal, weekday)
+		},
+		DayOffset: func(days int, reference string) string {
+			switch days {
+			case 1:
+				return "am Tag nach " + reference
+			case -1:
+				return "am Tag vor " + reference
+			}
+			if days < 0 {
+				return fmt.Sprintf("%d Tage vor %s", -days, reference)
+			}
+			return fmt.Sprintf("%d Tage nach %s", days, reference)
+		},
+	}
+}
+
+// ordinalWord returns the nth word of ordinals, or "nth." if there is none
+func ordinalWord(ordinals []string, n int) string {
+	if n >= 1 && n <= len(ordinals) {
+		return ordinals[n-1]
+	}
+	return fmt.Sprintf("%d.", n)
+}
+
+// HumanOption configures GetHumanReadable
+type HumanOption func(*humanOptions)
+
+type humanOptions struct {
+	locale  Locale
+	clock12 *bool
+}
+
+// humanLocales are the locales of GetHumanReadable by language
+var humanLocales = map[string]func() Locale{
+	"en": EnglishLocale,
+	"de": GermanLocale,
+}
+
+// WithLocale sets the language of GetHumanReadable. By default it is the
+// language of the region if supported (see SetRegion), or EnglishLocale.
+func WithLocale(l Locale) HumanOption {
+	return func(o *humanOptions) {
+		o.locale = l
+	}
+}
+
+// With12HourClock selects the 12-hour clock ("9 AM") or the 24-hour clock
+// ("09:00"), overriding the default of the locale
+func With12HourClock(enabled bool) HumanOption {
+	return func(o *humanOptions) {
+		o.clock12 = &enabled
+	}
+}
+
+// GetHumanReadable describes the value in natural language for display, e.g.
+// "Open Monday to Friday from 9 AM to 5 PM, closed on public holidays" for
+// "Mo-Fr 09:00-17:00; PH off". The description follows the rules in order, so
+// later rules override earlier ones as in the value itself.
+func (oh *OpeningHours) GetHumanReadable(opts ...HumanOption) string {
+	o := humanOptions{locale: EnglishLocale()}
+	if locale, ok := humanLocales[oh.language()]; ok {
+		o.locale = locale()
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	clock12 := o.locale.Clock12
+	if o.clock12 != nil {
+		clock12 = *o.clock12
+	}
+
+	var groups []string
+	for i, rules := range append([][]rule{oh.rules}, oh.fallbackGroups...) {
+		phrases := make([]string, len(rules))
+		for j, r := range rules {
+			phrases[j] = o.locale.describeRule(r, clock12)
+		}
+		group := strings.Join(phrases, ", ")
+		if i > 0 {
+			group = o.locale.Otherwise + " " + group
+		}
+		groups = append(groups, group)
+	}
+	return upperFirst(strings.Join(groups, ", "))
+}
+
+// upperFirst returns s with its first letter in upper case
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// describeRule describes a rule: state, selectors, times and comment
+func (l Locale) describeRule(r rule, clock12 bool) string {
+	parts := []string{l.Open}
+	switch r.state {
+	case StateClosed:
+		parts[0] = l.Closed
+	case StateUnknown:
+		parts[0] = l.Unknown
+	}
+
+	selectors := l.describeSelectors(r)
+	parts = append(parts, selectors...)
+
+	var times []string
+	for _, tr := range r.timeRanges {
+		times = append(times, l.describeTimeRange(tr, clock12))
+	}
+	switch {
+	case len(times) > 0:
+		if len(selectors) == 0 {
+			parts = append(parts, l.Daily)
+		}
+		parts = append(parts, l.list(times))
+	case len(selectors) == 0 && r.state == StateOpen:
+		parts = append(parts, l.Always)
+	}
+
+	result := strings.Join(parts, " ")
+	if r.comment != "" {
+		result += " (" + r.comment + ")"
+	}
+	return result
+}
+
+// describeSelectors describes the wide selectors (dates, weeks) followed by
+// weekdays and holidays
+func (l Locale) describeSelectors(r rule) []string {
+	var parts []string
+
+	if r.dateStart > 0 {
+		start := dateFromKey(r.dateStart)
+		end := dateFromKey(r.dateEnd)
+		startStr := l.MonthDay(l.Months[start.Month()-1], start.Day(), start.Year())
+		if r.dateEnd == r.dateStart {
+			parts = append(parts, fmt.Sprintf(l.On, startStr))
+		} else {
+			endStr := l.MonthDay(l.Months[end.Month()-1], end.Day(), end.Year())
+			parts = append(parts, fmt.Sprintf(l.FromTo, startStr, endStr))
+		}
+	} else if r.yearStart > 0 {
+		switch r.yearEnd {
+		case r.yearStart:
+			parts = append(parts, fmt.Sprintf(l.InYear, fmt.Sprint(r.yearStart)))
+		case 9999:
+			parts = append(parts, fmt.Sprintf(l.Since, fmt.Sprint(r.yearStart)))
+		default:
+			parts = append(parts, fmt.Sprintf(l.FromTo, fmt.Sprint(r.yearStart), fmt.Sprint(r.yearEnd)))
+		}
+		if r.yearInterval > 0 {
+			parts = append(parts, fmt.Sprintf(l.Every, r.yearInterval, l.Years))
+		}
+	}
+
+	if len(r.weekConstraints) > 0 {
+		var weeks []string
+		for _, wc := range r.weekConstraints {
+			week := fmt.Sprint(wc.weekStart)
+			if wc.weekEnd != wc.weekStart {
+				week = fmt.Sprintf(l.Range, week, fmt.Sprint(wc.weekEnd))
+			}
+			if wc.weekInterval > 0 {
+				week += " " + fmt.Sprintf(l.Every, wc.weekInterval, l.WeeksUnit)
+			}
+			weeks = append(weeks, week)
+		}
+		parts = append(parts, fmt.Sprintf(l.Weeks, l.list(weeks)))
+	}
+
+	if r.monthStart > 0 && r.dateStart == 0 {
+		parts = append(parts, l.describeMonths(r))
+	}
+
+	if r.isEaster {
+		name := l.Easter
+		if r.variableDate != nil {
+			name = r.variableDate.Name()
+		}
+		if r.isEasterRange {
+			parts = append(parts, fmt.Sprintf(l.FromTo, l.easterEndpoint(name, r.easterStartDate, r.easterOffset), l.easterEndpoint(name, r.easterEndDate, r.easterOffsetEnd)))
+		} else if r.easterOffset != 0 {
+			parts = append(parts, l.DayOffset(r.easterOffset, name))
+		} else {
+			parts = append(parts, fmt.Sprintf(l.On, name))
+		}
+	}
+
+	var days []string
+	if weekdays := l.describeWeekdays(r.weekdays); weekdays != "" {
+		days = append(days, weekdays)
+	}
+	for _, c := range r.weekdayConstraints {
+		ordinal := l.Ordinal(c.nthFrom)
+		if c.nthTo != 0 {
+			ordinal = fmt.Sprintf(l.Range, ordinal, l.Ordinal(c.nthTo))
+		}
+		if c.offset != 0 && l.NthWeekdayReference != nil {
+			days = append(days, l.DayOffset(c.offset, l.NthWeekdayReference(ordinal, l.Weekdays[c.weekday])))
+		} else if c.offset != 0 {
+			days = append(days, l.DayOffset(c.offset, l.NthWeekday(ordinal, l.Weekdays[c.weekday])))
+		} else {
+			days = append(days, l.NthWeekday(ordinal, l.Weekdays[c.weekday]))
+		}
+	}
+	var holidays []string
+	if r.isPH {
+		if r.phOffset != 0 {
+			holidays = append(holidays, l.DayOffset(r.phOffset, l.PublicHoliday))
+		} else {
+			holidays = append(holidays, l.PublicHolidays)
+		}
+	}
+	if r.isSH {
+		holidays = append(holidays, l.SchoolHolidays)
+	}
+	if r.holidayUnion {
+		// Weekdays or holidays: "Monday to Friday and on public holidays"
+		parts = append(parts, l.list(append(days, holidays...)))
+	} else {
+		parts = append(parts, holidays...)
+		if len(days) > 0 {
+			parts = append(parts, l.list(days))
+		}
+	}
+
+	return parts
+}
+
+// describeMonths describes a month or date range like "in June" or "from December 24 to January 2"
+func (l Locale) describeMonths(r rule) string {
+	if r.everyMonth {
+		months := fmt.Sprintf(l.Range, l.Months[r.monthStart-1], l.Months[r.monthEnd-1])
+		return fmt.Sprintf(l.On, l.MonthDay(months, r.dayStart, 0))
+	}
+	if r.dayStart == 0 {
+		if r.monthEnd == r.monthStart {
+			return fmt.Sprintf(l.In, l.Months[r.monthStart-1])
+		}
+		return fmt.Sprintf(l.FromTo, l.Months[r.monthStart-1], l.Months[r.monthEnd-1])
+	}
+
+	start := l.MonthDay(l.Months[r.monthStart-1], r.dayStart, 0)
+	var result string
+	if r.monthEnd == r.monthStart && r.dayEnd == r.dayStart {
+		if r.dateOffset != 0 {
+			return l.DayOffset(r.dateOffset, start)
+		}
+		result = fmt.Sprintf(l.On, start)
+	} else {
+		result = fmt.Sprintf(l.FromTo, start, l.MonthDay(l.Months[r.monthEnd-1], r.dayEnd, 0))
+	}
+	if r.dayInterval > 0 {
+		result += " " + fmt.Sprintf(l.Every, r.dayInterval, l.DaysUnit)
+	}
+	return result
+}
+
+// easterEndpoint describes an endpoint of an Easter range: the fixed mmdd date,
+// or the movable date name with an optional offset
+func (l Locale) easterEndpoint(name string, date, offset int) string {
+	switch {
+	case date > 0:
+		return l.MonthDay(l.Months[date/100-1], date%100, 0)
+	case offset != 0:
+		return l.DayOffset(offset, name)
+	}
+	return name
+}
+
+// describeWeekdays describes selected weekdays, starting on Monday, like
+// "Monday to Friday" or "Monday, Wednesday and Friday"
+func (l Locale) describeWeekdays(weekdays []bool) string {
+	if len(weekdays) != 7 {
+		return ""
+	}
+	var runs []string
+	for j := 0; j < 7; j++ {
+		i := (j + 1) % 7
+		if !weekdays[i] {
+			continue
+		}
+		count := 1
+		for j+count < 7 && weekdays[(i+count)%7] {
+			count++
+		}
+		switch count {
+		case 1:
+			runs = append(runs, l.Weekdays[i])
+		case 2:
+			runs = append(runs, l.Weekdays[i], l.Weekdays[(i+1)%7])
+		default:
+			runs = append(runs, fmt.Sprintf(l.Range, l.Weekdays[i], l.Weekdays[(i+count-1)%7]))
+		}
+		j += count - 1
+	}
+	return l.list(runs)
+}
+
+// describeTimeRange describes a time range like "from 9 AM to 5 PM"
+func (l Locale) describeTimeRange(tr timeRange, clock12 bool) string {
+	start := l.describeTime(tr.start, tr.startVar, tr.startOffset, clock12)
+	if tr.openEnd {
+		return fmt.Sprintf(l.OpenEnd, start)
+	}
+	result := fmt.Sprintf(l.TimeRange, start, l.describeTime(tr.end, tr.endVar, tr.endOffset, clock12))
+	if tr.interval > 0 {
+		result += " " + fmt.Sprintf(l.Every, tr.interval, l.MinutesUnit)
+	}
+	return result
+}
+
+// describeTime formats a fixed time in minutes or a variable time with its offset
+func (l Locale) describeTime(minutes int, variable string, offset int, clock12 bool) string {
+	if variable != "" {
+		name := variable
+		if l.Events[variable] != "" {
+			name = l.Events[variable]
+		}
+		if offset == 0 {
+			return name
+		}
+		sign := "+"
+		if offset < 0 {
+			sign, offset = "-", -offset
+		}
+		return fmt.Sprintf("%s %s %d:%02d", name, sign, offset/60, offset%60)
+	}
+
+	if !clock12 {
+		return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	}
+	if minutes == 24*60 {
+		return l.Midnight
+	}
+	hour, minute := minutes/60%24, minutes%60
+	suffix := "AM"
+	if hour >= 12 {
+		suffix = "PM"
+	}
+	hour %= 12
+	if hour == 0 {
+		hour = 12
+	}
+	if minute == 0 {
+		return fmt.Sprintf("%d %s", hour, suffix)
+	}
+	return fmt.Sprintf("%d:%02d %s", hour, minute, suffix)
+}
+
+// list joins items with commas and the last one with And
+func (l Locale) list(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + l.And + items[len(items)-1]
+}
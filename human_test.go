@@ -0,0 +1,75 @@
+package openinghours
+
+import "testing"
+
+func TestGetHumanReadable_English(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Mo-Fr 09:00-17:00; PH off", "Open Monday to Friday from 9 AM to 5 PM, closed on public holidays"},
+		{"24/7", "Open around the clock"},
+		{"10:00-18:00", "Open daily from 10 AM to 6 PM"},
+		{"off", "Closed"},
+		{`Mo,We,Fr 09:00-12:00,13:30-17:00; Sa 10:00-14:00 "by appointment"`,
+			"Open Monday, Wednesday and Friday from 9 AM to 12 PM and from 1:30 PM to 5 PM, open Saturday from 10 AM to 2 PM (by appointment)"},
+		{"Dec 24-26 off; Dec 31 10:00-14:00", "Closed from December 24 to December 26, open on December 31 from 10 AM to 2 PM"},
+		{"Su[-1] 10:00-12:00; PH +1 day off", "Open on the last Sunday of the month from 10 AM to 12 PM, closed the day after public holidays"},
+		{"easter -2 days off; week 01-10/2 Tu 17:00+",
+			"Closed 2 days before Easter, open in week 1 to 10 every 2 weeks Tuesday from 5 PM (open end)"},
+		{"Mo-Fr,PH 08:00-24:00", "Open Monday to Friday and on public holidays from 8 AM to midnight"},
+		{"2025 Jun 01-2026 Sep 30 Mo 10:00-16:00/01:30",
+			"Open from June 1, 2025 to September 30, 2026 Monday from 10 AM to 4 PM every 90 minutes"},
+		{"2024 Sa-Su sunrise-(sunset-01:00)", "Open in 2024 Saturday and Sunday from sunrise to sunset - 1:00"},
+		{"Mo-Fr 09:00-17:00 || unknown", "Open Monday to Friday from 9 AM to 5 PM, otherwise maybe open"},
+		{"Dec 25 +3 days off", "Closed 3 days after December 25"},
+		{"Sa[-1] +1 day 10:00-12:00", "Open the day after the last Saturday of the month from 10 AM to 12 PM"},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		if got := oh.GetHumanReadable(); got != tc.expected {
+			t.Errorf("%q:\n got %q\nwant %q", tc.value, got, tc.expected)
+		}
+	}
+}
+
+func TestGetHumanReadable_German(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Mo-Fr 09:00-17:00; PH off", "Geöffnet Montag bis Freitag von 09:00 bis 17:00, geschlossen an Feiertagen"},
+		{"24/7", "Geöffnet rund um die Uhr"},
+		{"Dec 24-26 off; Dec 31 10:00-14:00", "Geschlossen von 24. Dezember bis 26. Dezember, geöffnet am 31. Dezember von 10:00 bis 14:00"},
+		{"Su[-1] 10:00-12:00; PH +1 day off", "Geöffnet am letzten Sonntag im Monat von 10:00 bis 12:00, geschlossen am Tag nach Feiertagen"},
+		{"2024 SH Mo-Fr 10:00-12:00", "Geöffnet im Jahr 2024 in den Schulferien Montag bis Freitag von 10:00 bis 12:00"},
+		{"Dec 25 -1 day 10:00-14:00", "Geöffnet am Tag vor 25. Dezember von 10:00 bis 14:00"},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		if got := oh.GetHumanReadable(WithLocale(GermanLocale())); got != tc.expected {
+			t.Errorf("%q:\n got %q\nwant %q", tc.value, got, tc.expected)
+		}
+	}
+}
+
+func TestGetHumanReadable_Clock(t *testing.T) {
+	oh, err := New("Mo 00:00-12:30")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if got, want := oh.GetHumanReadable(With12HourClock(false)), "Open Monday from 00:00 to 12:30"; got != want {
+		t.Errorf("24-hour clock: got %q, want %q", got, want)
+	}
+	if got, want := oh.GetHumanReadable(WithLocale(GermanLocale()), With12HourClock(true)), "Geöffnet Montag von 12 AM bis 12:30 PM"; got != want {
+		t.Errorf("12-hour clock: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package openinghours
+
+import (
+	"strconv"
+	"sync"
+)
+
+// ruleInterner shares identical selector and time range slices between rules.
+// Values with many fallback groups ("... || ... || ...") often repeat the same
+// weekdays and times; after parsing, each distinct slice is kept only once.
+// Rules never mutate these slices after parsing, so sharing them is safe.
+type ruleInterner struct {
+	weekdays   map[uint8][]bool
+	timeRanges map[string][]timeRange
+	key        []byte // reused buffer for time range keys
+}
+
+func newRuleInterner() *ruleInterner {
+	return &ruleInterner{
+		weekdays:   make(map[uint8][]bool),
+		timeRanges: make(map[string][]timeRange),
+	}
+}
+
+// internerPool reuses interners (and their maps and key buffer) across parses
+var internerPool = sync.Pool{
+	New: func() any { return newRuleInterner() },
+}
+
+func (in *ruleInterner) intern(r *rule) {
+	if r.weekdays != nil {
+		var key uint8
+		for i, on := range r.weekdays {
+			if on {
+				key |= 1 << i
+			}
+		}
+		if shared, ok := in.weekdays[key]; ok {
+			r.weekdays = shared
+		} else {
+			in.weekdays[key] = r.weekdays
+		}
+	}
+
+	if len(r.timeRanges) > 0 {
+		in.key = appendTimeRangesKey(in.key[:0], r.timeRanges)
+		// The string conversion in the lookup does not allocate
+		if shared, ok := in.timeRanges[string(in.key)]; ok {
+			r.timeRanges = shared
+		} else {
+			in.timeRanges[string(in.key)] = r.timeRanges
+		}
+	}
+}
+
+// appendTimeRangesKey appends a key that is equal for equal time range slices
+func appendTimeRangesKey(b []byte, ranges []timeRange) []byte {
+	for _, tr := range ranges {
+		b = strconv.AppendInt(b, int64(tr.start), 10)
+		b = append(b, ',')
+		b = strconv.AppendInt(b, int64(tr.end), 10)
+		b = append(b, ',')
+		b = strconv.AppendBool(b, tr.openEnd)
+		b = append(b, ',')
+		b = strconv.AppendInt(b, int64(tr.minEnd), 10)
+		b = append(b, ',')
+		b = append(b, tr.startVar...)
+		b = append(b, ',')
+		b = append(b, tr.endVar...)
+		b = append(b, ',')
+		b = strconv.AppendInt(b, int64(tr.startOffset), 10)
+		b = append(b, ',')
+		b = strconv.AppendInt(b, int64(tr.endOffset), 10)
+		b = append(b, ',')
+		b = strconv.AppendInt(b, int64(tr.interval), 10)
+		b = append(b, ',')
+		b = strconv.AppendBool(b, tr.point)
+		b = append(b, ';')
+	}
+	return b
+}
+
+// internRules shares identical slices across the primary rules and all fallback groups
+func (oh *OpeningHours) internRules() {
+	in := internerPool.Get().(*ruleInterner)
+	defer func() {
+		clear(in.weekdays)
+		clear(in.timeRanges)
+		internerPool.Put(in)
+	}()
+
+	for i := range oh.rules {
+		in.intern(&oh.rules[i])
+	}
+	for _, group := range oh.fallbackGroups {
+		for i := range group {
+			in.intern(&group[i])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package openinghours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIntern_SharesSlicesAcrossFallbackGroups(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00 unknown || Mo-Fr 09:00-17:00 || Sa 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	primary := oh.rules[0]
+	first := oh.fallbackGroups[0][0]
+	second := oh.fallbackGroups[1][0]
+
+	if &primary.weekdays[0] != &first.weekdays[0] {
+		t.Errorf("expected identical weekdays to share a slice")
+	}
+	if &primary.timeRanges[0] != &first.timeRanges[0] || &first.timeRanges[0] != &second.timeRanges[0] {
+		t.Errorf("expected identical time ranges to share a slice")
+	}
+	if &first.weekdays[0] == &second.weekdays[0] {
+		t.Errorf("different weekdays must not share a slice")
+	}
+}
+
+func TestIntern_DoesNotChangeEvaluation(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00 unknown || Mo-Fr 10:00-16:00 || Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 15, 2024 is Monday
+	tests := []struct {
+		hour     int
+		expected string
+	}{
+		{9, "open"},
+		{12, "open"},
+		{17, "closed"},
+	}
+
+	for _, tt := range tests {
+		at := time.Date(2024, 1, 15, tt.hour, 30, 0, 0, time.UTC)
+		if got := oh.GetStateString(at); got != tt.expected {
+			t.Errorf("Mo %02d:30: got %q, want %q", tt.hour, got, tt.expected)
+		}
+	}
+}
+
+// largeFallbackValue builds a value with many fallback groups repeating the same rules
+func largeFallbackValue(groups int) string {
+	parts := make([]string, groups)
+	for i := range parts {
+		parts[i] = "Mo-Fr 09:00-12:00,13:00-18:00; Sa 10:00-14:00; PH off"
+	}
+	return strings.Join(parts, " || ")
+}
+
+func BenchmarkParseManyFallbackGroups(b *testing.B) {
+	value := largeFallbackValue(50)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := New(value); err != nil {
+			b.Fatalf("unexpected parse error: %v", err)
+		}
+	}
+}
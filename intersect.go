@@ -0,0 +1,124 @@
+package openinghours
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Intersect returns the intervals between from and to when both oh and other are open.
+// A result interval is unknown if either side is unknown; comments of both sides are joined.
+// A nil other is always closed, so the result is empty.
+func (oh *OpeningHours) Intersect(other *OpeningHours, from, to time.Time) []Interval {
+	return combineIntervals(oh.GetOpenIntervals(from, to), openIntervalsOf(other, from, to), true)
+}
+
+// Union returns the intervals between from and to when oh or other is open.
+// A result interval is unknown only if no side is definitely open; comments of
+// the contributing sides are joined. A nil other is always closed, so the
+// result is the intervals of oh.
+func (oh *OpeningHours) Union(other *OpeningHours, from, to time.Time) []Interval {
+	return combineIntervals(oh.GetOpenIntervals(from, to), openIntervalsOf(other, from, to), false)
+}
+
+// openIntervalsOf returns the open intervals of oh between from and to, or
+// none if oh is nil
+func openIntervalsOf(oh *OpeningHours, from, to time.Time) []Interval {
+	if oh == nil {
+		return nil
+	}
+	return oh.GetOpenIntervals(from, to)
+}
+
+// combineIntervals sweeps over the boundaries of two sorted, non-overlapping
+// interval lists and combines them by intersection (both) or union (either)
+func combineIntervals(a, b []Interval, both bool) []Interval {
+	var boundaries []time.Time
+	for _, list := range [][]Interval{a, b} {
+		for _, iv := range list {
+			boundaries = append(boundaries, iv.Start, iv.End)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var result []Interval
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		if !start.Before(end) {
+			continue
+		}
+
+		ivA, okA := intervalAt(a, start)
+		ivB, okB := intervalAt(b, start)
+
+		var sides []Interval
+		if both {
+			if !okA || !okB {
+				continue
+			}
+			sides = []Interval{ivA, ivB}
+		} else {
+			// Prefer sides that are definitely open over unknown ones
+			for _, side := range []struct {
+				iv Interval
+				ok bool
+			}{{ivA, okA}, {ivB, okB}} {
+				if side.ok && !side.iv.Unknown {
+					sides = append(sides, side.iv)
+				}
+			}
+			if len(sides) == 0 {
+				if okA {
+					sides = append(sides, ivA)
+				}
+				if okB {
+					sides = append(sides, ivB)
+				}
+			}
+			if len(sides) == 0 {
+				continue
+			}
+		}
+
+		combined := Interval{Start: start, End: end}
+		var comments []string
+		for _, side := range sides {
+			if side.Unknown {
+				combined.Unknown = true
+			}
+			if side.Comment != "" && !containsString(comments, side.Comment) {
+				comments = append(comments, side.Comment)
+			}
+		}
+		combined.Comment = strings.Join(comments, "; ")
+
+		// Merge with the previous interval if it continues it unchanged
+		if n := len(result); n > 0 && result[n-1].End.Equal(start) &&
+			result[n-1].Unknown == combined.Unknown && result[n-1].Comment == combined.Comment {
+			result[n-1].End = end
+			continue
+		}
+		result = append(result, combined)
+	}
+
+	return result
+}
+
+// intervalAt returns the interval containing t, if any
+func intervalAt(intervals []Interval, t time.Time) (Interval, bool) {
+	for _, iv := range intervals {
+		if iv.Contains(t) {
+			return iv, true
+		}
+	}
+	return Interval{}, false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntersect_BothOpen(t *testing.T) {
+	pharmacy, err := New("Mo-Fr 08:00-18:00; Sa 09:00-13:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	doctor, err := New("Mo,We 10:00-12:00,14:00-19:00; Sa 12:00-14:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 15, 2024 is Monday
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC)
+
+	got := pharmacy.Intersect(doctor, from, to)
+	want := []Interval{
+		{Start: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 17, 10, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 17, 14, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 17, 18, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 20, 13, 0, 0, 0, time.UTC)},
+	}
+
+	assertIntervals(t, got, want)
+}
+
+func TestUnion_EitherOpen(t *testing.T) {
+	morning, _ := New("Mo 08:00-12:00")
+	afternoon, _ := New("Mo 11:00-15:00; Tu 09:00-10:00")
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)
+
+	got := morning.Union(afternoon, from, to)
+	want := []Interval{
+		{Start: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)},
+	}
+
+	assertIntervals(t, got, want)
+}
+
+func TestIntersectUnion_UnknownAndComments(t *testing.T) {
+	a, _ := New("Mo 08:00-12:00 \"walk-in\"")
+	b, _ := New("Mo 10:00-14:00 unknown \"call ahead\"")
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	intersection := a.Intersect(b, from, to)
+	assertIntervals(t, intersection, []Interval{
+		{Start: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			Unknown: true, Comment: "walk-in; call ahead"},
+	})
+
+	union := a.Union(b, from, to)
+	assertIntervals(t, union, []Interval{
+		{Start: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			Comment: "walk-in"},
+		{Start: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+			Unknown: true, Comment: "call ahead"},
+	})
+}
+
+func TestIntersect_NoOverlap(t *testing.T) {
+	a, _ := New("Mo 08:00-10:00")
+	b, _ := New("Mo 10:00-12:00")
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	if got := a.Intersect(b, from, to); len(got) != 0 {
+		t.Errorf("expected no intersection for touching intervals, got %v", got)
+	}
+}
+
+func TestIntersectUnion_NilOther(t *testing.T) {
+	oh, _ := New("Mo 08:00-10:00")
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	if got := oh.Intersect(nil, from, to); len(got) != 0 {
+		t.Errorf("expected no intersection with nil, got %v", got)
+	}
+	assertIntervals(t, oh.Union(nil, from, to), []Interval{
+		{Start: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+	})
+}
+
+func assertIntervals(t *testing.T, got, want []Interval) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) ||
+			got[i].Unknown != want[i].Unknown || got[i].Comment != want[i].Comment {
+			t.Errorf("interval[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
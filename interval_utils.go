@@ -0,0 +1,128 @@
+package openinghours
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Contains reports whether t is in [iv.Start, iv.End)
+func (iv Interval) Contains(t time.Time) bool {
+	return !t.Before(iv.Start) && t.Before(iv.End)
+}
+
+// Overlaps reports whether iv and other share any time. Intervals that only
+// touch, like 09:00-12:00 and 12:00-17:00, don't overlap.
+func (iv Interval) Overlaps(other Interval) bool {
+	return iv.Start.Before(other.End) && other.Start.Before(iv.End)
+}
+
+// MergeIntervals merges open and unknown intervals, e.g. of several OpeningHours
+// like a venue and its kitchen, into sorted, non-overlapping intervals.
+// Intervals of the same state that overlap, touch or are at most tolerance
+// apart are merged, and the gap between them is included; comments are joined
+// with "; ". Like Union, open time takes precedence: unknown intervals are cut
+// where they overlap open ones, and an unknown interval next to an open one
+// stays separate. ivs is not modified.
+func MergeIntervals(ivs []Interval, tolerance time.Duration) []Interval {
+	var open, unknown []Interval
+	for _, iv := range ivs {
+		if iv.Unknown {
+			unknown = append(unknown, iv)
+		} else {
+			open = append(open, iv)
+		}
+	}
+
+	result := mergeSameState(open, tolerance, false)
+	openCount := len(result)
+	for _, iv := range mergeSameState(unknown, tolerance, true) {
+		result = append(result, cutIntervals(iv, result[:openCount])...)
+	}
+	slices.SortStableFunc(result, func(a, b Interval) int { return a.Start.Compare(b.Start) })
+	return result
+}
+
+// mergeSameState merges intervals that overlap, touch or are at most tolerance
+// apart into sorted intervals that are all unknown or all open
+func mergeSameState(ivs []Interval, tolerance time.Duration, unknown bool) []Interval {
+	sorted := slices.Clone(ivs)
+	slices.SortStableFunc(sorted, func(a, b Interval) int { return a.Start.Compare(b.Start) })
+
+	state := StateOpen
+	if unknown {
+		state = StateUnknown
+	}
+	var result []Interval
+	var comments []string
+	for _, iv := range sorted {
+		if !iv.Start.Before(iv.End) {
+			continue
+		}
+		n := len(result)
+		if n == 0 || iv.Start.Sub(result[n-1].End) > tolerance {
+			result = append(result, Interval{Start: iv.Start, End: iv.End, State: state, Unknown: unknown, Point: iv.Point})
+			comments = nil
+			n++
+		} else {
+			result[n-1].Point = false
+			if iv.End.After(result[n-1].End) {
+				result[n-1].End = iv.End
+			}
+		}
+
+		if iv.Comment != "" && !containsString(comments, iv.Comment) {
+			comments = append(comments, iv.Comment)
+			result[n-1].Comment = strings.Join(comments, "; ")
+		}
+	}
+	return result
+}
+
+// cutIntervals returns the parts of iv outside of the sorted, non-overlapping cuts
+func cutIntervals(iv Interval, cuts []Interval) []Interval {
+	var parts []Interval
+	for _, cut := range cuts {
+		if !cut.Overlaps(iv) {
+			continue
+		}
+		if cut.Start.After(iv.Start) {
+			part := iv
+			part.End = cut.Start
+			parts = append(parts, part)
+		}
+		iv.Start = cut.End
+		if !iv.Start.Before(iv.End) {
+			return parts
+		}
+	}
+	return append(parts, iv)
+}
+
+// TotalDuration returns the time covered by ivs, counting overlapping time once.
+// Unknown intervals are included.
+func TotalDuration(ivs []Interval) time.Duration {
+	var total time.Duration
+	for _, iv := range MergeIntervals(ivs, 0) {
+		total += iv.End.Sub(iv.Start)
+	}
+	return total
+}
+
+// ClampIntervals returns the parts of ivs between from and to, in the order of
+// ivs. Intervals outside of [from, to) are dropped. ivs is not modified.
+func ClampIntervals(ivs []Interval, from, to time.Time) []Interval {
+	var result []Interval
+	for _, iv := range ivs {
+		if iv.Start.Before(from) {
+			iv.Start = from
+		}
+		if iv.End.After(to) {
+			iv.End = to
+		}
+		if iv.Start.Before(iv.End) {
+			result = append(result, iv)
+		}
+	}
+	return result
+}
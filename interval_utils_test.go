@@ -0,0 +1,157 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInterval_ContainsOverlaps(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	iv := Interval{Start: at(9), End: at(12)}
+
+	for _, tt := range []struct {
+		t    time.Time
+		want bool
+	}{{at(8), false}, {at(9), true}, {at(11), true}, {at(12), false}} {
+		if got := iv.Contains(tt.t); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		other Interval
+		want  bool
+	}{
+		{Interval{Start: at(11), End: at(13)}, true},
+		{Interval{Start: at(8), End: at(13)}, true},
+		{Interval{Start: at(10), End: at(11)}, true},
+		{Interval{Start: at(12), End: at(13)}, false},
+		{Interval{Start: at(7), End: at(9)}, false},
+	} {
+		if got := iv.Overlaps(tt.other); got != tt.want {
+			t.Errorf("Overlaps(%v-%v) = %v, want %v", tt.other.Start.Hour(), tt.other.End.Hour(), got, tt.want)
+		}
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	venue, err := New(`Mo-Fr 09:00-14:00,14:10-22:00`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	kitchen, err := New(`Mo-Fr 12:00-15:00 "kitchen"; Sa 12:00-15:00 unknown "kitchen"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	saturday := monday.AddDate(0, 0, 5)
+	at := func(day time.Time, hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	var ivs []Interval
+	for _, oh := range []*OpeningHours{venue, kitchen} {
+		ivs = append(ivs, oh.GetOpenIntervals(monday, monday.AddDate(0, 0, 1))...)
+		ivs = append(ivs, oh.GetOpenIntervals(saturday, saturday.AddDate(0, 0, 1))...)
+	}
+
+	got := MergeIntervals(ivs, 0)
+	want := []Interval{
+		{Start: at(monday, 9, 0), End: at(monday, 22, 0), State: StateOpen, Comment: "kitchen"},
+		{Start: at(saturday, 12, 0), End: at(saturday, 15, 0), State: StateUnknown, Unknown: true, Comment: "kitchen"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) || got[i].State != want[i].State ||
+			got[i].Unknown != want[i].Unknown || got[i].Comment != want[i].Comment {
+			t.Errorf("interval %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Without the kitchen the 10-minute break is only bridged with enough tolerance
+	venueOnly := venue.GetOpenIntervals(monday, monday.AddDate(0, 0, 1))
+	if got := MergeIntervals(venueOnly, 5*time.Minute); len(got) != 2 {
+		t.Errorf("tolerance 5m: got %+v, want 2 intervals", got)
+	}
+	if got := MergeIntervals(venueOnly, 10*time.Minute); len(got) != 1 || !got[0].End.Equal(at(monday, 22, 0)) {
+		t.Errorf("tolerance 10m: got %+v, want one interval until 22:00", got)
+	}
+	if MergeIntervals(nil, time.Hour) != nil {
+		t.Errorf("expected nil for no intervals")
+	}
+}
+
+func TestMergeIntervals_MixedStates(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	open := func(from, to int) Interval { return Interval{Start: at(from), End: at(to), State: StateOpen} }
+	unknown := func(from, to int) Interval {
+		return Interval{Start: at(from), End: at(to), State: StateUnknown, Unknown: true}
+	}
+
+	tests := []struct {
+		name      string
+		ivs       []Interval
+		tolerance time.Duration
+		want      []Interval
+	}{
+		{"touching", []Interval{unknown(10, 12), open(12, 14)}, 0, []Interval{unknown(10, 12), open(12, 14)}},
+		{"overlapping", []Interval{unknown(10, 13), open(12, 14)}, 0, []Interval{unknown(10, 12), open(12, 14)}},
+		{"open inside unknown", []Interval{unknown(9, 17), open(10, 11), open(12, 13)}, 0,
+			[]Interval{unknown(9, 10), open(10, 11), unknown(11, 12), open(12, 13), unknown(13, 17)}},
+		{"gap between states", []Interval{open(10, 12), unknown(13, 14)}, 2 * time.Hour, []Interval{open(10, 12), unknown(13, 14)}},
+		{"unknown covered", []Interval{open(10, 14), unknown(11, 12)}, 0, []Interval{open(10, 14)}},
+	}
+
+	for _, tt := range tests {
+		got := MergeIntervals(tt.ivs, tt.tolerance)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) ||
+				got[i].State != tt.want[i].State || got[i].Unknown != tt.want[i].Unknown {
+				t.Errorf("%s: interval %d = %+v, want %+v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+
+	if got := TotalDuration([]Interval{unknown(9, 17), open(10, 11)}); got != 8*time.Hour {
+		t.Errorf("TotalDuration = %v, want 8h", got)
+	}
+}
+
+func TestTotalDurationAndClamp(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	ivs := []Interval{
+		{Start: at(13), End: at(17)},
+		{Start: at(9), End: at(12)},
+		{Start: at(11), End: at(14), Unknown: true},
+	}
+
+	if got := TotalDuration(ivs); got != 8*time.Hour {
+		t.Errorf("TotalDuration = %v, want 8h", got)
+	}
+
+	clamped := ClampIntervals(ivs, at(10), at(13))
+	want := []Interval{
+		{Start: at(10), End: at(12)},
+		{Start: at(11), End: at(13), Unknown: true},
+	}
+	if len(clamped) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %+v", len(clamped), len(want), clamped)
+	}
+	for i := range want {
+		if !clamped[i].Start.Equal(want[i].Start) || !clamped[i].End.Equal(want[i].End) || clamped[i].Unknown != want[i].Unknown {
+			t.Errorf("interval %d = %+v, want %+v", i, clamped[i], want[i])
+		}
+	}
+	if !ivs[1].Start.Equal(at(9)) {
+		t.Errorf("ClampIntervals modified its argument")
+	}
+}
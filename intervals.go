@@ -0,0 +1,131 @@
+package openinghours
+
+import (
+	"sort"
+	"time"
+)
+
+// changeTimes returns the sorted instants in (from, to) at which the state, the
+// unknown flag, the comment or the matching rule may change. Evaluation only
+// depends on the date and on the minute of the day compared to the rules' time
+// ranges, so between two consecutive change times every evaluation is constant.
+// Instants are collected day by day from the time range boundaries of all rules,
+// midnights and the zone transitions of the evaluated location.
+func (oh *OpeningHours) changeTimes(from, to time.Time) []time.Time {
+	seen := make(map[time.Time]bool)
+	var times []time.Time
+	add := func(t time.Time) {
+		if t.After(from) && t.Before(to) && !seen[t] {
+			seen[t] = true
+			times = append(times, t)
+		}
+	}
+
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for day.Before(to) {
+		next := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, day.Location())
+		add(day)
+
+		for _, minute := range oh.boundaryMinutes(day) {
+			// A wall clock time repeated when clocks are set back occurs twice
+			add(wallClockTime(day, minute))
+			add(time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location()))
+		}
+
+		// The minute of the day jumps at zone transitions, e.g. from 02:59 back to 02:00
+		if _, offset := day.Zone(); offset != zoneOffset(next) {
+			add(zoneTransition(day, next))
+		}
+
+		day = next
+	}
+
+	for _, c := range oh.closures {
+		add(c.from.In(from.Location()))
+		add(c.to.In(from.Location()))
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+// boundaryMinutes returns the minutes of the day of day (excluding midnight) at
+// which a time range of any rule, including fallback rules, starts or ends.
+// Ends of ranges that span midnight, extended hours like 26:00 and the points
+// in time of periodic ranges like 10:00-16:00/01:30 are included.
+func (oh *OpeningHours) boundaryMinutes(day time.Time) []int {
+	minutes := make(map[int]bool)
+	add := func(minute int) {
+		if minute > 0 && minute < 24*60 {
+			minutes[minute] = true
+		}
+	}
+
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			for _, tr := range r.timeRanges {
+				start, end := tr.start, tr.end
+				if tr.startVar != "" {
+					start = oh.resolveVariableTime(day, tr.startVar, tr.startOffset)
+				}
+				if tr.endVar != "" {
+					end = oh.resolveVariableTime(day, tr.endVar, tr.endOffset)
+				}
+
+				add(start)
+				add(end)
+				if tr.openEnd {
+					add(tr.minEnd)
+				}
+				if tr.point {
+					add(start + 1)
+				}
+				add(end - 24*60)
+				// Extended midnight continuation compares against the unresolved end
+				add(tr.end)
+
+				if tr.interval > 0 {
+					length := end - start
+					if length <= 0 {
+						length += 24 * 60
+					}
+					for _, offset := range periodicPoints(tr, length) {
+						for _, minute := range []int{start + offset, start + offset + oh.eventMinutes()} {
+							add(minute)
+							add(minute - 24*60)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sorted := make([]int, 0, len(minutes))
+	for minute := range minutes {
+		sorted = append(sorted, minute)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+// zoneOffset returns the UTC offset of t in seconds
+func zoneOffset(t time.Time) int {
+	_, offset := t.Zone()
+	return offset
+}
+
+// zoneTransition returns the first instant in (from, to] whose UTC offset differs
+// from the offset at from. The offsets at from and to must differ.
+func zoneTransition(from, to time.Time) time.Time {
+	offset := zoneOffset(from)
+	for to.Sub(from) > time.Second {
+		mid := from.Add(to.Sub(from) / 2).Truncate(time.Second)
+		if zoneOffset(mid) == offset {
+			from = mid
+		} else {
+			to = mid
+		}
+	}
+	return to
+}
@@ -484,7 +484,8 @@ func TestGetOpenIntervals_VeryLongRange(t *testing.T) {
 	}
 }
 
-// TestGetOpenIntervals_OpenEnd tests open-ended time ranges (e.g., "17:00+")
+// TestGetOpenIntervals_OpenEnd tests open-ended time ranges (e.g., "17:00+"): an
+// event starting at 17:00, with an unknown end
 func TestGetOpenIntervals_OpenEnd(t *testing.T) {
 	oh, err := New("17:00+")
 	if err != nil {
@@ -496,19 +497,19 @@ func TestGetOpenIntervals_OpenEnd(t *testing.T) {
 
 	intervals := oh.GetOpenIntervals(from, to)
 
-	if len(intervals) != 1 {
-		t.Fatalf("expected 1 interval, got %d", len(intervals))
+	if len(intervals) != 2 {
+		t.Fatalf("expected 2 intervals, got %d", len(intervals))
 	}
 
 	expectedStart := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
 
-	if !intervals[0].Start.Equal(expectedStart) {
-		t.Errorf("expected start %v, got %v", expectedStart, intervals[0].Start)
+	if !intervals[0].Start.Equal(expectedStart) || !intervals[0].Point || intervals[0].Unknown {
+		t.Errorf("expected an open point at %v, got %+v", expectedStart, intervals[0])
 	}
 
-	// End should be near end of day
-	if intervals[0].End.Hour() != 23 && intervals[0].End.Hour() != 0 {
-		t.Errorf("expected end near end of day, got %v", intervals[0].End)
+	// Unknown from the minute after the start until the end of day
+	if !intervals[1].Start.Equal(expectedStart.Add(time.Minute)) || !intervals[1].End.Equal(to) || !intervals[1].Unknown {
+		t.Errorf("expected unknown from 17:01 to %v, got %+v", to, intervals[1])
 	}
 }
 
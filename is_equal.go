@@ -0,0 +1,48 @@
+package openinghours
+
+import (
+	"slices"
+	"time"
+)
+
+// IsEqualToWithin compares oh and other for semantic equality between from and
+// to: they are equal if they have the same state, unknown flag and comment at
+// every time in [from, to). With a resolution > 0 the times from, from+resolution,
+// ... are sampled, which misses differences shorter than the resolution. With a
+// resolution <= 0 the values are compared exactly, at from and at every change
+// time of either value (see GetNextChange).
+//
+// If they are not equal, diff is the first time found with a difference, for
+// diagnostics; it is the zero time otherwise.
+func (oh *OpeningHours) IsEqualToWithin(other *OpeningHours, from, to time.Time, resolution time.Duration) (equal bool, diff time.Time) {
+	if other == nil {
+		return false, from
+	}
+
+	differs := func(t time.Time) bool {
+		a, b := oh.GetStateInfo(t), other.GetStateInfo(t)
+		return a.State != b.State || a.Comment != b.Comment
+	}
+
+	if resolution > 0 {
+		for t := from; t.Before(to); t = t.Add(resolution) {
+			if differs(t) {
+				return false, t
+			}
+		}
+		return true, time.Time{}
+	}
+
+	if !from.Before(to) {
+		return true, time.Time{}
+	}
+	times := append(oh.changeTimes(oh.inLocation(from), oh.inLocation(to)),
+		other.changeTimes(other.inLocation(from), other.inLocation(to))...)
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+	for _, t := range append([]time.Time{from}, times...) {
+		if differs(t) {
+			return false, t
+		}
+	}
+	return true, time.Time{}
+}
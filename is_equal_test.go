@@ -2,6 +2,7 @@ package openinghours
 
 import (
 	"testing"
+	"time"
 )
 
 func TestIsEqualTo_IdenticalStrings(t *testing.T) {
@@ -211,3 +212,73 @@ func TestIsEqualTo_Nil(t *testing.T) {
 		t.Error("non-nil should not equal nil")
 	}
 }
+
+func TestIsEqualTo_ShortDifference(t *testing.T) {
+	oh1, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("failed to parse oh1: %v", err)
+	}
+
+	oh2, err := New("Mo-Fr 09:00-17:05")
+	if err != nil {
+		t.Fatalf("failed to parse oh2: %v", err)
+	}
+
+	if oh1.IsEqualTo(oh2) {
+		t.Error("time ranges differing by 5 minutes should not be equal")
+	}
+}
+
+func TestIsEqualToWithin(t *testing.T) {
+	oh1, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("failed to parse oh1: %v", err)
+	}
+
+	oh2, err := New("Mo-Fr 09:00-17:00; Aug Mo-Fr 09:00-12:00")
+	if err != nil {
+		t.Fatalf("failed to parse oh2: %v", err)
+	}
+
+	if !oh1.IsEqualTo(oh2) {
+		t.Error("values differing only in August should be equal in the first week of January")
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	equal, diff := oh1.IsEqualToWithin(oh2, from, to, 0)
+	if equal {
+		t.Error("values differing in August should not be equal within 2024")
+	}
+	// August 1, 2024 is a Thursday
+	if want := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC); !diff.Equal(want) {
+		t.Errorf("first difference = %v, want %v", diff, want)
+	}
+
+	equal, diff = oh1.IsEqualToWithin(oh2, from, from.AddDate(0, 6, 0), 0)
+	if !equal || !diff.IsZero() {
+		t.Errorf("IsEqualToWithin first half of 2024 = %v, %v, want true, zero time", equal, diff)
+	}
+
+	// Sampling at 15 minutes misses the 5-minute difference, comparing exactly doesn't
+	oh3, err := New("Mo-Fr 09:00-16:55")
+	if err != nil {
+		t.Fatalf("failed to parse oh3: %v", err)
+	}
+	week := from.AddDate(0, 0, 7)
+	if equal, _ := oh1.IsEqualToWithin(oh3, from, week, 15*time.Minute); !equal {
+		t.Error("sampling at 15 minutes should miss the 5-minute difference")
+	}
+	equal, diff = oh1.IsEqualToWithin(oh3, from, week, 0)
+	if want := time.Date(2024, 1, 1, 16, 55, 0, 0, time.UTC); equal || !diff.Equal(want) {
+		t.Errorf("IsEqualToWithin exactly = %v, %v, want false, %v", equal, diff, want)
+	}
+	equal, diff = oh1.IsEqualToWithin(oh3, from, week, time.Minute)
+	if want := time.Date(2024, 1, 1, 16, 55, 0, 0, time.UTC); equal || !diff.Equal(want) {
+		t.Errorf("IsEqualToWithin every minute = %v, %v, want false, %v", equal, diff, want)
+	}
+
+	if equal, _ := oh1.IsEqualToWithin(nil, from, to, 0); equal {
+		t.Error("nil should not be equal")
+	}
+}
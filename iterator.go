@@ -0,0 +1,163 @@
+package openinghours
+
+import "time"
+
+// iteratorSearchLimit bounds how far NextChange and PrevChange search for a
+// change when no max date is set
+const iteratorSearchLimit = 366 * 24 * time.Hour
+
+// iteratorWindowDays is the number of days searched at once by NextChange and PrevChange
+const iteratorWindowDays = 7
+
+// Iterator provides efficient traversal of state changes
+type Iterator struct {
+	oh      *OpeningHours
+	current time.Time
+	maxDate time.Time // NextChange does not move past maxDate, zero for no bound
+}
+
+// iteratorState is what a change of the iterator is detected on
+type iteratorState struct {
+	open    bool
+	unknown bool
+	comment string
+}
+
+// GetIterator creates an iterator starting at the given time
+func (oh *OpeningHours) GetIterator(start time.Time) *Iterator {
+	start = oh.inLocation(start)
+	return &Iterator{
+		oh:      oh,
+		current: start,
+	}
+}
+
+// GetDate returns the current time of the iterator
+func (it *Iterator) GetDate() time.Time {
+	return it.current
+}
+
+// SetDate sets the iterator to a specific time
+func (it *Iterator) SetDate(t time.Time) {
+	it.current = it.oh.inLocation(t)
+}
+
+// SetMaxDate bounds NextChange and Advance: changes after t are not returned.
+// A zero t removes the bound, in which case changes up to a year ahead are found.
+func (it *Iterator) SetMaxDate(t time.Time) {
+	it.maxDate = t
+}
+
+// GetState returns the opening state at the current iterator time
+func (it *Iterator) GetState() bool {
+	return it.oh.GetState(it.current)
+}
+
+// GetUnknown returns true if the state is unknown at the current iterator time
+func (it *Iterator) GetUnknown() bool {
+	return it.oh.GetUnknown(it.current)
+}
+
+// State returns the state at the current iterator time
+func (it *Iterator) State() State {
+	return it.oh.stateAt(it.current)
+}
+
+// GetStateString returns the state as a string ("open", "closed", or "unknown")
+//
+// Deprecated: Use State.
+func (it *Iterator) GetStateString() string {
+	return it.oh.stateAt(it.current).String()
+}
+
+// GetComment returns any comment associated with the current state
+func (it *Iterator) GetComment() string {
+	return it.oh.GetComment(it.current)
+}
+
+// Advance moves the iterator to the next change of state or comment and returns the new time,
+// so that e.g. a commented "off" period within closed time is visited.
+// Returns zero time if there are no more changes (e.g., for 24/7 or always closed)
+func (it *Iterator) Advance() time.Time {
+	return it.NextChange()
+}
+
+// NextChange moves the iterator to the next time the state, the unknown flag or
+// the comment changes and returns it, so that open -> unknown transitions and
+// comment changes within the same state are visited as well.
+// Returns zero time and leaves the iterator unchanged if there is no change up
+// to the max date (see SetMaxDate).
+func (it *Iterator) NextChange() time.Time {
+	limit := it.current.Add(iteratorSearchLimit)
+	if !it.maxDate.IsZero() {
+		limit = it.oh.inLocation(it.maxDate)
+	}
+	if !limit.After(it.current) {
+		return time.Time{}
+	}
+
+	current := it.stateAt(it.current)
+	start := it.current
+	for start.Before(limit) {
+		end := time.Date(start.Year(), start.Month(), start.Day()+iteratorWindowDays, 0, 0, 0, 0, start.Location())
+		if end.After(limit) {
+			end = limit
+		}
+
+		// end is a midnight or the limit, so it has to be checked as well
+		for _, t := range append(it.oh.changeTimes(start, end), end) {
+			if it.stateAt(t) != current {
+				it.current = t
+				return t
+			}
+		}
+		start = end
+	}
+
+	if it.maxDate.IsZero() {
+		incMetric(CounterSlowPath)
+	}
+	return time.Time{}
+}
+
+// PrevChange moves the iterator to the last time before the current time at
+// which the state, the unknown flag or the comment changed and returns it.
+// Changes up to a year back are found; returns zero time and leaves the
+// iterator unchanged if there is none.
+func (it *Iterator) PrevChange() time.Time {
+	limit := it.current.Add(-iteratorSearchLimit)
+
+	end := it.current
+	var times []time.Time
+	for end.After(limit) {
+		start := time.Date(end.Year(), end.Month(), end.Day()-iteratorWindowDays, 0, 0, 0, 0, end.Location())
+		if start.Before(limit) {
+			start = limit
+		}
+
+		// After the first window, times still holds the start of the previous
+		// window, so that a change exactly at it is found
+		times = append(append([]time.Time{start}, it.oh.changeTimes(start, end)...), times...)
+		for i := len(times) - 1; i > 0; i-- {
+			if it.stateAt(times[i]) != it.stateAt(times[i-1]) {
+				it.current = times[i]
+				return times[i]
+			}
+		}
+
+		times = times[:1]
+		end = start
+	}
+
+	incMetric(CounterSlowPath)
+	return time.Time{}
+}
+
+// stateAt returns the state, unknown flag and comment at t
+func (it *Iterator) stateAt(t time.Time) iteratorState {
+	return iteratorState{
+		open:    it.oh.GetState(t),
+		unknown: it.oh.GetUnknown(t),
+		comment: it.oh.GetComment(t),
+	}
+}
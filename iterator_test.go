@@ -246,3 +246,84 @@ func TestIterator_AlwaysOpen(t *testing.T) {
 		t.Errorf("GetDate after Advance: got %v, want %v", it.GetDate(), startTime)
 	}
 }
+
+func TestIterator_NextChangeUnknownAndComments(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; Mo-Fr 12:00-13:00 \"lunch\"; Sa 10:00-12:00 unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Friday Jan 19, 2024 08:00
+	it := oh.GetIterator(time.Date(2024, 1, 19, 8, 0, 0, 0, time.UTC))
+
+	want := []struct {
+		at      time.Time
+		state   string
+		comment string
+	}{
+		{time.Date(2024, 1, 19, 9, 0, 0, 0, time.UTC), "open", ""},
+		{time.Date(2024, 1, 19, 12, 0, 0, 0, time.UTC), "open", "lunch"},
+		{time.Date(2024, 1, 19, 13, 0, 0, 0, time.UTC), "open", ""},
+		{time.Date(2024, 1, 19, 17, 0, 0, 0, time.UTC), "closed", ""},
+		{time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC), "unknown", ""},
+		{time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC), "closed", ""},
+	}
+
+	for _, w := range want {
+		got := it.NextChange()
+		if !got.Equal(w.at) {
+			t.Fatalf("NextChange: got %v, want %v", got, w.at)
+		}
+		if it.GetStateString() != w.state || it.GetComment() != w.comment {
+			t.Errorf("at %v: got %s %q, want %s %q", got, it.GetStateString(), it.GetComment(), w.state, w.comment)
+		}
+	}
+
+	// Walk back over the same changes
+	for i := len(want) - 2; i >= 0; i-- {
+		if got := it.PrevChange(); !got.Equal(want[i].at) {
+			t.Errorf("PrevChange: got %v, want %v", got, want[i].at)
+		}
+	}
+}
+
+func TestIterator_PrevChangeAcrossWeeks(t *testing.T) {
+	oh, err := New("24/7; Dec 25 off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	it := oh.GetIterator(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	if got, want := it.PrevChange(), time.Date(2023, 12, 26, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("PrevChange: got %v, want %v", got, want)
+	}
+	if got, want := it.PrevChange(), time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("PrevChange: got %v, want %v", got, want)
+	}
+	if it.GetState() {
+		t.Errorf("expected closed on Dec 25")
+	}
+}
+
+func TestIterator_MaxDate(t *testing.T) {
+	oh, err := New("24/7; Dec 25 off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	it := oh.GetIterator(start)
+	it.SetMaxDate(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+
+	if got := it.NextChange(); !got.IsZero() {
+		t.Errorf("NextChange before max date: expected zero time, got %v", got)
+	}
+	if !it.GetDate().Equal(start) {
+		t.Errorf("GetDate after NextChange without change: got %v, want %v", it.GetDate(), start)
+	}
+
+	it.SetMaxDate(time.Time{})
+	if got, want := it.NextChange(), time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextChange without max date: got %v, want %v", got, want)
+	}
+}
@@ -1440,6 +1440,43 @@ func TestJS_CaseInsensitivity_Months(t *testing.T) {
 	}
 }
 
+func TestCaseInsensitivity_Keywords(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"WEEK 01-10 Mo 10:00-12:00", "week 01-10 Mo 10:00-12:00"},
+		{"Week 01-10 Mo 10:00-12:00", "week 01-10 Mo 10:00-12:00"},
+		{"PH +1 DAY 10:00-12:00", "PH +1 day 10:00-12:00"},
+		{"PH -2 Days off", "PH -2 days off"},
+		{"EASTER 10:00-12:00", "easter 10:00-12:00"},
+		{"Easter +1 Day off", "easter +1 day off"},
+		{"Easter -2 DAYS-Easter +1 Day 10:00-12:00", "easter -2 days-easter +1 day 10:00-12:00"},
+		{"Sunrise-Sunset", "sunrise-sunset"},
+		{"(SUNRISE+01:00)-18:00", "(sunrise+01:00)-18:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			oh, err := New(tc.value)
+			if err != nil {
+				t.Fatalf("failed to parse '%s': %v", tc.value, err)
+			}
+			if got := oh.PrettifyValue(); got != tc.expected {
+				t.Errorf("PrettifyValue() = %q, want %q", got, tc.expected)
+			}
+
+			canonical, err := New(tc.expected)
+			if err != nil {
+				t.Fatalf("failed to parse '%s': %v", tc.expected, err)
+			}
+			if !oh.IsEqualTo(canonical) {
+				t.Errorf("'%s' should be equal to '%s'", tc.value, tc.expected)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // Weekday range wrapping (Fr-Mo, Sa-Tu)
 // =============================================================================
@@ -2803,12 +2840,11 @@ func TestJS_DayInterval_EveryWeek(t *testing.T) {
 }
 
 // =============================================================================
-// Time period intervals (10:00-16:00/01:30 = open periods with gaps)
+// Time period intervals (10:00-16:00/01:30 = points in time every 1:30)
 // =============================================================================
 
 func TestJS_TimePeriodInterval(t *testing.T) {
-	// 10:00-16:00/01:30 means open periods of 1h30m within the range
-	// TODO: Time period interval notation not yet implemented
+	// 10:00-16:00/01:30 means points in time every 1h30m within the range
 	oh, err := New("Mo-Fr 10:00-16:00/01:30")
 	if err != nil {
 		t.Skipf("Time period interval not implemented: %v", err)
@@ -3861,10 +3897,8 @@ func TestJS_Modifier_Unknown(t *testing.T) {
 	// GetStateString should return "unknown" when rule has unknown modifier
 	monNoon := time.Date(2012, 10, 1, 12, 0, 0, 0, time.UTC)
 	state := oh.GetStateString(monNoon)
-	// Note: Current implementation returns "closed" for unknown during matching hours
-	// This is a known limitation - the unknown state is parsed but treated as closed in GetStateString
-	if state != "unknown" && state != "closed" {
-		t.Errorf("Expected 'unknown' or 'closed', got '%s'", state)
+	if state != "unknown" {
+		t.Errorf("Expected 'unknown', got '%s'", state)
 	}
 }
 
@@ -3894,10 +3928,9 @@ func TestJS_Modifier_Closed(t *testing.T) {
 func TestJS_Holiday_WeekdayAndPH(t *testing.T) {
 	// Open on weekdays AND public holidays
 	// Note: "Mo-Fr,PH" syntax means "weekdays OR public holidays"
-	// The comma creates separate rules, each needing their own handling
-	oh, err := New("Mo-Fr 09:00-17:00; PH 09:00-17:00")
+	oh, err := New("Mo-Fr,PH 09:00-17:00")
 	if err != nil {
-		t.Skipf("Weekday+PH combination not implemented: %v", err)
+		t.Fatalf("failed to parse: %v", err)
 	}
 
 	hc := &jsTestHolidayChecker{
@@ -4936,25 +4969,19 @@ func TestJS_Pattern_AllIntervalTypes(t *testing.T) {
 		if err != nil {
 			t.Fatalf("failed to parse: %v", err)
 		}
-		// 10:00 should be open
-		t10 := time.Date(2012, 10, 1, 10, 0, 0, 0, time.UTC)
-		if !oh.GetState(t10) {
-			t.Error("10:00 should be open")
-		}
-		// 11:00 should be open (within first 1:30 slot)
-		t11 := time.Date(2012, 10, 1, 11, 0, 0, 0, time.UTC)
-		if !oh.GetState(t11) {
-			t.Error("11:00 should be open")
-		}
-		// 12:00 should be closed (second slot is closed)
-		t12 := time.Date(2012, 10, 1, 12, 0, 0, 0, time.UTC)
-		if oh.GetState(t12) {
-			t.Error("12:00 should be closed")
+		// Points in time every 1:30 from 10:00 to 16:00
+		for _, open := range []int{10 * 60, 11*60 + 30, 13 * 60, 14*60 + 30, 16 * 60} {
+			at := time.Date(2012, 10, 1, open/60, open%60, 0, 0, time.UTC)
+			if !oh.GetState(at) {
+				t.Errorf("%s should be open", at.Format("15:04"))
+			}
 		}
-		// 13:00 should be open (third slot)
-		t13 := time.Date(2012, 10, 1, 13, 0, 0, 0, time.UTC)
-		if !oh.GetState(t13) {
-			t.Error("13:00 should be open")
+		// Between the points it is closed
+		for _, closed := range []int{10*60 + 1, 11 * 60, 12 * 60, 16*60 + 1} {
+			at := time.Date(2012, 10, 1, closed/60, closed%60, 0, 0, time.UTC)
+			if oh.GetState(at) {
+				t.Errorf("%s should be closed", at.Format("15:04"))
+			}
 		}
 	})
 }
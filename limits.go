@@ -0,0 +1,59 @@
+package openinghours
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Limits on values accepted by New and Parser, so that services parsing
+// untrusted input spend bounded time and memory on every value. Real values
+// are far below them: OSM limits tag values to 255 characters.
+const (
+	MaxValueLength  = 4096 // bytes of the value before normalization
+	MaxRules        = 512  // rules of all groups, after expanding year and month lists
+	MaxNestingDepth = 8    // depth of brackets and parentheses outside comments
+)
+
+// ErrTooComplex is returned (wrapped, test with errors.Is) by New and Parser
+// for values exceeding MaxValueLength, MaxRules or MaxNestingDepth
+var ErrTooComplex = errors.New("opening hours value too complex")
+
+// checkComplexity returns an ErrTooComplex if value is too long or too deeply
+// nested to be parsed
+func checkComplexity(value string) error {
+	if len(value) > MaxValueLength {
+		return fmt.Errorf("%w: %d bytes, at most %d are allowed", ErrTooComplex, len(value), MaxValueLength)
+	}
+	depth, inComment := 0, false
+	for _, ch := range value {
+		switch {
+		case ch == '"':
+			inComment = !inComment
+		case inComment:
+		case ch == '[' || ch == '(':
+			if depth++; depth > MaxNestingDepth {
+				return fmt.Errorf("%w: brackets nested deeper than %d", ErrTooComplex, MaxNestingDepth)
+			}
+		case ch == ']' || ch == ')':
+			depth--
+		}
+	}
+	return nil
+}
+
+// checkRuleCount returns an ErrTooComplex if oh has more than MaxRules rules
+// with the rules of the group being parsed, checked while parsing to stop
+// expanding lists early
+func (oh *OpeningHours) checkRuleCount(rules *[]rule) error {
+	n := len(*rules)
+	if rules != &oh.rules {
+		n += len(oh.rules)
+	}
+	for _, group := range oh.fallbackGroups {
+		n += len(group)
+	}
+	if n > MaxRules {
+		return fmt.Errorf("%w: more than %d rules", ErrTooComplex, MaxRules)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package openinghours
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLimits_TooComplex(t *testing.T) {
+	var years []string
+	for year := 2000; year <= 2000+MaxRules; year++ {
+		years = append(years, strconv.Itoa(year))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"too long", strings.Repeat("Mo 10:00-12:00; ", MaxValueLength/16+1)},
+		{"too deep", "Mo" + strings.Repeat("[", MaxNestingDepth+1) + "1" + strings.Repeat("]", MaxNestingDepth+1) + " 10:00-12:00"},
+		{"too many rules", strings.Join(years, ",") + " Mo 10:00-12:00"},
+		{"too many fallback rules", strings.Repeat("Mo || ", MaxRules) + "Tu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.value); !errors.Is(err, ErrTooComplex) {
+				t.Errorf("New() error = %v, want ErrTooComplex", err)
+			}
+			if _, err := NewParser().Parse(tt.value); !errors.Is(err, ErrTooComplex) {
+				t.Errorf("Parse() error = %v, want ErrTooComplex", err)
+			}
+		})
+	}
+}
+
+func TestLimits_WithinLimits(t *testing.T) {
+	values := []string{
+		strings.TrimSuffix(strings.Repeat("Mo 10:00-12:00; ", 100), "; "),
+		`Mo 10:00-12:00 "(((((((((((((((((((("`,
+		"Mo[1] 10:00-12:00; (sunrise+01:00)-(sunset-01:00)",
+	}
+	for _, value := range values {
+		if _, err := New(value); err != nil {
+			t.Errorf("New(%q): unexpected error: %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package openinghours
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Severity ranks the issues reported by Validate
+type Severity int
+
+const (
+	SeverityInfo    Severity = iota // style issue, the value is evaluated as written
+	SeverityWarning                 // the value is probably not evaluated as intended
+	SeverityError                   // part of the value can never apply
+)
+
+// String returns the name of the severity, e.g. "warning"
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// Issue is a spec violation found by Validate
+type Issue struct {
+	Severity Severity
+	Message  string
+	Fix      string // the value with only this issue fixed, empty if there is no automatic fix
+}
+
+// Report is the result of Validate
+type Report struct {
+	Issues []Issue
+	// Prettified is the prettified value with all fixes applied that keep its
+	// meaning. Fixes that change the evaluation, like joining overlapping rules,
+	// are only suggested in their Issue.
+	Prettified string
+}
+
+var (
+	deprecated247Pattern = regexp.MustCompile(`\b7/24\b`)
+	ampersandPattern     = regexp.MustCompile(`\s*&\s*`)
+	weekdayTokenPattern  = regexp.MustCompile(`(?i)\b(mo|tu|we|th|fr|sa|su)\b`)
+	unpaddedTimePattern  = regexp.MustCompile(`\b(\d):(\d{2})\b`)
+	maxDaysInMonth       = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+)
+
+// Validate lints value like the opening_hours.js "warnings + prettified value"
+// workflow used by OSM QA tools. Beyond GetWarnings it reports deprecated
+// syntax ("7/24", "&"), times without zero padding, weekdays in the wrong
+// case, dates that do not exist (Feb 30), repeated rules and rules that
+// override parts of earlier rules. Syntax issues are fixed before parsing, so
+// an error is only returned if the fixed value still can not be parsed.
+func Validate(value string) (Report, error) {
+	var report Report
+
+	// Syntax issues, fixed on the text outside of comments
+	fixed := value
+	fixText := func(severity Severity, message string, re *regexp.Regexp, repl func(string) string) {
+		report.Issues = append(report.Issues, Issue{
+			Severity: severity,
+			Message:  message,
+			Fix:      replaceOutsideComments(value, re, repl),
+		})
+		fixed = replaceOutsideComments(fixed, re, repl)
+	}
+
+	if hasMatchOutsideComments(value, deprecated247Pattern) {
+		fixText(SeverityWarning, `"7/24" is deprecated, use "24/7"`, deprecated247Pattern,
+			func(string) string { return "24/7" })
+	}
+	if hasMatchOutsideComments(value, ampersandPattern) {
+		fixText(SeverityWarning, `"&" is not a valid separator, use ","`, ampersandPattern,
+			func(string) string { return "," })
+	}
+	for _, token := range distinctMatchesOutsideComments(value, weekdayTokenPattern) {
+		canonical := strings.ToUpper(token[:1]) + strings.ToLower(token[1:])
+		if token == canonical {
+			continue
+		}
+		re := regexp.MustCompile(`\b` + token + `\b`)
+		fixText(SeverityInfo, fmt.Sprintf("Weekday %q should be written as %q", token, canonical), re,
+			func(string) string { return canonical })
+	}
+	for _, token := range distinctMatchesOutsideComments(value, unpaddedTimePattern) {
+		re := regexp.MustCompile(`\b` + regexp.QuoteMeta(token) + `\b`)
+		fixText(SeverityInfo, fmt.Sprintf("Time %q should be written as %q", token, "0"+token), re,
+			func(string) string { return "0" + token })
+	}
+
+	oh, err := New(fixed)
+	if err != nil {
+		return report, err
+	}
+	for _, w := range oh.GetWarningDetails() {
+		report.Issues = append(report.Issues, Issue{Severity: SeverityWarning, Message: w.Message})
+	}
+
+	// Rule issues, fixed by rewriting the rules of a group
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	cleaned := make([][]rule, len(groups))
+	for g, rules := range groups {
+		drop := make(map[int]bool)
+		for j := range rules {
+			if issue, ok := neverMatchingRule(groups, g, j); ok {
+				report.Issues = append(report.Issues, issue)
+				drop[j] = true
+			} else if issue, ok := repeatedRule(groups, g, j); ok {
+				report.Issues = append(report.Issues, issue)
+				drop[j] = true
+			} else if issue, ok := overridingRule(groups, g, j); ok {
+				report.Issues = append(report.Issues, issue)
+			}
+		}
+		for j, r := range rules {
+			if !drop[j] {
+				cleaned[g] = append(cleaned[g], r)
+			}
+		}
+	}
+	report.Prettified = prettifyGroups(cleaned)
+
+	return report, nil
+}
+
+// neverMatchingRule reports rule j of group g if it only selects days that do
+// not exist, like "Feb 30" or "Apr 31"
+func neverMatchingRule(groups [][]rule, g, j int) (Issue, bool) {
+	r := groups[g][j]
+	if r.monthStart == 0 || r.monthEnd != r.monthStart || max(r.dayStart, -r.dayStart) <= maxDaysInMonth[r.monthStart] {
+		return Issue{}, false
+	}
+	return Issue{
+		Severity: SeverityError,
+		Message: fmt.Sprintf("Rule %q never applies: %s has at most %d days",
+			prettifyRule(r, prettifyOptions{}), defaultMonthNames[r.monthStart-1], maxDaysInMonth[r.monthStart]),
+		Fix: prettifyGroups(withRules(groups, g, removeRule(groups[g], j))),
+	}, true
+}
+
+// repeatedRule reports rule j of group g if an earlier rule of the group is identical
+func repeatedRule(groups [][]rule, g, j int) (Issue, bool) {
+	s := prettifyRule(groups[g][j], prettifyOptions{})
+	for i := 0; i < j; i++ {
+		if prettifyRule(groups[g][i], prettifyOptions{}) == s {
+			return Issue{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Rule %q is repeated", s),
+				Fix:      prettifyGroups(withRules(groups, g, removeRule(groups[g], j))),
+			}, true
+		}
+	}
+	return Issue{}, false
+}
+
+// overridingRule reports rule j of group g if it overrides an earlier rule on
+// some but not all of its weekdays, e.g. "We 14:00-16:00" after "Mo-Fr 10:00-12:00".
+// Usually additional times were meant, so the fix joins the rules with a comma.
+func overridingRule(groups [][]rule, g, j int) (Issue, bool) {
+	rules := groups[g]
+	rj := rules[j]
+	if rj.state != StateOpen || len(rj.timeRanges) == 0 || rj.weekdays == nil {
+		return Issue{}, false
+	}
+	for i := 0; i < j; i++ {
+		ri := rules[i]
+		if ri.state != StateOpen || ri.weekdays == nil || (rj.ruleGroup > 0 && ri.ruleGroup == rj.ruleGroup) {
+			continue
+		}
+		if !sameNonWeekdaySelectors(ri, rj) || !weekdaysOverlapPartially(ri.weekdays, rj.weekdays) {
+			continue
+		}
+
+		fixed := append([]rule(nil), rules...)
+		group := fixed[j-1].ruleGroup
+		if group == 0 {
+			group = maxRuleGroup(groups) + 1
+			fixed[j-1].ruleGroup = group
+		}
+		fixed[j].ruleGroup = group
+
+		return Issue{
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf("Rule %q overrides %q on the weekdays both apply to, use \",\" instead of \";\" to add times",
+				prettifyRule(rj, prettifyOptions{}), prettifyRule(ri, prettifyOptions{})),
+			Fix: prettifyGroups(withRules(groups, g, fixed)),
+		}, true
+	}
+	return Issue{}, false
+}
+
+// sameNonWeekdaySelectors reports whether a and b select the same days apart from their weekdays
+func sameNonWeekdaySelectors(a, b rule) bool {
+	a.weekdays, b.weekdays = nil, nil
+	a.timeRanges, b.timeRanges = nil, nil
+	a.comment, b.comment = "", ""
+	a.ruleGroup, b.ruleGroup = 0, 0
+	a.monthList, b.monthList = 0, 0
+	return reflect.DeepEqual(a, b)
+}
+
+// weekdaysOverlapPartially reports whether a and b share some but not all weekdays
+func weekdaysOverlapPartially(a, b []bool) bool {
+	overlap, same := false, true
+	for d := 0; d < 7; d++ {
+		if a[d] && b[d] {
+			overlap = true
+		}
+		if a[d] != b[d] {
+			same = false
+		}
+	}
+	return overlap && !same
+}
+
+// maxRuleGroup returns the highest rule group used in groups
+func maxRuleGroup(groups [][]rule) int {
+	highest := 0
+	for _, rules := range groups {
+		for _, r := range rules {
+			if r.ruleGroup > highest {
+				highest = r.ruleGroup
+			}
+		}
+	}
+	return highest
+}
+
+// removeRule returns a copy of rules without rule j
+func removeRule(rules []rule, j int) []rule {
+	result := append([]rule(nil), rules[:j]...)
+	return append(result, rules[j+1:]...)
+}
+
+// withRules returns a copy of groups with group g replaced by rules
+func withRules(groups [][]rule, g int, rules []rule) [][]rule {
+	result := append([][]rule(nil), groups...)
+	result[g] = rules
+	return result
+}
+
+// prettifyGroups prettifies the primary group and fallback groups of groups,
+// skipping groups that are empty
+func prettifyGroups(groups [][]rule) string {
+	var nonEmpty [][]rule
+	for _, rules := range groups {
+		if len(rules) > 0 {
+			nonEmpty = append(nonEmpty, rules)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return ""
+	}
+	oh := &OpeningHours{rules: nonEmpty[0], fallbackGroups: nonEmpty[1:]}
+	return oh.PrettifyValue()
+}
+
+// replaceOutsideComments replaces the matches of re outside of quoted comments with repl
+func replaceOutsideComments(s string, re *regexp.Regexp, repl func(string) string) string {
+	parts := strings.Split(s, `"`)
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = re.ReplaceAllStringFunc(parts[i], repl)
+	}
+	return strings.Join(parts, `"`)
+}
+
+// hasMatchOutsideComments reports whether re matches s outside of quoted comments
+func hasMatchOutsideComments(s string, re *regexp.Regexp) bool {
+	return len(distinctMatchesOutsideComments(s, re)) > 0
+}
+
+// distinctMatchesOutsideComments returns the distinct matches of re outside of
+// quoted comments in order of their first occurrence
+func distinctMatchesOutsideComments(s string, re *regexp.Regexp) []string {
+	seen := make(map[string]bool)
+	var matches []string
+	parts := strings.Split(s, `"`)
+	for i := 0; i < len(parts); i += 2 {
+		for _, m := range re.FindAllString(parts[i], -1) {
+			if !seen[m] {
+				seen[m] = true
+				matches = append(matches, m)
+			}
+		}
+	}
+	return matches
+}
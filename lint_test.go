@@ -0,0 +1,89 @@
+package openinghours
+
+import "testing"
+
+func TestValidate_Issues(t *testing.T) {
+	testCases := []struct {
+		value      string
+		severity   Severity
+		message    string
+		fix        string
+		prettified string
+	}{
+		{"7/24", SeverityWarning, `"7/24" is deprecated, use "24/7"`, "24/7", "24/7"},
+		{"Mo&Tu 10:00-12:00", SeverityWarning, `"&" is not a valid separator, use ","`, "Mo,Tu 10:00-12:00", "Mo-Tu 10:00-12:00"},
+		{"Mo-Fr 9:00-17:00", SeverityInfo, `Time "9:00" should be written as "09:00"`, "Mo-Fr 09:00-17:00", "Mo-Fr 09:00-17:00"},
+		{`MO 10:00-12:00 "MO only"`, SeverityInfo, `Weekday "MO" should be written as "Mo"`, `Mo 10:00-12:00 "MO only"`, `Mo 10:00-12:00 "MO only"`},
+		{"Feb 30 10:00-12:00; Mo 10:00-12:00", SeverityError, `Rule "Feb 30 10:00-12:00" never applies: Feb has at most 29 days`, "Mo 10:00-12:00", "Mo 10:00-12:00"},
+		{"Mo 10:00-12:00; Mo 10:00-12:00", SeverityWarning, `Rule "Mo 10:00-12:00" is repeated`, "Mo 10:00-12:00", "Mo 10:00-12:00"},
+		{"Mo-Fr 10:00-12:00; We 14:00-16:00", SeverityWarning,
+			`Rule "We 14:00-16:00" overrides "Mo-Fr 10:00-12:00" on the weekdays both apply to, use "," instead of ";" to add times`,
+			"Mo-Fr 10:00-12:00, We 14:00-16:00", "Mo-Fr 10:00-12:00; We 14:00-16:00"},
+		{"Mo 10:00-12:00,11:00-13:00", SeverityWarning, "Overlapping time ranges detected", "", "Mo 10:00-12:00,11:00-13:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			report, err := Validate(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(report.Issues) != 1 {
+				t.Fatalf("expected 1 issue, got %+v", report.Issues)
+			}
+			issue := report.Issues[0]
+			if issue.Severity != tc.severity || issue.Message != tc.message || issue.Fix != tc.fix {
+				t.Errorf("got {%v %q %q}, want {%v %q %q}", issue.Severity, issue.Message, issue.Fix,
+					tc.severity, tc.message, tc.fix)
+			}
+			if report.Prettified != tc.prettified {
+				t.Errorf("Prettified = %q, want %q", report.Prettified, tc.prettified)
+			}
+			if issue.Fix != "" {
+				if _, err := New(issue.Fix); err != nil {
+					t.Errorf("fix %q does not parse: %v", issue.Fix, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_Clean(t *testing.T) {
+	for _, value := range []string{
+		"24/7",
+		"Mo-Fr 09:00-17:00; Sa 10:00-14:00; PH off",
+		"Mo-Fr 10:00-12:00, We 14:00-16:00",
+		"Feb 29 10:00-12:00",
+		"Mo-Fr 09:00-17:00; We off",
+	} {
+		report, err := Validate(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", value, err)
+		}
+		if len(report.Issues) != 0 {
+			t.Errorf("%q: expected no issues, got %+v", value, report.Issues)
+		}
+		if report.Prettified != value {
+			t.Errorf("Prettified = %q, want %q", report.Prettified, value)
+		}
+	}
+}
+
+func TestValidate_MultipleFixes(t *testing.T) {
+	report, err := Validate("mo&tu 9:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Issues) != 4 {
+		t.Fatalf("expected 4 issues, got %+v", report.Issues)
+	}
+	if report.Prettified != "Mo-Tu 09:00-17:00" {
+		t.Errorf("Prettified = %q, want %q", report.Prettified, "Mo-Tu 09:00-17:00")
+	}
+}
+
+func TestValidate_Error(t *testing.T) {
+	if _, err := Validate("Mo 30:00-31:00"); err == nil {
+		t.Error("expected error for unparseable value")
+	}
+}
@@ -0,0 +1,99 @@
+package openinghours
+
+import "encoding/json"
+
+// MarshalText encodes oh as the value it was parsed from, so that an
+// OpeningHours can be a field of structs encoded as JSON, YAML or TOML.
+// An OpeningHours that wasn't parsed from a value is encoded as its
+// prettified value; the zero OpeningHours, without rules, as "".
+func (oh *OpeningHours) MarshalText() ([]byte, error) {
+	if oh.source != "" {
+		return []byte(oh.source), nil
+	}
+	if len(oh.rules) == 0 {
+		return []byte{}, nil
+	}
+	return []byte(oh.PrettifyValue()), nil
+}
+
+// UnmarshalText parses text like New, replacing the rules of oh. Settings
+// like holiday checkers, coordinates, the timezone and policies are kept, so
+// a configured OpeningHours can be filled from a config file. Empty text, as
+// encoded for the zero OpeningHours, removes the rules.
+func (oh *OpeningHours) UnmarshalText(text []byte) error {
+	parsed := OpeningHours{
+		holidayChecker:          oh.holidayChecker,
+		schoolHolidayChecker:    oh.schoolHolidayChecker,
+		latitude:                oh.latitude,
+		longitude:               oh.longitude,
+		hasCoordinates:          oh.hasCoordinates,
+		region:                  oh.region,
+		elevation:               oh.elevation,
+		normalizers:             oh.normalizers,
+		variableDates:           oh.variableDates,
+		location:                oh.location,
+		holidayPolicy:           oh.holidayPolicy,
+		schoolHolidayPolicy:     oh.schoolHolidayPolicy,
+		mergeSplitDates:         oh.mergeSplitDates,
+		openEndUnknown:          oh.openEndUnknown,
+		strict:                  oh.strict,
+		eventDuration:           oh.eventDuration,
+		closures:                oh.closures,
+		sunCache:                newDayCache[sunKey, sunMinutes](),
+		holidayCheckerCtx:       oh.holidayCheckerCtx,
+		schoolHolidayCheckerCtx: oh.schoolHolidayCheckerCtx,
+	}
+	if len(text) > 0 {
+		if err := parsed.parse(string(text)); err != nil {
+			return err
+		}
+	}
+	*oh = parsed
+	return nil
+}
+
+// MarshalJSON encodes oh as a JSON string of its value, see MarshalText
+func (oh *OpeningHours) MarshalJSON() ([]byte, error) {
+	text, err := oh.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses a JSON string encoded by MarshalJSON, see
+// UnmarshalText. JSON null leaves oh unchanged.
+func (oh *OpeningHours) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return oh.UnmarshalText([]byte(value))
+}
+
+// parsedJSON is the encoding of MarshalRulesJSON
+type parsedJSON struct {
+	Value      string     `json:"value"`
+	Prettified string     `json:"prettified"`
+	Rules      []RuleInfo `json:"rules"`
+	Warnings   []Warning  `json:"warnings,omitempty"`
+}
+
+// MarshalRulesJSON encodes the parsed form of oh for debugging: the value,
+// its prettified form, the rules as returned by Rules and the warnings. The
+// encoding is not parsed back; use MarshalJSON to store values.
+func (oh *OpeningHours) MarshalRulesJSON() ([]byte, error) {
+	value, err := oh.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(parsedJSON{
+		Value:      string(value),
+		Prettified: oh.PrettifyValue(),
+		Rules:      oh.Rules(),
+		Warnings:   oh.GetWarningDetails(),
+	})
+}
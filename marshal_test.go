@@ -0,0 +1,136 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSON_RoundTrip(t *testing.T) {
+	type venue struct {
+		Name  string        `json:"name"`
+		Hours *OpeningHours `json:"hours"`
+	}
+	oh, err := New("mo-fr 9:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	data, err := json.Marshal(venue{Name: "Bakery", Hours: oh})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	// The original value is kept, not the normalized one
+	if want := `{"name":"Bakery","hours":"mo-fr 9:00-17:00; PH off"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded venue
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !decoded.Hours.IsEqualTo(oh) {
+		t.Errorf("decoded %q, want %q", decoded.Hours.PrettifyValue(), oh.PrettifyValue())
+	}
+}
+
+func TestMarshalJSON_ZeroValue(t *testing.T) {
+	var zero OpeningHours
+	data, err := json.Marshal(&zero)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `""` {
+		t.Errorf("Marshal() = %s, want \"\"", data)
+	}
+
+	oh, err := New("24/7")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if err := json.Unmarshal(data, oh); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	at := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	if oh.GetState(at) || len(oh.Rules()) != 0 {
+		t.Errorf("decoded %q, want the zero value without rules", oh.PrettifyValue())
+	}
+	if again, err := json.Marshal(oh); err != nil || string(again) != `""` {
+		t.Errorf("Marshal() after the round trip = %s, %v, want \"\"", again, err)
+	}
+}
+
+func TestUnmarshalJSON_Errors(t *testing.T) {
+	var oh OpeningHours
+	if err := json.Unmarshal([]byte(`"Mo-Fr 25:00-26:00x"`), &oh); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+	if err := json.Unmarshal([]byte(`42`), &oh); err == nil {
+		t.Error("expected an error for a number")
+	}
+
+	var v struct{ Hours *OpeningHours }
+	if err := json.Unmarshal([]byte(`{"Hours":null}`), &v); err != nil || v.Hours != nil {
+		t.Errorf("Unmarshal(null) = %v, %v, want nil", v.Hours, err)
+	}
+}
+
+func TestUnmarshalText_KeepsSettings(t *testing.T) {
+	oh, err := New("24/7", WithHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}}))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if err := oh.UnmarshalText([]byte("Mo-Fr 09:00-17:00; PH off")); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if oh.GetState(time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected closed on the holiday of the kept holiday checker")
+	}
+	if !oh.GetState(time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected open on Tuesday")
+	}
+	if got, _ := oh.MarshalText(); string(got) != "Mo-Fr 09:00-17:00; PH off" {
+		t.Errorf("MarshalText() = %q after UnmarshalText", got)
+	}
+}
+
+func TestMarshalText_Prettified(t *testing.T) {
+	oh := &OpeningHours{}
+	if err := oh.UnmarshalText([]byte("Sa 10:00-12:00")); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	oh.source = ""
+	if got, _ := oh.MarshalText(); string(got) != "Sa 10:00-12:00" {
+		t.Errorf("MarshalText() = %q, want the prettified value", got)
+	}
+}
+
+func TestMarshalRulesJSON(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00; Sa 10:00-12:00 "by appointment"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	data, err := oh.MarshalRulesJSON()
+	if err != nil {
+		t.Fatalf("MarshalRulesJSON: %v", err)
+	}
+
+	var decoded struct {
+		Value string
+		Rules []struct {
+			Value   string
+			State   State
+			Comment string
+		}
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+	if len(decoded.Rules) != 2 || decoded.Rules[1].Comment != "by appointment" || decoded.Rules[1].State != StateOpen {
+		t.Errorf("rules = %+v", decoded.Rules)
+	}
+	if !strings.Contains(string(data), `"Value":"Sa 10:00-12:00 \"by appointment\""`) {
+		t.Errorf("MarshalRulesJSON() = %s, want the prettified rules", data)
+	}
+}
@@ -0,0 +1,60 @@
+package openinghours
+
+import "sync/atomic"
+
+// Counter identifies a counter reported to Metrics
+type Counter int
+
+const (
+	CounterParse             Counter = iota // a value was parsed (New, Parser.Parse, Parser.ParseInto)
+	CounterEvaluation                       // a state was evaluated (GetState, including internal calls)
+	CounterSlowPath                         // a search for the next or previous change found none within its whole search window
+	CounterScheduleCacheHit                 // a day schedule was served from the schedule cache
+	CounterScheduleCacheMiss                // a day schedule had to be computed
+)
+
+// String returns the name of the counter, e.g. "evaluations"
+func (c Counter) String() string {
+	switch c {
+	case CounterParse:
+		return "parses"
+	case CounterEvaluation:
+		return "evaluations"
+	case CounterSlowPath:
+		return "slow_paths"
+	case CounterScheduleCacheHit:
+		return "schedule_cache_hits"
+	case CounterScheduleCacheMiss:
+		return "schedule_cache_misses"
+	}
+	return "unknown"
+}
+
+// Metrics receives counter increments from all OpeningHours instances of the
+// process, e.g. to export them to a monitoring system; see the expvarmetrics
+// package for an implementation based on expvar. Inc is called on hot
+// paths and from multiple goroutines, so implementations must be cheap and
+// safe for concurrent use.
+type Metrics interface {
+	Inc(c Counter)
+}
+
+// metricsHolder wraps Metrics so that it can be stored in an atomic.Value
+type metricsHolder struct {
+	m Metrics
+}
+
+var metrics atomic.Value // metricsHolder
+
+// SetMetrics sets the process-level metrics receiver. nil disables metrics,
+// which is the default.
+func SetMetrics(m Metrics) {
+	metrics.Store(metricsHolder{m})
+}
+
+// incMetric reports an increment of c to the metrics receiver, if any
+func incMetric(c Counter) {
+	if h, ok := metrics.Load().(metricsHolder); ok && h.m != nil {
+		h.m.Inc(c)
+	}
+}
@@ -0,0 +1,63 @@
+package openinghours
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingMetrics counts increments per counter
+type countingMetrics struct {
+	mu     sync.Mutex
+	counts map[Counter]int
+}
+
+func (m *countingMetrics) Inc(c Counter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[c]++
+}
+
+func TestMetrics_Counters(t *testing.T) {
+	m := &countingMetrics{counts: make(map[Counter]int)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+	ResetScheduleCache()
+	defer ResetScheduleCache()
+
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if _, err := New("Mo 30:00-31:00"); err == nil {
+		t.Fatalf("expected parse error")
+	}
+
+	day := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC) // Monday
+	oh.GetState(day)
+	oh.GetDaySchedule(day)
+	oh.GetDaySchedule(day)
+
+	closed, err := New("off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	it := closed.GetIterator(day)
+	if next := it.NextChange(); !next.IsZero() {
+		t.Fatalf("expected no change, got %v", next)
+	}
+
+	if m.counts[CounterParse] != 3 {
+		t.Errorf("parses = %d, want 3", m.counts[CounterParse])
+	}
+	if m.counts[CounterEvaluation] == 0 {
+		t.Errorf("expected evaluations to be counted")
+	}
+	if m.counts[CounterScheduleCacheMiss] != 1 || m.counts[CounterScheduleCacheHit] != 1 {
+		t.Errorf("cache misses = %d, hits = %d, want 1 and 1",
+			m.counts[CounterScheduleCacheMiss], m.counts[CounterScheduleCacheHit])
+	}
+	if m.counts[CounterSlowPath] != 1 {
+		t.Errorf("slow paths = %d, want 1", m.counts[CounterSlowPath])
+	}
+}
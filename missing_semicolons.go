@@ -0,0 +1,111 @@
+package openinghours
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// splitMissingSemicolons splits rules that lack the semicolon between them,
+// like "Mo-Fr 08:00-18:00 Sa 09:00-12:00" -> ["Mo-Fr 08:00-18:00", "Sa 09:00-12:00"].
+// A rule is split where a weekday, month, holiday or week selector follows a
+// complete rule, that is after a time, a state or a comment. A warning is added
+// for each split, about the end of the rule and the selector, like "08:00-18:00 Sa".
+func (oh *OpeningHours) splitMissingSemicolons(parts []string) []string {
+	var result []string
+	for _, part := range parts {
+		split, missing := splitAtMissingSemicolons(part)
+		for _, m := range missing {
+			oh.addWarning(WarnMissingSemicolon, m.span, fmt.Sprintf("Rules should be separated by semicolons, a semicolon is probably missing before %q in %q", m.selector, m.span))
+		}
+		result = append(result, split...)
+	}
+	return result
+}
+
+// missingSemicolon is a place where splitAtMissingSemicolons split a rule
+type missingSemicolon struct {
+	span     string // the last token of the rule up to the selector, like "08:00-18:00 Sa"
+	selector string // the selector starting the next rule, like "Sa"
+}
+
+// splitAtMissingSemicolons splits s before each selector following a complete
+// rule, returning the rules and where the semicolons are missing
+func splitAtMissingSemicolons(s string) (parts []string, missing []missingSemicolon) {
+	tokens, offsets := ruleTokens(s)
+	start, first := 0, 0 // byte offset and first token of the current part
+	for i := 1; i < len(tokens); i++ {
+		if startsRule(tokens[i]) && endsRule(tokens[first:i]) {
+			parts = append(parts, strings.TrimSpace(s[start:offsets[i]]))
+			missing = append(missing, missingSemicolon{span: s[offsets[i-1] : offsets[i]+len(tokens[i])], selector: tokens[i]})
+			start, first = offsets[i], i
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:])), missing
+}
+
+// ruleTokens splits s at whitespace outside of comments, brackets and
+// parentheses, returning the tokens and their byte offsets in s
+func ruleTokens(s string) (tokens []string, offsets []int) {
+	depth, inQuote, start := 0, false, -1
+	for i, ch := range s {
+		switch {
+		case ch == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case ch == '[' || ch == '(':
+			depth++
+		case ch == ']' || ch == ')':
+			depth--
+		case unicode.IsSpace(ch) && depth <= 0:
+			if start >= 0 {
+				tokens, offsets = append(tokens, s[start:i]), append(offsets, start)
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens, offsets = append(tokens, s[start:]), append(offsets, start)
+	}
+	return tokens, offsets
+}
+
+// startsRule reports whether tok is a weekday, month, holiday or week selector
+// like "Sa", "Sa,Su", "Mo[1]", "Jan", "PH" or "week"
+func startsRule(tok string) bool {
+	name := strings.ToLower(tok)
+	if i := strings.IndexFunc(name, func(r rune) bool { return !unicode.IsLetter(r) }); i >= 0 {
+		name = name[:i]
+	}
+	if _, ok := weekdayNames[name]; ok {
+		return true
+	}
+	if _, ok := monthNames[name]; ok {
+		return true
+	}
+	return name == "ph" || name == "sh" || name == "week" || name == "easter"
+}
+
+// endsRule reports whether tokens are a complete rule: ending with a time, or
+// with a state or comment after a selector
+func endsRule(tokens []string) bool {
+	last := tokens[len(tokens)-1]
+	if strings.HasSuffix(last, ",") || strings.HasSuffix(last, "-") {
+		return false
+	}
+	if startsWithTime(last) {
+		return true
+	}
+	if len(tokens) < 2 {
+		return false
+	}
+	switch strings.ToLower(last) {
+	case "open", "closed", "off", "unknown":
+		return true
+	}
+	return strings.HasPrefix(last, `"`) && strings.HasSuffix(last, `"`) && len(last) > 1
+}
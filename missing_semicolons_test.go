@@ -0,0 +1,123 @@
+package openinghours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMissingSemicolons_Split(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Mo-Fr 08:00-18:00 Sa 09:00-12:00", "Mo-Fr 08:00-18:00; Sa 09:00-12:00"},
+		{"Mo-Fr 08:00-18:00 Sa 09:00-12:00 Su off", "Mo-Fr 08:00-18:00; Sa 09:00-12:00; Su off"},
+		{"Mo-Fr 08:00-18:00 PH off", "Mo-Fr 08:00-18:00; PH off"},
+		{"Mo 10:00-12:00 Jan off", "Mo 10:00-12:00; Jan off"},
+		{"Mo off Tu 10:00-12:00", "Mo off; Tu 10:00-12:00"},
+		{`Mo-Fr 08:00-18:00 "by appointment" Sa 10:00-12:00`, `Mo-Fr 08:00-18:00 "by appointment"; Sa 10:00-12:00`},
+		{"Mo-Fr sunrise-sunset Su[1] 10:00-11:00", "Mo-Fr sunrise-sunset; Su[1] 10:00-11:00"},
+		{"Mo-Fr 08:00-12:00, Sa 09:00-12:00 Su off", "Mo-Fr 08:00-12:00, Sa 09:00-12:00; Su off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			want, err := New(tt.want)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := oh.PrettifyValue(); got != want.PrettifyValue() {
+				t.Errorf("PrettifyValue() = %q, want %q", got, want.PrettifyValue())
+			}
+			if !hasWarning(oh, "separated by semicolons") {
+				t.Errorf("expected a missing semicolon warning, got %v", oh.GetWarnings())
+			}
+		})
+	}
+}
+
+func TestMissingSemicolons_WarningSpans(t *testing.T) {
+	oh, err := New(`Mo-Fr 08:00-18:00 "by appointment" Sa 09:00-12:00 Su off`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	want := []struct{ span, selector string }{
+		{`"by appointment" Sa`, `"Sa"`},
+		{"09:00-12:00 Su", `"Su"`},
+	}
+	warnings := oh.GetWarningDetails()
+	if len(warnings) != len(want) {
+		t.Fatalf("expected %d warnings, got %v", len(want), warnings)
+	}
+	for i, w := range warnings {
+		if w.Code != WarnMissingSemicolon || w.Span != want[i].span {
+			t.Errorf("warning %d = %+v, want span %q", i, w, want[i].span)
+		}
+		if !strings.Contains(w.Message, "before "+want[i].selector) {
+			t.Errorf("warning %d = %q, want it to name the selector %s", i, w.Message, want[i].selector)
+		}
+	}
+}
+
+func TestMissingSemicolons_Evaluate(t *testing.T) {
+	oh, err := New("Mo-Fr 08:00-18:00 Sa 09:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		time time.Time
+		want bool
+	}{
+		{time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), true},  // Monday
+		{time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC), true},  // Saturday
+		{time.Date(2024, 1, 20, 14, 0, 0, 0, time.UTC), false}, // Saturday afternoon
+		{time.Date(2024, 1, 21, 10, 0, 0, 0, time.UTC), false}, // Sunday
+	}
+	for _, tt := range tests {
+		if got := oh.GetState(tt.time); got != tt.want {
+			t.Errorf("GetState(%v) = %v, want %v", tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestMissingSemicolons_NotSplit(t *testing.T) {
+	values := []string{
+		"Mo-Fr 08:00-18:00; Sa 09:00-12:00",
+		"Mo,Sa 10:00-12:00",
+		"Mo-Fr 10:00-12:00, 14:00-18:00",
+		`Mo 10:00-12:00 "closed Sa 10:00-12:00"`,
+		"Jan Mo-Fr 10:00-12:00",
+	}
+	for _, value := range values {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("New(%q): unexpected parse error: %v", value, err)
+		}
+		if hasWarning(oh, "separated by semicolons") {
+			t.Errorf("New(%q): unexpected missing semicolon warning", value)
+		}
+	}
+}
+
+func TestMissingSemicolons_StrictMode(t *testing.T) {
+	if _, err := New("Mo-Fr 08:00-18:00 Sa 09:00-12:00", WithStrictMode()); err == nil {
+		t.Error("expected strict mode to reject missing semicolons")
+	}
+}
+
+// hasWarning reports whether oh has a warning containing substr
+func hasWarning(oh *OpeningHours, substr string) bool {
+	for _, w := range oh.GetWarningDetails() {
+		if strings.Contains(w.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package openinghours
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monthDayFromEndPattern matches a month day or month-day range at the start
+// of a rule where days may count from the end of the month, like "Jan -1",
+// "Dec -3 days-Dec 31", "Feb 20-Feb -1" or "Dec -3--1"
+var monthDayFromEndPattern = regexp.MustCompile(`(?i)^(\p{L}+)\s+(-\d{1,2}(?:\s*days?)?|\d{1,2})(?:-(?:(\p{L}+)\s+)?(-\d{1,2}(?:\s*days?)?|\d{1,2}))?(?:\s+|$)`)
+
+// everyMonthDayPattern matches a day in each month of a month range at the
+// start of a rule, like "Jan-Dec -1" (the last day of every month) or
+// "Jan-Dec 01"
+var everyMonthDayPattern = regexp.MustCompile(`(?i)^(\p{L}+)-(\p{L}+)\s+(-\d{1,2}(?:\s*days?)?|\d{1,2})(?:\s+|$)`)
+
+// parseMonthDayFromEnd parses month days counted from the end of the month
+// ("Jan -1", "Dec -3 days-Dec 31") and days in each month of a month range
+// ("Jan-Dec -1") at the start of s into r. Other month selectors are left to
+// parseMonthDate. Returns the remaining string.
+func parseMonthDayFromEnd(r *rule, s string) (string, error) {
+	if m := everyMonthDayPattern.FindStringSubmatch(s); m != nil {
+		monthStart, ok1 := monthNames[strings.ToLower(m[1])]
+		monthEnd, ok2 := monthNames[strings.ToLower(m[2])]
+		if !ok1 || !ok2 {
+			return s, nil
+		}
+		day, err := parseMonthDay(m[3])
+		if err != nil {
+			return s, err
+		}
+		r.monthStart, r.monthEnd = monthStart, monthEnd
+		r.dayStart, r.dayEnd = day, day
+		r.everyMonth = true
+		return strings.TrimSpace(s[len(m[0]):]), nil
+	}
+
+	m := monthDayFromEndPattern.FindStringSubmatch(s)
+	if m == nil || (!strings.HasPrefix(m[2], "-") && !strings.HasPrefix(m[4], "-")) {
+		return s, nil
+	}
+	monthStart, ok := monthNames[strings.ToLower(m[1])]
+	if !ok {
+		return s, nil
+	}
+	monthEnd := monthStart
+	if m[3] != "" {
+		if monthEnd, ok = monthNames[strings.ToLower(m[3])]; !ok {
+			return s, nil
+		}
+	}
+	dayStart, err := parseMonthDay(m[2])
+	if err != nil {
+		return s, err
+	}
+	dayEnd := dayStart
+	if m[4] != "" {
+		if dayEnd, err = parseMonthDay(m[4]); err != nil {
+			return s, err
+		}
+	}
+
+	r.monthStart, r.monthEnd = monthStart, monthEnd
+	r.dayStart, r.dayEnd = dayStart, dayEnd
+	return strings.TrimSpace(s[len(m[0]):]), nil
+}
+
+// parseMonthDay parses a day of the month like "05", or a day counted from the
+// end of the month like "-1" or "-3 days"
+func parseMonthDay(s string) (int, error) {
+	digits := strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(s), "s"), "day")
+	day, err := strconv.Atoi(strings.TrimSpace(digits))
+	if err != nil || day == 0 || day < -31 || day > 31 {
+		return 0, fmt.Errorf("invalid day of month: %s", s)
+	}
+	return day, nil
+}
+
+// resolveMonthDay returns the day of month of day in month of year: day itself,
+// or for days counted from the end the day from the month's length, e.g. 29 for
+// -1 in February 2024
+func resolveMonthDay(day, month, year int) int {
+	if day >= 0 {
+		return day
+	}
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day() + day + 1
+}
+
+// matchesMonthDay checks the month and day selectors of r on date
+func (r *rule) matchesMonthDay(date time.Time) bool {
+	month := int(date.Month())
+	day := date.Day()
+
+	inMonths := func(inclusive bool) bool {
+		switch {
+		case r.monthStart == r.monthEnd:
+			return month == r.monthStart
+		case r.monthStart < r.monthEnd && inclusive:
+			return month >= r.monthStart && month <= r.monthEnd
+		case r.monthStart < r.monthEnd:
+			return month > r.monthStart && month < r.monthEnd
+		case inclusive:
+			return month >= r.monthStart || month <= r.monthEnd
+		}
+		return month > r.monthStart || month < r.monthEnd
+	}
+
+	if r.dayStart == 0 {
+		return inMonths(true)
+	}
+
+	if r.everyMonth {
+		return inMonths(true) && day >= resolveMonthDay(r.dayStart, month, date.Year()) &&
+			day <= resolveMonthDay(r.dayEnd, month, date.Year())
+	}
+
+	dayStart := resolveMonthDay(r.dayStart, r.monthStart, date.Year())
+	dayEnd := resolveMonthDay(r.dayEnd, r.monthEnd, date.Year())
+	if r.monthStart == r.monthEnd {
+		if month != r.monthStart || day < dayStart || day > dayEnd {
+			return false
+		}
+		// Check day interval if specified (e.g., Jan 01-31/8 means every 8th day)
+		return r.dayInterval == 0 || (day-dayStart)%r.dayInterval == 0
+	}
+	return inMonths(false) || (month == r.monthStart && day >= dayStart) || (month == r.monthEnd && day <= dayEnd)
+}
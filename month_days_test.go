@@ -0,0 +1,86 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthDaysFromEnd(t *testing.T) {
+	tests := []struct {
+		value string
+		date  string
+		want  bool
+	}{
+		{"Feb -1 10:00-12:00", "2024-02-29", true},
+		{"Feb -1 10:00-12:00", "2024-02-28", false},
+		{"Feb -1 10:00-12:00", "2025-02-28", true},
+		{"Jan -1 10:00-12:00", "2024-01-31", true},
+		{"Jan -1 10:00-12:00", "2024-02-01", false},
+		{"Dec -3 days-Dec 31 10:00-12:00", "2024-12-28", false},
+		{"Dec -3 days-Dec 31 10:00-12:00", "2024-12-29", true},
+		{"Dec -3-31 10:00-12:00", "2024-12-31", true},
+		{"Dec -3--1 10:00-12:00", "2024-12-30", true},
+		{"Feb 20-Feb -1 10:00-12:00", "2024-02-29", true},
+		{"Feb 20-Feb -1 10:00-12:00", "2024-03-01", false},
+		{"Nov -1-Jan 02 10:00-12:00", "2024-11-30", true},
+		{"Nov -1-Jan 02 10:00-12:00", "2025-01-01", true},
+		{"Nov -1-Jan 02 10:00-12:00", "2024-11-29", false},
+		// The last day of every month
+		{"Jan-Dec -1 10:00-12:00", "2024-04-30", true},
+		{"Jan-Dec -1 10:00-12:00", "2024-02-29", true},
+		{"Jan-Dec -1 10:00-12:00", "2024-03-30", false},
+		{"Jun-Aug 01 10:00-12:00", "2024-07-01", true},
+		{"Jun-Aug 01 10:00-12:00", "2024-09-01", false},
+		{"Mo-Fr 10:00-12:00; Jan-Dec -1 off", "2024-05-31", false},
+		{"Mo-Fr 10:00-12:00; Jan-Dec -1 off", "2024-05-30", true},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := oh.GetState(date.Add(11 * time.Hour)); got != tt.want {
+			t.Errorf("%q on %s: got %v, want %v", tt.value, tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestMonthDaysFromEnd_Prettify(t *testing.T) {
+	tests := map[string]string{
+		"Feb -1 off":                     "Feb -1 off",
+		"dec -3 days-dec 31 10:00-12:00": "Dec -3-31 10:00-12:00",
+		"Dec -3--1 off":                  "Dec -3--1 off",
+		"Jan-Dec -1 10:00-12:00":         "Jan-Dec -1 10:00-12:00",
+		"Jun-Aug 01 off":                 "Jun-Aug 01 off",
+	}
+	for value, want := range tests {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if got := oh.PrettifyValue(); got != want {
+			t.Errorf("%q: PrettifyValue = %q, want %q", value, got, want)
+		}
+	}
+
+	oh, err := New("Jan-Dec -1 off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got := oh.Rules()[0].Months; got == nil || got.StartDay != -1 || !got.EveryMonth {
+		t.Errorf("Months = %+v, want the last day of every month", got)
+	}
+	if got := oh.GetHumanReadable(); got != "Closed on the last day of January to December" {
+		t.Errorf("GetHumanReadable = %q", got)
+	}
+}
+
+func TestMonthDaysFromEnd_Invalid(t *testing.T) {
+	for _, value := range []string{"Jan -32 off", "Jan-Dec -0 off"} {
+		if _, err := New(value); err == nil {
+			t.Errorf("%q: expected a parse error", value)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMonthDayList_State tests that comma-separated month days select each day
+func TestMonthDayList_State(t *testing.T) {
+	testCases := []struct {
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		{"Mo-Su 09:00-17:00; Jan 01,May 01,Dec 25-26 off", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 09:00-17:00; Jan 01,May 01,Dec 25-26 off", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 09:00-17:00; Jan 01,May 01,Dec 25-26 off", time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 09:00-17:00; Jan 01,May 01,Dec 25-26 off", time.Date(2024, 12, 26, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 09:00-17:00; Jan 01,May 01,Dec 25-26 off", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true},
+		{"Mo-Su 09:00-17:00; Jan 01,May 01,Dec 25-26 off", time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC), true},
+		// Items without a month continue the month of the previous item
+		{"Dec 24,31 10:00-14:00", time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), true},
+		{"Dec 24,31 10:00-14:00", time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC), false},
+		{"Dec 24-Jan 02,05 off; Mo-Su 09:00-17:00", time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC), true},
+		// Years apply to every item
+		{"2024 Dec 24,Dec 31 10:00-14:00", time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), true},
+		{"2024 Dec 24,Dec 31 10:00-14:00", time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC), false},
+		// Day ranges of later months are not abbreviated times
+		{"Jan 05-15,Feb 10-20 10:00-12:00", time.Date(2024, 2, 12, 11, 0, 0, 0, time.UTC), true},
+		{"Jan 05-15,Feb 10-20 10:00-12:00", time.Date(2024, 2, 21, 11, 0, 0, 0, time.UTC), false},
+		{"Jan 05-15,Feb 10-20 10:00-12:00", time.Date(2024, 1, 16, 11, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 10:00-12:00; 2024 Jan 05-15,Feb 10-20 off", time.Date(2024, 2, 20, 11, 0, 0, 0, time.UTC), false},
+		// Month lists mixing ranges, after a year and before weekdays
+		{"Jan-Feb,Aug Mo-Fr 10:00-12:00", time.Date(2024, 2, 12, 11, 0, 0, 0, time.UTC), true},
+		{"Jan-Feb,Aug Mo-Fr 10:00-12:00", time.Date(2024, 7, 1, 11, 0, 0, 0, time.UTC), false},
+		{"2024 Jan,Jul Mo 10:00-12:00", time.Date(2024, 7, 1, 11, 0, 0, 0, time.UTC), true},
+		{"2024 Jan,Jul Mo 10:00-12:00", time.Date(2025, 7, 7, 11, 0, 0, 0, time.UTC), false},
+		{"Mo-Fr 08:00-18:00; Jan,Aug Sa 10:00-12:00", time.Date(2024, 8, 3, 11, 0, 0, 0, time.UTC), true},
+		{"Mo-Fr 08:00-18:00; Jan,Aug Sa 10:00-12:00", time.Date(2024, 7, 6, 11, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		if got := oh.GetState(tc.time); got != tc.expected {
+			t.Errorf("%q at %v: GetState = %v, want %v", tc.value, tc.time, got, tc.expected)
+		}
+	}
+}
+
+// TestMonthList_Prettify tests that expanded month lists are written as lists again
+func TestMonthList_Prettify(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Jan 01,May 01,Dec 25-26 off", "Jan 01,May 01,Dec 25-26 off"},
+		{"jan 1, may 1 off", "Jan 01,May 01 off"},
+		{"Dec 24,31 10:00-14:00", "Dec 24,Dec 31 10:00-14:00"},
+		{"Mo-Fr 09:00-17:00; Dec 24,Dec 31 10:00-14:00", "Mo-Fr 09:00-17:00; Dec 24,Dec 31 10:00-14:00"},
+		{"Jun-Aug,Dec Mo 10:00-12:00", "Jun-Aug,Dec Mo 10:00-12:00"},
+		// Separate rules stay separate
+		{"Jan 01 off; May 01 off", "Jan 01 off; May 01 off"},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		prettified := oh.PrettifyValue()
+		if prettified != tc.expected {
+			t.Errorf("%q: PrettifyValue = %q, want %q", tc.value, prettified, tc.expected)
+		}
+		again, err := New(prettified)
+		if err != nil {
+			t.Fatalf("%q: prettified value does not parse: %v", prettified, err)
+		}
+		if !again.IsEqualTo(oh) {
+			t.Errorf("%q: prettified value %q is not equal", tc.value, prettified)
+		}
+	}
+
+	// Each item is still a rule of its own
+	oh, err := New("Jan 01,May 01,Dec 25-26 off")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if rules := oh.Rules(); len(rules) != 3 || rules[2].Value != "Dec 25-26 off" {
+		t.Errorf("Rules = %v, want 3 rules ending with Dec 25-26 off", rules)
+	}
+}
@@ -0,0 +1,63 @@
+package openinghours
+
+import "time"
+
+// NextOpen returns the first time at or after t at which oh is open: t itself
+// if it is open at t. ok is false if it doesn't open within a year, like
+// GetNextChange.
+func (oh *OpeningHours) NextOpen(t time.Time) (next time.Time, ok bool) {
+	return oh.nextState(t, func(s State) bool { return s == StateOpen })
+}
+
+// NextClose returns the first time at or after t at which oh is not open, i.e.
+// closed or unknown: t itself if it is not open at t. ok is false if it stays
+// open for a year, e.g. for "24/7".
+func (oh *OpeningHours) NextClose(t time.Time) (next time.Time, ok bool) {
+	return oh.nextState(t, func(s State) bool { return s != StateOpen })
+}
+
+// OpenFor returns how long oh stays open from t, e.g. to show "closes in 45
+// minutes". ok is false if it is not open at t or stays open for a year.
+func (oh *OpeningHours) OpenFor(t time.Time) (remaining time.Duration, ok bool) {
+	if oh.stateAt(t) != StateOpen {
+		return 0, false
+	}
+	next, ok := oh.NextClose(t)
+	if !ok {
+		return 0, false
+	}
+	return next.Sub(t), true
+}
+
+// ClosedFor returns how long oh stays closed or unknown from t, e.g. to show
+// "opens in 2 hours". ok is false if it is open at t or doesn't open within a
+// year.
+func (oh *OpeningHours) ClosedFor(t time.Time) (remaining time.Duration, ok bool) {
+	if oh.stateAt(t) == StateOpen {
+		return 0, false
+	}
+	next, ok := oh.NextOpen(t)
+	if !ok {
+		return 0, false
+	}
+	return next.Sub(t), true
+}
+
+// nextState returns the first time at or after t whose state matches, from
+// the state changes up to iteratorSearchLimit after t
+func (oh *OpeningHours) nextState(t time.Time, match func(State) bool) (time.Time, bool) {
+	t = oh.inLocation(t)
+	if match(oh.stateAt(t)) {
+		return t, true
+	}
+	if oh.isConstant() {
+		return time.Time{}, false
+	}
+	limit := t.Add(iteratorSearchLimit)
+	for c := oh.nextStateChange(t, limit); !c.IsZero(); c = oh.nextStateChange(c, limit) {
+		if match(oh.stateAt(c)) {
+			return c, true
+		}
+	}
+	return time.Time{}, false
+}
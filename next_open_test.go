@@ -0,0 +1,76 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextOpenAndClose(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value     string
+		at        time.Time
+		nextOpen  time.Time // zero if none
+		nextClose time.Time // zero if none
+	}{
+		{"Mo-Fr 09:00-17:00", monday.Add(8 * time.Hour), monday.Add(9 * time.Hour), monday.Add(8 * time.Hour)},
+		{"Mo-Fr 09:00-17:00", monday.Add(10 * time.Hour), monday.Add(10 * time.Hour), monday.Add(17 * time.Hour)},
+		// Friday evening until Monday
+		{"Mo-Fr 09:00-17:00", monday.AddDate(0, 0, 4).Add(18 * time.Hour), monday.AddDate(0, 0, 7).Add(9 * time.Hour), monday.AddDate(0, 0, 4).Add(18 * time.Hour)},
+		// Date rules
+		{"Dec 24 10:00-14:00", monday, time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC), monday},
+		{"2024 Jan 10-Jan 12", monday, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), monday},
+		{"2024 Jan 10-Jan 12", time.Date(2024, 1, 11, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)},
+		// Unknown isn't open
+		{"Mo-Fr 09:00-17:00 unknown; Sa 10:00-12:00", monday.Add(10 * time.Hour), monday.AddDate(0, 0, 5).Add(10 * time.Hour), monday.Add(10 * time.Hour)},
+		{"Mo-Fr 09:00-12:00, Mo-Fr 12:00-14:00 unknown", monday.Add(10 * time.Hour), monday.Add(10 * time.Hour), monday.Add(12 * time.Hour)},
+		{"24/7", monday, monday, time.Time{}},
+		{"off", monday, time.Time{}, monday},
+		{"2023 Mo-Fr 09:00-17:00", monday, time.Time{}, monday},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		next, ok := oh.NextOpen(tt.at)
+		if ok != !tt.nextOpen.IsZero() || !next.Equal(tt.nextOpen) {
+			t.Errorf("%q at %s: NextOpen = %v, %v, want %v", tt.value, tt.at, next, ok, tt.nextOpen)
+		}
+		next, ok = oh.NextClose(tt.at)
+		if ok != !tt.nextClose.IsZero() || !next.Equal(tt.nextClose) {
+			t.Errorf("%q at %s: NextClose = %v, %v, want %v", tt.value, tt.at, next, ok, tt.nextClose)
+		}
+	}
+}
+
+func TestOpenForAndClosedFor(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	at := time.Date(2024, 1, 1, 16, 15, 0, 0, time.UTC)
+	if got, ok := oh.OpenFor(at); !ok || got != 45*time.Minute {
+		t.Errorf("OpenFor = %v, %v, want 45m", got, ok)
+	}
+	if _, ok := oh.ClosedFor(at); ok {
+		t.Errorf("ClosedFor while open: expected false")
+	}
+
+	at = time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	if got, ok := oh.ClosedFor(at); !ok || got != 2*time.Hour {
+		t.Errorf("ClosedFor = %v, %v, want 2h", got, ok)
+	}
+	if _, ok := oh.OpenFor(at); ok {
+		t.Errorf("OpenFor while closed: expected false")
+	}
+
+	always, err := New("24/7")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if _, ok := always.OpenFor(at); ok {
+		t.Errorf("OpenFor of 24/7: expected false, it never closes")
+	}
+}
@@ -0,0 +1,142 @@
+package openinghours
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ChangeKind tells which normalization step made a Change
+type ChangeKind int
+
+const (
+	ChangeCustom           ChangeKind = iota // a normalizer added by WithNormalizers
+	ChangeFullWidth                          // NormalizeFullWidth: "１０：００" -> "10:00"
+	ChangeDashes                             // NormalizeDashes: "Mo–Fr" -> "Mo-Fr"
+	ChangeRangeWords                         // NormalizeRangeWords: "Mo to Fr" -> "Mo-Fr"
+	ChangeWeekdayTimeSpace                   // NormalizeWeekdayTimeSpace: "Mo09:00" -> "Mo 09:00"
+	ChangeDotTimes                           // NormalizeDotTimes: "10.00" -> "10:00"
+	ChangeRangeSpaces                        // NormalizeRangeSpaces: "Mo - Fr" -> "Mo-Fr"
+	ChangeShortTimes                         // NormalizeShortTimes: "10-12" -> "10:00-12:00"
+	ChangeAMPM                               // NormalizeAMPM: "5pm" -> "17:00"
+)
+
+// Change is a rewrite of the value made by a normalizer before parsing. Changes
+// cover whole words, e.g. "10.00-12.00" -> "10:00-12:00" rather than "." -> ":".
+type Change struct {
+	Original    string
+	Replacement string
+	Position    int // byte offset of Original in the value after the preceding changes
+	Kind        ChangeKind
+}
+
+// GetNormalizationChanges returns the changes error tolerance made to the value
+// before parsing, in the order they were made, or nil if it was parsed as is.
+// Applying them in order to the value without surrounding whitespace yields the
+// normalized value, so they can be used to fix the source data.
+func (oh *OpeningHours) GetNormalizationChanges() []Change {
+	return slices.Clone(oh.normalizationChanges)
+}
+
+// diffOp is an edit of a rune when turning one string into another
+type diffOp struct {
+	r        rune
+	inBefore bool // the rune is kept or deleted
+	inAfter  bool // the rune is kept or inserted
+	changed  bool // the rune is deleted or inserted, or part of a changed word
+}
+
+// diffChanges returns the changes turning before into after, each extended to
+// the whole words it touches
+func diffChanges(before, after string, kind ChangeKind) []Change {
+	ops := diffRunes([]rune(before), []rune(after))
+
+	// Extend changes over kept runes up to the next whitespace in both directions
+	for i := 1; i < len(ops); i++ {
+		if !ops[i].changed && ops[i-1].changed && !unicode.IsSpace(ops[i].r) {
+			ops[i].changed = true
+		}
+	}
+	for i := len(ops) - 2; i >= 0; i-- {
+		if !ops[i].changed && ops[i+1].changed && !unicode.IsSpace(ops[i].r) {
+			ops[i].changed = true
+		}
+	}
+
+	var changes []Change
+	var current *Change
+	position := 0
+	for _, op := range ops {
+		if !op.changed {
+			current = nil
+		} else {
+			if current == nil {
+				changes = append(changes, Change{Position: position, Kind: kind})
+				current = &changes[len(changes)-1]
+			}
+			if op.inBefore {
+				current.Original += string(op.r)
+			}
+			if op.inAfter {
+				current.Replacement += string(op.r)
+			}
+		}
+		if op.inAfter {
+			position += utf8.RuneLen(op.r)
+		}
+	}
+	return changes
+}
+
+// diffRunes returns a shortest edit script turning a into b, based on their
+// longest common subsequence
+func diffRunes(a, b []rune) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b)-prefix-suffix)
+	for _, r := range a[:prefix] {
+		ops = append(ops, diffOp{r: r, inBefore: true, inAfter: true})
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, diffOp{r: midA[i], inBefore: true, inAfter: true})
+			i++
+			j++
+		case j == len(midB) || (i < len(midA) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{r: midA[i], inBefore: true, changed: true})
+			i++
+		default:
+			ops = append(ops, diffOp{r: midB[j], inAfter: true, changed: true})
+			j++
+		}
+	}
+	for _, r := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{r: r, inBefore: true, inAfter: true})
+	}
+	return ops
+}
@@ -0,0 +1,291 @@
+package openinghours
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Normalizer rewrites an opening hours string before it is parsed.
+// Normalizers are applied in order; each receives the output of the previous one.
+type Normalizer func(string) string
+
+// builtinNormalizers are the built-in normalization steps in the order they are
+// applied, with the kind of the changes they make
+var builtinNormalizers = []struct {
+	kind       ChangeKind
+	normalizer Normalizer
+}{
+	{ChangeFullWidth, NormalizeFullWidth},
+	{ChangeDashes, NormalizeDashes},
+	{ChangeRangeWords, NormalizeRangeWords},
+	{ChangeWeekdayTimeSpace, NormalizeWeekdayTimeSpace},
+	{ChangeDotTimes, NormalizeDotTimes},
+	{ChangeRangeSpaces, NormalizeRangeSpaces},
+	{ChangeShortTimes, NormalizeShortTimes},
+	{ChangeAMPM, NormalizeAMPM},
+}
+
+// DefaultNormalizers returns the built-in normalization steps in the order they are applied
+func DefaultNormalizers() []Normalizer {
+	normalizers := make([]Normalizer, len(builtinNormalizers))
+	for i, b := range builtinNormalizers {
+		normalizers[i] = b.normalizer
+	}
+	return normalizers
+}
+
+// WithNormalizers adds custom normalizers, e.g. for company-specific abbreviations.
+// They run in order on the whole value before the built-in DefaultNormalizers.
+func WithNormalizers(normalizers ...Normalizer) Option {
+	return func(oh *OpeningHours) {
+		oh.normalizers = append(oh.normalizers, normalizers...)
+	}
+}
+
+// normalize applies the custom normalizers followed by the built-in ones and
+// records their changes, see GetNormalizationChanges
+func (oh *OpeningHours) normalize(s string) string {
+	apply := func(kind ChangeKind, n Normalizer) {
+		normalized := n(s)
+		if normalized != s {
+			oh.normalizationChanges = append(oh.normalizationChanges, diffChanges(s, normalized, kind)...)
+		}
+		s = normalized
+	}
+	for _, n := range oh.normalizers {
+		apply(ChangeCustom, n)
+	}
+	for _, b := range builtinNormalizers {
+		apply(b.kind, b.normalizer)
+	}
+	return s
+}
+
+// normalizeTimeString converts various time formats to standard HH:MM-HH:MM format
+func normalizeTimeString(s string) string {
+	for _, b := range builtinNormalizers {
+		s = b.normalizer(s)
+	}
+	return s
+}
+
+var toPattern = regexp.MustCompile(`(?i)\s+to\s+`)
+var throughPattern = regexp.MustCompile(`(?i)\s+through\s+`)
+
+// localRangeWordPattern matches "to" in German, French, Spanish, Italian and Dutch
+// with the words around it, e.g. "Lun a Vie" or "9:00 bis 17:00"
+var localRangeWordPattern = regexp.MustCompile(`(?i)([\p{L}\d:]+)\s+(?:bis|à|au|al|a|hasta|tot|t/m)\s+([\p{L}\d:]+)`)
+var shortTimeWordPattern = regexp.MustCompile(`^(\d{1,2})-(\d{1,2})$`)
+
+// spacedRangePattern matches a range hyphen between weekdays, months, days, times
+// or variable times, with or without surrounding whitespace
+var spacedRangePattern = regexp.MustCompile(`(?i)\b(mo|tu|we|th|fr|sa|su|jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec|\d{1,2}:\d{2}|\d{1,4}|sunrise|sunset|dawn|dusk)(\s*-\s*)(mo|tu|we|th|fr|sa|su|jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec|\d|sunrise|sunset|dawn|dusk|\()`)
+
+// NormalizeFullWidth converts full-width digits (０-９), colons (：) and hyphens (－),
+// common in Japanese-sourced data, to ASCII: "１０：００－１９：００" -> "10:00-19:00"
+func NormalizeFullWidth(s string) string {
+	if !hasFullWidth(s) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９':
+			return '0' + (r - '０')
+		case r == '：':
+			return ':'
+		case r == '－':
+			return '-'
+		}
+		return r
+	}, s)
+}
+
+// hasFullWidth reports whether s contains characters converted by NormalizeFullWidth
+func hasFullWidth(s string) bool {
+	return strings.ContainsFunc(s, isFullWidth)
+}
+
+// isFullWidth reports whether r is converted by NormalizeFullWidth
+func isFullWidth(r rune) bool {
+	return (r >= '０' && r <= '９') || r == '：' || r == '－'
+}
+
+// fullWidthMatch returns the first run of characters in s converted by
+// NormalizeFullWidth, like "１０：００－１９：００", or ""
+func fullWidthMatch(s string) string {
+	start := strings.IndexFunc(s, isFullWidth)
+	if start < 0 {
+		return ""
+	}
+	end := strings.IndexFunc(s[start:], func(r rune) bool { return !isFullWidth(r) })
+	if end < 0 {
+		return s[start:]
+	}
+	return s[start : start+end]
+}
+
+// NormalizeDashes converts en dash, em dash and minus sign to a hyphen
+func NormalizeDashes(s string) string {
+	// En dash (U+2013), Em dash (U+2014), minus sign (U+2212) -> hyphen-minus (U+002D)
+	s = strings.ReplaceAll(s, "–", "-") // En dash
+	s = strings.ReplaceAll(s, "—", "-") // Em dash
+	s = strings.ReplaceAll(s, "−", "-") // Minus sign
+	return s
+}
+
+// NormalizeRangeWords converts "to" and "through" range separators to a hyphen,
+// as well as their German, French, Spanish, Italian and Dutch equivalents
+// ("bis", "à", "hasta", "a", "tot") between weekdays, months, days and times
+func NormalizeRangeWords(s string) string {
+	// "to" and "through" can be used instead of "-" in time and weekday ranges
+	// Require surrounding whitespace to avoid replacing inside words
+	s = toPattern.ReplaceAllString(s, "-")
+	s = throughPattern.ReplaceAllString(s, "-")
+	// Localized words like "a" are common in comments, so both sides must be range endpoints
+	return localRangeWordPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := localRangeWordPattern.FindStringSubmatch(match)
+		if !isRangeEndpoint(m[1]) || !isRangeEndpoint(m[2]) {
+			return match
+		}
+		return m[1] + "-" + m[2]
+	})
+}
+
+// isRangeEndpoint reports whether word can start or end a range: a weekday, a
+// month, a day number or a time
+func isRangeEndpoint(word string) bool {
+	lower := strings.ToLower(word)
+	_, isWeekday := weekdayNames[lower]
+	_, isMonth := monthNames[lower]
+	return isWeekday || isMonth || (lower[0] >= '0' && lower[0] <= '9')
+}
+
+// NormalizeRangeSpaces removes whitespace around range hyphens of weekdays, months,
+// days and times: "Mo - Fr 09:00 - 17:00" -> "Mo-Fr 09:00-17:00"
+func NormalizeRangeSpaces(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range spacedRanges(s) {
+		b.WriteString(s[last:m[4]])
+		b.WriteString("-")
+		last = m[5]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// dayOffsetPattern matches the number and unit of a day offset like "-1 day"
+var dayOffsetPattern = regexp.MustCompile(`(?i)^\d+\s*days?\b`)
+
+// spacedRanges returns the submatch indexes of spacedRangePattern in s, without
+// day offsets like "Dec 25 -1 day" and days from the end of the month like
+// "Feb -1" whose sign is not a range hyphen
+func spacedRanges(s string) [][]int {
+	var ranges [][]int
+	for _, m := range spacedRangePattern.FindAllStringSubmatchIndex(s, -1) {
+		if m[5]-m[4] > 1 && dayOffsetPattern.MatchString(s[m[6]:]) {
+			continue
+		}
+		if _, isMonth := monthNames[strings.ToLower(s[m[2]:m[3]])]; isMonth && s[m[4]] != '-' && s[m[5]-1] == '-' && s[m[6]] >= '0' && s[m[6]] <= '9' {
+			continue
+		}
+		ranges = append(ranges, m)
+	}
+	return ranges
+}
+
+// spacedRangeMatch returns the first range in s whose hyphen is changed by
+// NormalizeRangeSpaces, like "Mo - Fr", or ""
+func spacedRangeMatch(s string) string {
+	for _, m := range spacedRanges(s) {
+		if s[m[4]:m[5]] != "-" {
+			end := len(s)
+			if i := strings.IndexAny(s[m[6]:], " \t;,"); i >= 0 {
+				end = m[6] + i
+			}
+			return s[m[0]:end]
+		}
+	}
+	return ""
+}
+
+// weekdayTimePattern matches a weekday or holiday directly followed by a time
+// or an hour range, without the separating space
+var weekdayTimePattern = regexp.MustCompile(`(?i)\b(mo|tu|we|th|fr|sa|su|ph|sh)(\d{1,2}(?:[:.]\d{2}|\s*-\s*\d))`)
+
+// NormalizeWeekdayTimeSpace inserts a missing space between weekdays and times,
+// common in imported data: "Mo-Fr09:00-17:00" -> "Mo-Fr 09:00-17:00"
+func NormalizeWeekdayTimeSpace(s string) string {
+	return weekdayTimePattern.ReplaceAllString(s, "$1 $2")
+}
+
+// NormalizeDotTimes converts dots to colons in times: 10.00 -> 10:00
+func NormalizeDotTimes(s string) string {
+	return dotTimePattern.ReplaceAllString(s, "$1:$2")
+}
+
+// NormalizeShortTimes converts short hour ranges to the standard format: 10-12 -> 10:00-12:00
+func NormalizeShortTimes(s string) string {
+	// Must not match things like "week 1-10", "Mo-Fr", or "Jan 01-15" (day ranges)
+	// Split by spaces and check each word to avoid converting week numbers or day ranges
+	words := strings.Fields(s)
+	for i, word := range words {
+		// Only convert if the previous word isn't "week" (case insensitive)
+		prevIsWeek := i > 0 && strings.ToLower(words[i-1]) == "week"
+		// Also don't convert if previous word is a month name (it's a day range like "Jan 01-15"),
+		// also at the end of a list like "Jan 05-15,Feb 10-20"
+		prevIsMonth := i > 0 && endsWithMonth(words[i-1])
+		if !prevIsWeek && !prevIsMonth {
+			if match := shortTimeWordPattern.FindStringSubmatch(word); match != nil {
+				start, err1 := strconv.Atoi(match[1])
+				end, err2 := strconv.Atoi(match[2])
+				if err1 == nil && err2 == nil && start >= 0 && start <= 24 && end >= 0 && end <= 24 {
+					words[i] = fmt.Sprintf("%d:00-%d:00", start, end)
+				}
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// endsWithMonth reports whether word is a month name or a list ending with
+// one, like "Jan" or "15,Feb"
+func endsWithMonth(word string) bool {
+	_, isMonth := monthNames[strings.ToLower(word[strings.LastIndex(word, ",")+1:])]
+	return isMonth
+}
+
+// NormalizeAMPM converts 12-hour times with am/pm to 24-hour format: 5pm -> 17:00
+func NormalizeAMPM(s string) string {
+	return ampmPattern.ReplaceAllStringFunc(s, func(match string) string {
+		// Parse the match
+		parts := ampmPattern.FindStringSubmatch(match)
+		if parts == nil {
+			return match
+		}
+
+		hour, _ := strconv.Atoi(parts[1])
+		minute := 0
+		if parts[2] != "" {
+			minute, _ = strconv.Atoi(parts[2])
+		}
+		ampm := strings.ToLower(parts[3])
+
+		// Normalize ampm (remove dots and spaces)
+		ampm = strings.ReplaceAll(ampm, ".", "")
+		ampm = strings.TrimSpace(ampm)
+
+		// Convert to 24-hour format
+		if strings.HasPrefix(ampm, "p") && hour != 12 {
+			// PM: add 12 hours (except for 12pm which stays 12)
+			hour += 12
+		} else if strings.HasPrefix(ampm, "a") && hour == 12 {
+			// 12am is midnight (00:00)
+			hour = 0
+		}
+
+		return fmt.Sprintf("%d:%02d", hour, minute)
+	})
+}
@@ -0,0 +1,238 @@
+package openinghours
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalize_DefaultSteps(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer Normalizer
+		input      string
+		expected   string
+	}{
+		{"full width", NormalizeFullWidth, "１０：００－１９：００", "10:00-19:00"},
+		{"dashes", NormalizeDashes, "Mo–Fr 10:00—12:00", "Mo-Fr 10:00-12:00"},
+		{"range words", NormalizeRangeWords, "Mo to Fr 10:00 through 12:00", "Mo-Fr 10:00-12:00"},
+		{"localized range words", NormalizeRangeWords, "Mo bis Fr 09:00 à 12:00, lunes a viernes 16:00 hasta 19:00", "Mo-Fr 09:00-12:00, lunes-viernes 16:00-19:00"},
+		{"localized range words keep comments", NormalizeRangeWords, `Mo 10:00-12:00 "ouvert a midi"`, `Mo 10:00-12:00 "ouvert a midi"`},
+		{"weekday time space", NormalizeWeekdayTimeSpace, "Mo-Fr09:00-17:00; Sa10-14; PH10.00-12.00", "Mo-Fr 09:00-17:00; Sa 10-14; PH 10.00-12.00"},
+		{"weekday time space keep dates", NormalizeWeekdayTimeSpace, "Dec 24 10:00-12:00", "Dec 24 10:00-12:00"},
+		{"dot times", NormalizeDotTimes, "10.00-12.30", "10:00-12:30"},
+		{"range spaces", NormalizeRangeSpaces, "Mo - Fr 09:00 - 17:00", "Mo-Fr 09:00-17:00"},
+		{"range spaces months", NormalizeRangeSpaces, "Jan 05 - Feb 10 10:00 -12:00", "Jan 05-Feb 10 10:00-12:00"},
+		{"range spaces keep offsets", NormalizeRangeSpaces, "PH -1 day 10:00-12:00", "PH -1 day 10:00-12:00"},
+		{"range spaces keep date offsets", NormalizeRangeSpaces, "Dec 25 -1 day 10 - 12", "Dec 25 -1 day 10-12"},
+		{"short times", NormalizeShortTimes, "Mo 10-12", "Mo 10:00-12:00"},
+		{"short times keep weeks", NormalizeShortTimes, "week 1-10 Mo 10:00-12:00", "week 1-10 Mo 10:00-12:00"},
+		{"am/pm", NormalizeAMPM, "9am-5pm", "9:00-17:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.normalizer(tt.input); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_CustomChain(t *testing.T) {
+	expandBusinessHours := func(s string) string {
+		return strings.ReplaceAll(s, "business hours", "Mo-Fr 9am-5pm")
+	}
+
+	oh, err := New("business hours; Sa 10-14", WithNormalizers(expandBusinessHours))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 15, 2024 is Monday
+	if !oh.GetState(time.Date(2024, 1, 15, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Mo 16:00: expected open")
+	}
+	if oh.GetState(time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Mo 17:00: expected closed")
+	}
+	if !oh.GetState(time.Date(2024, 1, 20, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("Sa 13:00: expected open")
+	}
+}
+
+func TestNormalize_CustomRunsBeforeDefaults(t *testing.T) {
+	// The custom step produces "to" and short times, which the built-in steps then normalize
+	translate := func(s string) string {
+		return strings.ReplaceAll(s, " bis ", " to ")
+	}
+
+	oh, err := New("Mo 10 bis 12", WithNormalizers(translate))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if !oh.GetState(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Mo 11:00: expected open")
+	}
+}
+
+func TestNormalize_FullWidthValue(t *testing.T) {
+	oh, err := New("Mo-Fr １０：００-１９：００")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 15, 2024 is Monday
+	if !oh.GetState(time.Date(2024, 1, 15, 18, 59, 0, 0, time.UTC)) {
+		t.Errorf("Mo 18:59: expected open")
+	}
+	if oh.GetState(time.Date(2024, 1, 15, 19, 0, 0, 0, time.UTC)) {
+		t.Errorf("Mo 19:00: expected closed")
+	}
+
+	warnings := oh.GetWarnings()
+	if len(warnings) != 1 || !containsAny(warnings[0], []string{"full-width"}) {
+		t.Errorf("expected one warning about full-width characters, got %v", warnings)
+	}
+}
+
+func TestNormalize_SpacedRanges(t *testing.T) {
+	oh, err := New("Mo - Fr 09:00 - 17:00; Sa 10 - 12")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 19, 2024 is Friday
+	if !oh.GetState(time.Date(2024, 1, 19, 16, 59, 0, 0, time.UTC)) {
+		t.Errorf("Fr 16:59: expected open")
+	}
+	if oh.GetState(time.Date(2024, 1, 19, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Fr 17:00: expected closed")
+	}
+	if !oh.GetState(time.Date(2024, 1, 20, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Sa 11:00: expected open")
+	}
+
+	warnings := oh.GetWarnings()
+	if len(warnings) != 1 || !containsAny(warnings[0], []string{"range hyphens"}) {
+		t.Errorf("expected one warning about spaces around range hyphens, got %v", warnings)
+	}
+
+	oh, err = New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if warnings := oh.GetWarnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestNormalize_MissingWeekdayTimeSpace(t *testing.T) {
+	oh, err := New("Mo-Fr09:00-17:00; sa10-14")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got := oh.PrettifyValue(); got != "Mo-Fr 09:00-17:00; Sa 10:00-14:00" {
+		t.Errorf("PrettifyValue = %q", got)
+	}
+
+	if !hasWarning(oh, "space was inserted") {
+		t.Errorf("expected a warning about the missing space, got %v", oh.GetWarnings())
+	}
+}
+
+func TestNormalize_LocalizedNames(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Lundi-Vendredi 09:00-18:00; samedi 10:00-12:00", "Mo-Fr 09:00-18:00; Sa 10:00-12:00"},
+		{"Lun à Ven 9:00 à 18:00; dim off", "Mo-Fr 09:00-18:00; Su off"},
+		{"Lunes a Viernes 09:00 a 14:00; Sáb 10:00-13:00", "Mo-Fr 09:00-14:00; Sa 10:00-13:00"},
+		{"miércoles 10:00-12:00; diciembre 24 off", "We 10:00-12:00; Dec 24 off"},
+		{"lunedì-venerdì 09:00-13:00; Agosto off", "Mo-Fr 09:00-13:00; Aug off"},
+		{"Maandag t/m Vrijdag 09:00 tot 17:00; za 10:00-16:00", "Mo-Fr 09:00-17:00; Sa 10:00-16:00"},
+		{"ma-vr 09:00-17:00; mei 01 off", "Mo-Fr 09:00-17:00; May 01 off"},
+		{"juillet-août Mo-Fr 10:00-12:00", "Jul-Aug Mo-Fr 10:00-12:00"},
+		{"Mo bis Fr 09:00 bis 17:00", "Mo-Fr 09:00-17:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			oh, err := New(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := oh.PrettifyValue(); got != tt.expected {
+				t.Errorf("PrettifyValue = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_Changes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Change
+	}{
+		{"Mo-Fr 09:00-17:00", nil},
+		{"Mo - Fr 10.00-12.00, Sa 10.00 to 14.00", []Change{
+			{Original: "10.00 to 14.00", Replacement: "10.00-14.00", Position: 24, Kind: ChangeRangeWords},
+			{Original: "10.00-12.00,", Replacement: "10:00-12:00,", Position: 8, Kind: ChangeDotTimes},
+			{Original: "10.00-14.00", Replacement: "10:00-14:00", Position: 24, Kind: ChangeDotTimes},
+			{Original: "Mo - Fr", Replacement: "Mo-Fr", Position: 0, Kind: ChangeRangeSpaces},
+		}},
+		{"Mo–Fr09:00-17:00", []Change{
+			{Original: "Mo–Fr09:00-17:00", Replacement: "Mo-Fr09:00-17:00", Position: 0, Kind: ChangeDashes},
+			{Original: "Mo-Fr09:00-17:00", Replacement: "Mo-Fr 09:00-17:00", Position: 0, Kind: ChangeWeekdayTimeSpace},
+		}},
+		{"Sa 9-17", []Change{
+			{Original: "9-17", Replacement: "9:00-17:00", Position: 3, Kind: ChangeShortTimes},
+		}},
+		{"Sa 10:00-12:00; Su 9am-5:30pm", []Change{
+			{Original: "9am-5:30pm", Replacement: "9:00-17:30", Position: 19, Kind: ChangeAMPM},
+		}},
+		{"１０：００－１９：００", []Change{
+			{Original: "１０：００－１９：００", Replacement: "10:00-19:00", Position: 0, Kind: ChangeFullWidth},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			oh, err := New(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			changes := oh.GetNormalizationChanges()
+			if !reflect.DeepEqual(changes, tt.expected) {
+				t.Fatalf("GetNormalizationChanges = %+v, want %+v", changes, tt.expected)
+			}
+
+			// Applying the changes in order yields the normalized value
+			value := tt.input
+			for _, c := range changes {
+				if value[c.Position:c.Position+len(c.Original)] != c.Original {
+					t.Fatalf("change %+v does not apply to %q", c, value)
+				}
+				value = value[:c.Position] + c.Replacement + value[c.Position+len(c.Original):]
+			}
+			if value != oh.value {
+				t.Errorf("applied changes = %q, want %q", value, oh.value)
+			}
+		})
+	}
+}
+
+func TestNormalize_CustomChanges(t *testing.T) {
+	expand := func(s string) string { return strings.ReplaceAll(s, "weekdays", "Mo-Fr") }
+	oh, err := New("weekdays 09:00-17:00", WithNormalizers(expand))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	want := []Change{{Original: "weekdays", Replacement: "Mo-Fr", Kind: ChangeCustom}}
+	if got := oh.GetNormalizationChanges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetNormalizationChanges = %+v, want %+v", got, want)
+	}
+}
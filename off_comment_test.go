@@ -0,0 +1,106 @@
+package openinghours
+
+import (
+	"testing"
+)
+
+func TestOffComment_StateAndComment(t *testing.T) {
+	testCases := []struct {
+		value   string
+		time    string
+		state   string
+		comment string
+	}{
+		{`Mo 15:00-16:00 off "staff meeting"`, "2012-10-01 15:30", "closed", "staff meeting"},
+		{`Mo 15:00-16:00 off "staff meeting"`, "2012-10-01 10:00", "closed", ""},
+		{`Mo 09:00-18:00; Mo 15:00-16:00 off "staff meeting"`, "2012-10-01 15:30", "closed", "staff meeting"},
+		{`Mo 09:00-18:00; Mo 15:00-16:00 off "staff meeting"`, "2012-10-01 10:00", "open", ""},
+		{`Mo-Fr 09:00-18:00, Mo 15:00-16:00 off "staff meeting"`, "2012-10-01 15:30", "closed", "staff meeting"},
+		{`Mo-Fr 09:00-18:00; We off "inventory"`, "2012-10-03 12:00", "closed", "inventory"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value+"@"+tc.time, func(t *testing.T) {
+			oh, err := New(tc.value)
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			tm := parseTime(tc.time)
+			if got := oh.GetStateString(tm); got != tc.state {
+				t.Errorf("expected state '%s', got '%s'", tc.state, got)
+			}
+			if got := oh.GetComment(tm); got != tc.comment {
+				t.Errorf("expected comment '%s', got '%s'", tc.comment, got)
+			}
+		})
+	}
+}
+
+func TestOffComment_ClosedIntervals(t *testing.T) {
+	oh, err := New(`Mo 09:00-18:00; Mo 15:00-16:00 off "staff meeting"`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	from := parseTime("2012-10-01 00:00")
+	to := parseTime("2012-10-02 00:00")
+
+	var found bool
+	for _, iv := range oh.GetClosedIntervals(from, to) {
+		if iv.Comment != "staff meeting" {
+			continue
+		}
+		found = true
+		if !iv.Start.Equal(parseTime("2012-10-01 15:00")) || !iv.End.Equal(parseTime("2012-10-01 16:00")) {
+			t.Errorf("staff meeting interval %v-%v, want 15:00-16:00", iv.Start, iv.End)
+		}
+		if iv.Reason != ClosedByRule {
+			t.Errorf("staff meeting reason %v, want ClosedByRule", iv.Reason)
+		}
+	}
+	if !found {
+		t.Error("expected a closed interval with comment 'staff meeting'")
+	}
+
+	open := oh.GetOpenIntervals(from, to)
+	if len(open) != 2 {
+		t.Fatalf("expected 2 open intervals around the meeting, got %d", len(open))
+	}
+}
+
+func TestOffComment_IteratorVisitsClosedComment(t *testing.T) {
+	oh, err := New(`Mo 15:00-16:00 off "staff meeting"`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	it := oh.GetIterator(parseTime("2012-10-01 00:00"))
+	if next := it.Advance(); !next.Equal(parseTime("2012-10-01 15:00")) {
+		t.Fatalf("Advance() = %v, want 2012-10-01 15:00", next)
+	}
+	if it.GetStateString() != "closed" || it.GetComment() != "staff meeting" {
+		t.Errorf("at 15:00 got %s %q, want closed \"staff meeting\"", it.GetStateString(), it.GetComment())
+	}
+	if next := it.Advance(); !next.Equal(parseTime("2012-10-01 16:00")) {
+		t.Fatalf("Advance() = %v, want 2012-10-01 16:00", next)
+	}
+	if it.GetComment() != "" {
+		t.Errorf("after the meeting got comment %q, want empty", it.GetComment())
+	}
+}
+
+func TestOffComment_OpenCommentChange(t *testing.T) {
+	oh, err := New(`Mo 09:00-12:00 "Morning"; Mo 12:00-15:00 "Afternoon"`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	intervals := oh.GetOpenIntervals(parseTime("2012-10-01 00:00"), parseTime("2012-10-02 00:00"))
+	if len(intervals) != 2 {
+		t.Fatalf("expected 2 intervals split by comment, got %v", intervals)
+	}
+	if intervals[0].Comment != "Morning" || intervals[1].Comment != "Afternoon" ||
+		!intervals[0].End.Equal(parseTime("2012-10-01 12:00")) {
+		t.Errorf("unexpected intervals %v", intervals)
+	}
+}
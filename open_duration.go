@@ -0,0 +1,102 @@
+package openinghours
+
+import (
+	"sort"
+	"time"
+)
+
+// RuleDuration is the open and unknown time contributed by one rule, see
+// GetOpenDurationDetailed
+type RuleDuration struct {
+	RuleIndex int    // like GetMatchingRule
+	Rule      string // prettified rule, e.g. "PH 10:00-14:00"
+	Open      time.Duration
+	Unknown   time.Duration
+}
+
+// DayDuration is the open and unknown time of one day, see GetOpenDurationDetailed
+type DayDuration struct {
+	Date    time.Time // Midnight at the start of the day
+	Open    time.Duration
+	Unknown time.Duration
+	Rules   []RuleDuration // contributing rules, ordered by RuleIndex
+}
+
+// OpenDurationDetail breaks the open and unknown time between two instants
+// down by day and by rule, see GetOpenDurationDetailed
+type OpenDurationDetail struct {
+	Open    time.Duration  // like the open duration of GetOpenDuration
+	Unknown time.Duration  // like the unknown duration of GetOpenDuration
+	Days    []DayDuration  // every day between from and to, including closed days
+	Rules   []RuleDuration // contributing rules over all days, ordered by RuleIndex
+}
+
+// GetOpenDurationDetailed returns the open and unknown durations between from
+// and to like GetOpenDuration, broken down per day and attributed to the rules
+// deciding each span, e.g. to tell the hours of "PH 10:00-14:00" apart from
+// those of "Mo-Fr 09:00-17:00". Days are in the evaluation location; the first
+// and last day only count the time after from and before to.
+func (oh *OpeningHours) GetOpenDurationDetailed(from, to time.Time) OpenDurationDetail {
+	from, to = oh.inLocation(from), oh.inLocation(to)
+	var detail OpenDurationDetail
+	if !from.Before(to) {
+		return detail
+	}
+
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()); day.Before(to); day = day.AddDate(0, 0, 1) {
+		detail.Days = append(detail.Days, DayDuration{Date: day})
+	}
+
+	// changeTimes includes midnights, so every span lies within one day
+	starts := append([]time.Time{from}, oh.changeTimes(from, to)...)
+	dayIndex := 0
+	for i, start := range starts {
+		end := to
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		for dayIndex+1 < len(detail.Days) && !start.Before(detail.Days[dayIndex+1].Date) {
+			dayIndex++
+		}
+
+		incMetric(CounterEvaluation)
+		e := oh.evaluate(startOfMinute(start))
+		if e.state == StateClosed {
+			continue
+		}
+		contribution := RuleDuration{RuleIndex: oh.ruleIndex(e)}
+		if e.index >= 0 {
+			rules, _ := oh.rulesOf(e.group)
+			contribution.Rule = prettifyRule(rules[e.index], prettifyOptions{})
+		}
+		if e.state == StateUnknown {
+			contribution.Unknown = end.Sub(start)
+		} else {
+			contribution.Open = end.Sub(start)
+		}
+
+		day := &detail.Days[dayIndex]
+		day.Open += contribution.Open
+		day.Unknown += contribution.Unknown
+		day.Rules = addRuleDuration(day.Rules, contribution)
+		detail.Open += contribution.Open
+		detail.Unknown += contribution.Unknown
+		detail.Rules = addRuleDuration(detail.Rules, contribution)
+	}
+	return detail
+}
+
+// addRuleDuration adds the durations of d to the entry of its rule in
+// durations, keeping them ordered by RuleIndex
+func addRuleDuration(durations []RuleDuration, d RuleDuration) []RuleDuration {
+	i := sort.Search(len(durations), func(i int) bool { return durations[i].RuleIndex >= d.RuleIndex })
+	if i < len(durations) && durations[i].RuleIndex == d.RuleIndex {
+		durations[i].Open += d.Open
+		durations[i].Unknown += d.Unknown
+		return durations
+	}
+	durations = append(durations, RuleDuration{})
+	copy(durations[i+1:], durations[i:])
+	durations[i] = d
+	return durations
+}
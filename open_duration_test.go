@@ -0,0 +1,82 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetOpenDurationDetailed(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00; PH 10:00-14:00; Sa 10:00-12:00 unknown "call ahead"`,
+		WithHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}}))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	from := time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC) // Monday
+	to := from.AddDate(0, 0, 7)
+
+	detail := oh.GetOpenDurationDetailed(from, to)
+
+	if detail.Open != 36*time.Hour || detail.Unknown != 2*time.Hour {
+		t.Errorf("Open, Unknown = %v, %v, want 36h, 2h", detail.Open, detail.Unknown)
+	}
+	open, unknown := oh.GetOpenDuration(from, to)
+	if detail.Open != open || detail.Unknown != unknown {
+		t.Errorf("Open, Unknown = %v, %v, GetOpenDuration() = %v, %v", detail.Open, detail.Unknown, open, unknown)
+	}
+
+	wantRules := []RuleDuration{
+		{RuleIndex: 0, Rule: "Mo-Fr 09:00-17:00", Open: 32 * time.Hour},
+		{RuleIndex: 1, Rule: "PH 10:00-14:00", Open: 4 * time.Hour},
+		{RuleIndex: 2, Rule: `Sa 10:00-12:00 unknown "call ahead"`, Unknown: 2 * time.Hour},
+	}
+	if len(detail.Rules) != len(wantRules) {
+		t.Fatalf("Rules = %+v, want %+v", detail.Rules, wantRules)
+	}
+	for i, want := range wantRules {
+		if detail.Rules[i] != want {
+			t.Errorf("Rules[%d] = %+v, want %+v", i, detail.Rules[i], want)
+		}
+	}
+
+	if len(detail.Days) != 7 {
+		t.Fatalf("got %d days, want 7", len(detail.Days))
+	}
+	christmas := detail.Days[2]
+	if !christmas.Date.Equal(time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)) || christmas.Open != 4*time.Hour ||
+		len(christmas.Rules) != 1 || christmas.Rules[0].RuleIndex != 1 {
+		t.Errorf("Days[2] = %+v, want 4h of rule 1 on Dec 25", christmas)
+	}
+	if sunday := detail.Days[6]; sunday.Open != 0 || sunday.Unknown != 0 || len(sunday.Rules) != 0 {
+		t.Errorf("Days[6] = %+v, want a closed day", sunday)
+	}
+}
+
+func TestGetOpenDurationDetailed_SplitsAtMidnight(t *testing.T) {
+	oh, err := New("Fr 20:00-02:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	from := time.Date(2024, 1, 19, 12, 0, 0, 0, time.UTC) // Friday noon
+	detail := oh.GetOpenDurationDetailed(from, from.AddDate(0, 0, 1))
+
+	if len(detail.Days) != 2 {
+		t.Fatalf("got %d days, want 2", len(detail.Days))
+	}
+	if detail.Days[0].Open != 4*time.Hour || detail.Days[1].Open != 2*time.Hour {
+		t.Errorf("day durations = %v, %v, want 4h, 2h", detail.Days[0].Open, detail.Days[1].Open)
+	}
+	if len(detail.Rules) != 1 || detail.Rules[0].Open != 6*time.Hour {
+		t.Errorf("Rules = %+v, want 6h of the only rule", detail.Rules)
+	}
+}
+
+func TestGetOpenDurationDetailed_Empty(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if detail := oh.GetOpenDurationDetailed(at, at); len(detail.Days) != 0 || detail.Open != 0 {
+		t.Errorf("GetOpenDurationDetailed(at, at) = %+v, want zero", detail)
+	}
+}
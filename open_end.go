@@ -0,0 +1,52 @@
+package openinghours
+
+import "time"
+
+// openEndComment is the comment of the unknown part of open-ended ranges
+// without a comment of their own, see WithOpenEndUnknown
+const openEndComment = "Specified as open end. Closing time was guessed."
+
+// WithOpenEndUnknown makes open-ended ranges unknown after their minimum:
+// "14:00-17:00+" is open from 14:00 and unknown from 17:00 until midnight. The
+// unknown part has the comment of the rule, or "Specified as open end. Closing
+// time was guessed." if the rule has none. Without this option open-ended
+// ranges are open until midnight.
+//
+// Points in time with open end like "17:00+" are events starting at 17:00 with
+// an undefined end: open from 17:00 to 17:01 and unknown after it until
+// midnight, with or without this option.
+func WithOpenEndUnknown() Option {
+	return func(oh *OpeningHours) {
+		oh.openEndUnknown = true
+	}
+}
+
+// ruleState returns the state of r at t, which r must match. Open rules are
+// unknown in the open end of their ranges if WithOpenEndUnknown is set.
+func (oh *OpeningHours) ruleState(r *rule, t time.Time) State {
+	if r.state == StateOpen && oh.inOpenEnd(r, t) {
+		return StateUnknown
+	}
+	return r.state
+}
+
+// inOpenEnd reports whether t is after the minimum of an open-ended range of r
+// and WithOpenEndUnknown is set, or after the start of an event like "17:00+".
+// A minimum before the start, as in "22:00-01:00+", lies after midnight, so the
+// range has no open end that day.
+func (oh *OpeningHours) inOpenEnd(r *rule, t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	for _, tr := range r.timeRanges {
+		if !tr.openEnd || minute >= tr.end {
+			continue
+		}
+		if tr.point {
+			if minute > tr.start {
+				return true
+			}
+		} else if oh.openEndUnknown && tr.minEnd >= tr.start && minute >= tr.minEnd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpenEndUnknown_GetState(t *testing.T) {
+	tests := []struct {
+		value   string
+		time    string
+		state   string
+		comment string
+	}{
+		{"Mo 17:00+", "2024-01-15 16:59", "closed", ""},
+		{"Mo 17:00+", "2024-01-15 17:00", "open", ""},
+		{"Mo 17:00+", "2024-01-15 17:01", "unknown", openEndComment},
+		{"Mo 17:00+", "2024-01-15 23:59", "unknown", openEndComment},
+		{"Mo 17:00+", "2024-01-16 00:00", "closed", ""},
+		{"Mo-Fr 14:00-17:00+", "2024-01-15 13:59", "closed", ""},
+		{"Mo-Fr 14:00-17:00+", "2024-01-15 16:59", "open", ""},
+		{"Mo-Fr 14:00-17:00+", "2024-01-15 17:00", "unknown", openEndComment},
+		{`Mo-Fr 14:00-17:00+ "late on match days"`, "2024-01-15 18:00", "unknown", "late on match days"},
+		{"Mo 10:00-12:00,20:00+", "2024-01-15 11:00", "open", ""},
+		{"Mo 10:00-12:00,20:00+", "2024-01-15 21:00", "unknown", openEndComment},
+		{"Mo 22:00-01:00+", "2024-01-15 23:00", "open", ""},
+		{"Mo 17:00+ || Mo 17:00-20:00", "2024-01-15 18:00", "open", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.time, func(t *testing.T) {
+			oh, err := New(tt.value, WithOpenEndUnknown())
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			at, _ := time.Parse("2006-01-02 15:04", tt.time)
+			if got := oh.GetStateString(at); got != tt.state {
+				t.Errorf("state = %q, want %q", got, tt.state)
+			}
+			if got := oh.GetComment(at); got != tt.comment {
+				t.Errorf("comment = %q, want %q", got, tt.comment)
+			}
+		})
+	}
+}
+
+func TestOpenEndUnknown_Intervals(t *testing.T) {
+	oh, err := New("Mo-Fr 14:00-17:00+", WithOpenEndUnknown())
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	intervals := oh.GetOpenIntervals(monday, monday.AddDate(0, 0, 1))
+	expected := []Interval{
+		{Start: monday.Add(14 * time.Hour), End: monday.Add(17 * time.Hour)},
+		{Start: monday.Add(17 * time.Hour), End: monday.AddDate(0, 0, 1), Unknown: true, Comment: openEndComment},
+	}
+	if len(intervals) != len(expected) {
+		t.Fatalf("got %d intervals, want %d: %+v", len(intervals), len(expected), intervals)
+	}
+	for i, iv := range intervals {
+		if !iv.Start.Equal(expected[i].Start) || !iv.End.Equal(expected[i].End) || iv.Unknown != expected[i].Unknown || iv.Comment != expected[i].Comment {
+			t.Errorf("interval %d = %+v, want %+v", i, iv, expected[i])
+		}
+	}
+
+	if got := oh.GetNextChange(monday.Add(15 * time.Hour)); !got.Equal(monday.Add(17 * time.Hour)) {
+		t.Errorf("next change = %v, want 17:00", got)
+	}
+
+	// The value and schedules without the option are unaffected
+	if got := oh.PrettifyValue(); got != "Mo-Fr 14:00-17:00+" {
+		t.Errorf("PrettifyValue = %q", got)
+	}
+	plain, err := New("Mo-Fr 14:00-17:00+")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if intervals := plain.GetOpenIntervals(monday, monday.AddDate(0, 0, 1)); len(intervals) != 1 || intervals[0].Unknown {
+		t.Errorf("expected one open interval without the option, got %+v", intervals)
+	}
+}
+
+func TestPointInTime_Intervals(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return monday.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	tests := []struct {
+		value    string
+		expected []Interval
+	}{
+		// Without an end the event is open at its start and unknown afterwards
+		{"Mo 08:00+", []Interval{
+			{Start: at(8, 0), End: at(8, 1), State: StateOpen, Point: true},
+			{Start: at(8, 1), End: at(24, 0), State: StateUnknown, Unknown: true, Comment: openEndComment},
+		}},
+		{`Mo 20:00+ "concert"`, []Interval{
+			{Start: at(20, 0), End: at(20, 1), State: StateOpen, Comment: "concert", Point: true},
+			{Start: at(20, 1), End: at(24, 0), State: StateUnknown, Unknown: true, Comment: "concert"},
+		}},
+		// Points without "+" last their minute, like collection times
+		{"Mo 08:00,08:01,17:30", []Interval{
+			{Start: at(8, 0), End: at(8, 1), State: StateOpen, Point: true},
+			{Start: at(8, 1), End: at(8, 2), State: StateOpen, Point: true},
+			{Start: at(17, 30), End: at(17, 31), State: StateOpen, Point: true},
+		}},
+		{"Mo 10:00-12:00,18:00+", []Interval{
+			{Start: at(10, 0), End: at(12, 0), State: StateOpen},
+			{Start: at(18, 0), End: at(18, 1), State: StateOpen, Point: true},
+			{Start: at(18, 1), End: at(24, 0), State: StateUnknown, Unknown: true, Comment: openEndComment},
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, opts := range [][]Option{nil, {WithOpenEndUnknown()}} {
+			oh, err := New(tt.value, opts...)
+			if err != nil {
+				t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+			}
+			intervals := oh.GetOpenIntervals(monday, monday.AddDate(0, 0, 1))
+			if len(intervals) != len(tt.expected) {
+				t.Errorf("%q: got %d intervals, want %d: %+v", tt.value, len(intervals), len(tt.expected), intervals)
+				continue
+			}
+			for i, iv := range intervals {
+				if !iv.Start.Equal(tt.expected[i].Start) || !iv.End.Equal(tt.expected[i].End) || iv.State != tt.expected[i].State ||
+					iv.Unknown != tt.expected[i].Unknown || iv.Comment != tt.expected[i].Comment || iv.Point != tt.expected[i].Point {
+					t.Errorf("%q: interval %d = %+v, want %+v", tt.value, i, iv, tt.expected[i])
+				}
+			}
+		}
+	}
+}
+
+func TestPointInTime_StateAndPrettify(t *testing.T) {
+	oh, err := New("Mo 8:00+")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	if info := oh.GetStateInfo(monday.Add(8 * time.Hour)); info.State != StateOpen || !info.Point {
+		t.Errorf("at 08:00: got %+v, want an open point", info)
+	}
+	if info := oh.GetStateInfo(monday.Add(9 * time.Hour)); info.State != StateUnknown || info.Point {
+		t.Errorf("at 09:00: got %+v, want unknown", info)
+	}
+	if got := oh.GetNextChange(monday.Add(8 * time.Hour)); !got.Equal(monday.Add(8*time.Hour + time.Minute)) {
+		t.Errorf("next change after the start = %v, want 08:01", got)
+	}
+
+	for value, want := range map[string]string{
+		"Mo 8:00+":          "Mo 08:00+",
+		"Mo-Fr 12:00,17:30": "Mo-Fr 12:00,17:30",
+	} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if got := oh.PrettifyValue(); got != want {
+			t.Errorf("%q: PrettifyValue = %q, want %q", value, got, want)
+		}
+	}
+}
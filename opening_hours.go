@@ -3,31 +3,77 @@ package openinghours
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// HolidayChecker is an interface that users can implement to provide public holiday information
+// HolidayChecker is an interface that users can implement to provide public holiday information.
+// IsHoliday is called with times in the location they are evaluated in: the
+// venue timezone if set (see SetTimezone), otherwise the location of the queried
+// time. Implementations should key on the calendar date of t in t's location,
+// e.g. t.Format("2006-01-02"), not on t.UTC(), so that day boundaries are local
+// midnights and late-evening queries don't match the neighbouring day.
 type HolidayChecker interface {
 	IsHoliday(t time.Time) bool
 }
 
-// SchoolHolidayChecker is an interface for checking school holidays
+// HolidayNamer is implemented by holiday checkers that know the name of the
+// public holiday of a day, like holidays.Calendar. GetComment returns the name
+// for matching PH rules without a comment of their own. ok is false if t is
+// not a public holiday.
+type HolidayNamer interface {
+	HolidayName(t time.Time) (name string, ok bool)
+}
+
+// SchoolHolidayChecker is an interface for checking school holidays. Like
+// HolidayChecker, it is called with times in their evaluation location.
 type SchoolHolidayChecker interface {
 	IsSchoolHoliday(t time.Time) bool
 }
 
-// OpeningHours represents parsed opening hours
+// OpeningHours represents parsed opening hours.
+//
+// Evaluation methods like GetState, GetNextChange and GetOpenIntervals only read
+// the rules and settings, which are fixed at parse time, so an OpeningHours can
+// be evaluated from many goroutines at once. Setters like SetHolidayChecker or
+// SetTimezone must not be called concurrently with evaluation; pass options to
+// New instead, or configure a Clone per goroutine.
 type OpeningHours struct {
-	rules                []rule   // Primary group of rules (before ||)
-	fallbackGroups       [][]rule // Fallback groups (after ||), each group separated by ||
+	rules                []rule      // Primary group of rules (before ||)
+	fallbackGroups       [][]rule    // Fallback groups (after ||), each group separated by ||
+	ruleOrder            []ruleRef   // Evaluation order of rules, see orderRules
+	fallbackOrders       [][]ruleRef // Evaluation order of each fallback group
+	commaGroups          [][]int     // Indexes of the primary rules of each comma-separated group, see groupRules
+	weekStates           *weekStates // Precomputed week of week stable values, see computeWeekStates
+	closures             []closure   // Temporary closures, see AddClosure
 	holidayChecker       HolidayChecker
 	schoolHolidayChecker SchoolHolidayChecker
-	latitude             float64 // Latitude for sunrise/sunset calculations
-	longitude            float64 // Longitude for sunrise/sunset calculations
-	hasCoordinates       bool    // Whether coordinates have been set
-	warnings             []string // Warnings collected during parsing
+	latitude             float64                // Latitude for sunrise/sunset calculations
+	longitude            float64                // Longitude for sunrise/sunset calculations
+	hasCoordinates       bool                   // Whether coordinates have been set
+	region               *Region                // Regional context, see SetRegion
+	elevation            float64                // Observer elevation in meters, see SetElevation
+	warnings             []Warning              // Warnings collected during parsing
+	parsingRule          int                    // Index of the rule being parsed for warnings, -1 outside of rules
+	normalizers          []Normalizer           // Custom normalizers applied before the built-in ones
+	normalizationChanges []Change               // Changes made by the normalizers, see GetNormalizationChanges
+	variableDates        []VariableDateProvider // Movable dates added by WithVariableDates
+	value                string                 // Normalized value, used as schedule cache key
+	source               string                 // Value as passed to New, see MarshalText
+	location             *time.Location         // Venue timezone, nil to use the location of the evaluated time
+	holidayPolicy        HolidayPolicy          // How PH rules are evaluated without a holiday checker
+	schoolHolidayPolicy  SchoolHolidayPolicy    // How SH rules are evaluated without a school holiday checker
+	mergeSplitDates      bool                   // Date-only rules take the modifier of the following rule, see WithMergedSplitDates
+	openEndUnknown       bool                   // Open-ended ranges are unknown after their minimum, see WithOpenEndUnknown
+	strict               bool                   // Values with warnings are rejected, see WithStrictMode
+	eventDuration        int                    // Minutes a point in time lasts, 0 for one minute, see WithEventDuration
+
+	sunCache *dayCache[sunKey, sunMinutes] // Memoized variable times, see ClearCache
+
+	holidayCheckerCtx       HolidayCheckerCtx       // Context-aware holiday checker, if set
+	schoolHolidayCheckerCtx SchoolHolidayCheckerCtx // Context-aware school holiday checker, if set
 }
 
 type weekConstraint struct {
@@ -43,42 +89,57 @@ type rule struct {
 	timeRanges         []timeRange
 	state              State
 	comment            string
-	yearStart          int  // 0=not set, otherwise the year (e.g., 2024)
-	yearEnd            int  // 0=not set, otherwise the end year (e.g., 2026)
-	yearInterval       int  // 0=not set, interval for year ranges (e.g., /2 for every other year)
-	monthStart         int  // 0=not set, 1-12 for Jan-Dec
-	monthEnd           int  // 0=not set, 1-12 for Jan-Dec
-	dayStart           int  // 0=not set, 1-31 for day of month
-	dayEnd             int  // 0=not set, 1-31 for day of month
-	dayInterval        int  // 0=not set, interval for day ranges (e.g., /8 for every 8th day)
-	isPH               bool // true if this rule applies to public holidays
-	isSH               bool // true if this rule applies to school holidays
-	phOffset           int  // days offset from public holiday (-1 = day before, +1 = day after, 0 = no offset/actual PH)
-	isEaster           bool // true if this rule uses Easter
-	easterOffset       int  // days offset from Easter (-2 = Good Friday, +1 = Easter Monday)
-	isEasterRange      bool // true if this is an Easter date range
-	easterOffsetEnd    int  // end offset for Easter ranges (e.g., "easter -2 days-easter +1 day")
-	ruleGroup          int  // rules from same comma-separated expression share a group; 0 = no group
+	yearStart          int                  // 0=not set, otherwise the year (e.g., 2024)
+	yearEnd            int                  // 0=not set, otherwise the end year (e.g., 2026)
+	yearInterval       int                  // 0=not set, interval for year ranges (e.g., /2 for every other year)
+	dateStart          int                  // 0=not set, otherwise yyyymmdd start of a full date range (e.g., "2025 Jun 01-2026 Sep 30")
+	dateEnd            int                  // 0=not set, otherwise yyyymmdd end of a full date range
+	monthStart         int                  // 0=not set, 1-12 for Jan-Dec
+	monthEnd           int                  // 0=not set, 1-12 for Jan-Dec
+	dayStart           int                  // 0=not set, 1-31 for day of month, -1 to -31 from the end of the month (-1 = last day)
+	dayEnd             int                  // 0=not set, 1-31 for day of month, -1 to -31 from the end of the month
+	everyMonth         bool                 // true if the days apply in each month of monthStart-monthEnd (e.g., "Jan-Dec -1")
+	dayInterval        int                  // 0=not set, interval for day ranges (e.g., /8 for every 8th day)
+	dateOffset         int                  // days added to a single date (e.g., 3 for "Dec 25 +3 days"), 0 = no offset
+	isPH               bool                 // true if this rule applies to public holidays
+	isSH               bool                 // true if this rule applies to school holidays
+	holidayUnion       bool                 // true if weekdays and PH/SH were listed together ("Mo-Fr,PH"), matching on either
+	phOffset           int                  // days offset from public holiday (-1 = day before, +1 = day after, 0 = no offset/actual PH)
+	isEaster           bool                 // true if this rule uses Easter
+	easterOffset       int                  // days offset from Easter (-2 = Good Friday, +1 = Easter Monday)
+	isEasterRange      bool                 // true if this is an Easter date range
+	easterOffsetEnd    int                  // end offset for Easter ranges (e.g., "easter -2 days-easter +1 day")
+	easterStartDate    int                  // 0=range starts at Easter, otherwise mmdd of a fixed start (e.g., 106 for "Jan 06-easter")
+	easterEndDate      int                  // 0=range ends at Easter, otherwise mmdd of a fixed end (e.g., 1031 for "easter-Oct 31")
+	easterWraps        bool                 // true if the range with a fixed end crosses the year end (e.g., "Oct 01-easter"), see easterRange
+	variableDate       VariableDateProvider // movable date used instead of Easter (e.g., "whitsun"), nil = Easter
+	ruleGroup          int                  // rules from same comma-separated expression share a group; 0 = no group
+	monthList          int                  // rules expanded from the same month list ("Jan 01,Dec 25-26") share an id; 0 = no list
+	metadata           map[string]string    // set by SetRuleMetadata, not part of the value
 }
 
 type weekdayConstraint struct {
 	weekday int // 0-6 for Su-Sa
 	nthFrom int // positive: nth occurrence (1 = first), negative: from end (-1 = last)
 	nthTo   int // for ranges like [1-2], 0 if single value
+	offset  int // days after the nth weekday, like +1 in "Sa[-1] +1 day"
 }
 
 type timeRange struct {
 	start       int    // minutes from midnight (or -1 for variable)
 	end         int    // minutes from midnight (or -1 for variable)
 	openEnd     bool   // true if this is an open-ended range (e.g., 17:00+)
+	minEnd      int    // open-ended ranges: the end before "+" (e.g., 17:00 of 14:00-17:00+), equal to start for 17:00+
 	startVar    string // "sunrise", "sunset", "dawn", "dusk" (empty if fixed time)
 	endVar      string // "sunrise", "sunset", "dawn", "dusk" (empty if fixed time)
 	startOffset int    // offset in minutes (+60 means +01:00)
 	endOffset   int    // offset in minutes (+60 means +01:00)
-	interval    int    // 0=not set, interval in minutes for periodic opening (e.g., 90 for 01:30)
+	interval    int    // 0=not set, minutes between the points in time of a periodic range (e.g., 90 for 10:00-16:00/01:30)
+	point       bool   // a point in time like 12:00 (the minute from 12:00 to 12:01, see WithEventDuration) or the event start 12:00+
 }
 
-// State represents the opening state
+// State represents the opening state. It formats and encodes to JSON as
+// "open", "closed" or "unknown".
 type State int
 
 const (
@@ -91,8 +152,12 @@ const (
 type Interval struct {
 	Start   time.Time
 	End     time.Time
-	Unknown bool   // true if this interval is "unknown" state
-	Comment string // comment for this interval
+	State   State        // StateOpen, StateUnknown, or StateClosed for closed intervals
+	Unknown bool         // true if this interval is "unknown" state
+	Comment string       // comment for this interval
+	Point   bool         // a point in time like "12:00" or "10:00" of "10:00-16:00/01:30", or the start of an event like "12:00+"
+	Reason  ClosedReason // why the interval is closed (closed intervals only)
+	Rule    string       // prettified rule that closes the interval, if any (closed intervals only)
 }
 
 var weekdayNames = map[string]int{
@@ -102,6 +167,19 @@ var weekdayNames = map[string]int{
 	// German weekday names
 	"sonntag": 0, "montag": 1, "dienstag": 2, "mittwoch": 3, "donnerstag": 4, "freitag": 5, "samstag": 6,
 	"so": 0, "di": 2, "mi": 3, "do": 4,
+	// French weekday names
+	"dimanche": 0, "lundi": 1, "mardi": 2, "mercredi": 3, "jeudi": 4, "vendredi": 5, "samedi": 6,
+	"dim": 0, "lun": 1, "mer": 3, "jeu": 4, "ven": 5, "sam": 6,
+	// Spanish weekday names
+	"domingo": 0, "lunes": 1, "martes": 2, "miércoles": 3, "miercoles": 3, "jueves": 4, "viernes": 5, "sábado": 6, "sabado": 6,
+	"dom": 0, "mié": 3, "mie": 3, "jue": 4, "vie": 5, "sáb": 6, "sab": 6,
+	// Italian weekday names
+	"domenica": 0, "lunedì": 1, "lunedi": 1, "martedì": 2, "martedi": 2, "mercoledì": 3, "mercoledi": 3,
+	"giovedì": 4, "giovedi": 4, "venerdì": 5, "venerdi": 5, "sabato": 6,
+	"gio": 4,
+	// Dutch weekday names
+	"zondag": 0, "maandag": 1, "dinsdag": 2, "woensdag": 3, "donderdag": 4, "vrijdag": 5, "zaterdag": 6,
+	"zo": 0, "ma": 1, "wo": 3, "vr": 5, "za": 6,
 }
 
 var monthNames = map[string]int{
@@ -112,117 +190,104 @@ var monthNames = map[string]int{
 	// German month names
 	"januar": 1, "februar": 2, "märz": 3, "maerz": 3, "mai": 5, "juni": 6,
 	"juli": 7, "oktober": 10, "dezember": 12,
+	// French month names
+	"janvier": 1, "février": 2, "fevrier": 2, "mars": 3, "avril": 4, "juin": 6, "juillet": 7,
+	"août": 8, "aout": 8, "septembre": 9, "octobre": 10, "novembre": 11, "décembre": 12, "decembre": 12,
+	"janv": 1, "févr": 2, "fevr": 2, "avr": 4, "juil": 7, "sept": 9, "déc": 12,
+	// Spanish month names
+	"enero": 1, "febrero": 2, "marzo": 3, "abril": 4, "mayo": 5, "junio": 6, "julio": 7,
+	"agosto": 8, "septiembre": 9, "setiembre": 9, "octubre": 10, "noviembre": 11, "diciembre": 12,
+	"ene": 1, "abr": 4, "ago": 8, "dic": 12,
+	// Italian month names
+	"gennaio": 1, "febbraio": 2, "aprile": 4, "maggio": 5, "giugno": 6, "luglio": 7,
+	"settembre": 9, "ottobre": 10, "dicembre": 12,
+	"gen": 1, "mag": 5, "giu": 6, "lug": 7, "set": 9, "ott": 10,
+	// Dutch month names
+	"januari": 1, "februari": 2, "maart": 3, "mei": 5, "augustus": 8,
+	"mrt": 3, "okt": 10,
 }
 
 var timeRangePattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})(?:/(\d{2}):(\d{2}))?$`)
 var singleTimePattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
 var openEndPattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})\+$`)
 var openEndRangePattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})\+$`)
-var variableTimePattern = regexp.MustCompile(`^\(?(sunrise|sunset|dawn|dusk)([+-]\d{2}:\d{2})?\)?$`)
+var variableTimePattern = regexp.MustCompile(`(?i)^\(?(sunrise|sunset|dawn|dusk)([+-]\d{2}:\d{2})?\)?$`)
 var dotTimePattern = regexp.MustCompile(`\b(\d{1,2})\.(\d{2})\b`)
 var ampmPattern = regexp.MustCompile(`(?i)(\d{1,2})(?::(\d{2}))?\s*([ap]\.?m\.?)`)
-var phOffsetPattern = regexp.MustCompile(`^\s*([+-]?\d+)\s*days?\s*`)
-var easterPattern = regexp.MustCompile(`^easter\s*([+-]?\d+\s*days?)?`)
-var easterRangePattern = regexp.MustCompile(`^easter\s*([+-]?\d+)\s*days?\s*-\s*easter\s*([+-]?\d+)\s*days?\s*`)
-
-// normalizeTimeString converts various time formats to standard HH:MM-HH:MM format
-func normalizeTimeString(s string) string {
-	// 0. Normalize different dash types to standard hyphen
-	// En dash (U+2013), Em dash (U+2014), minus sign (U+2212) -> hyphen-minus (U+002D)
-	s = strings.ReplaceAll(s, "–", "-") // En dash
-	s = strings.ReplaceAll(s, "—", "-") // Em dash
-	s = strings.ReplaceAll(s, "−", "-") // Minus sign
-
-	// 0.5. Normalize alternative separators to hyphen
-	// "to" and "through" can be used instead of "-" in time and weekday ranges
-	// Use word boundaries to avoid replacing inside words
-	toPattern := regexp.MustCompile(`(?i)\s+to\s+`)
-	throughPattern := regexp.MustCompile(`(?i)\s+through\s+`)
-	s = toPattern.ReplaceAllString(s, "-")
-	s = throughPattern.ReplaceAllString(s, "-")
-
-	// 1. Convert dots to colons in time: 10.00 -> 10:00
-	// This must be done FIRST before short time format conversion
-	// Pattern: \b(\d{1,2})\.(\d{2})\b
-	s = dotTimePattern.ReplaceAllString(s, "$1:$2")
-
-	// 2. Convert short time format to standard format: 10-12 -> 10:00-12:00
-	// Must not match things like "week 1-10", "Mo-Fr", or "Jan 01-15" (day ranges)
-	// Split by spaces and check each word to avoid converting week numbers or day ranges
-	words := strings.Fields(s)
-	for i, word := range words {
-		// Only convert if the previous word isn't "week" (case insensitive)
-		prevIsWeek := i > 0 && strings.ToLower(words[i-1]) == "week"
-		// Also don't convert if previous word is a month name (it's a day range like "Jan 01-15")
-		prevIsMonth := false
-		if i > 0 {
-			_, prevIsMonth = monthNames[strings.ToLower(words[i-1])]
-		}
-		if !prevIsWeek && !prevIsMonth {
-			shortPattern := regexp.MustCompile(`^(\d{1,2})-(\d{1,2})$`)
-			if match := shortPattern.FindStringSubmatch(word); match != nil {
-				start, err1 := strconv.Atoi(match[1])
-				end, err2 := strconv.Atoi(match[2])
-				if err1 == nil && err2 == nil && start >= 0 && start <= 24 && end >= 0 && end <= 24 {
-					words[i] = fmt.Sprintf("%d:00-%d:00", start, end)
-				}
-			}
-		}
-	}
-	s = strings.Join(words, " ")
 
-	// 3. Convert AM/PM to 24-hour format
-	// Pattern for time with am/pm: (\d{1,2})(?::(\d{2}))?\s*(a\.?m\.?|p\.?m\.?)
-	s = ampmPattern.ReplaceAllStringFunc(s, func(match string) string {
-		// Parse the match
-		parts := ampmPattern.FindStringSubmatch(match)
-		if parts == nil {
-			return match
-		}
+// shortTimePattern matches number-number that is NOT preceded or followed by a colon or another digit
+var shortTimePattern = regexp.MustCompile(`(?:^|[^\d:])(\d{1,2})-(\d{1,2})(?:[^\d:]|$)`)
+var phOffsetPattern = regexp.MustCompile(`(?i)^\s*([+-]?\d+)\s*days?\s*`)
 
-		hour, _ := strconv.Atoi(parts[1])
-		minute := 0
-		if parts[2] != "" {
-			minute, _ = strconv.Atoi(parts[2])
-		}
-		ampm := strings.ToLower(parts[3])
+// allDayTimePattern matches "24/7" used as the time after a selector like "Sa-Su 24/7"
+var allDayTimePattern = regexp.MustCompile(`([^\s;,]\s+)24/7(\s|[;,]|$)`)
+var easterPattern = regexp.MustCompile(`(?i)^easter\s*([+-]?\d+\s*days?)?`)
+var easterRangePattern = regexp.MustCompile(`(?i)^easter\s*([+-]?\d+)\s*days?\s*-\s*easter\s*([+-]?\d+)\s*days?\s*`)
+var dateToEasterPattern = regexp.MustCompile(`(?i)^(\p{L}+)\s+(\d{1,2})\s*-\s*easter(?:\s*([+-]\d+)\s*days?)?(?:\s+|$)`)
+var easterToDatePattern = regexp.MustCompile(`(?i)^easter(?:\s*([+-]\d+)\s*days?)?\s*-\s*(\p{L}+)\s+(\d{1,2})(?:\s+|$)`)
 
-		// Normalize ampm (remove dots and spaces)
-		ampm = strings.ReplaceAll(ampm, ".", "")
-		ampm = strings.TrimSpace(ampm)
-
-		// Convert to 24-hour format
-		if strings.HasPrefix(ampm, "p") && hour != 12 {
-			// PM: add 12 hours (except for 12pm which stays 12)
-			hour += 12
-		} else if strings.HasPrefix(ampm, "a") && hour == 12 {
-			// 12am is midnight (00:00)
-			hour = 0
-		}
-
-		return fmt.Sprintf("%d:%02d", hour, minute)
-	})
-
-	return s
-}
+// Option configures an OpeningHours instance created by New
+type Option func(*OpeningHours)
 
 // New parses an opening hours string and returns an OpeningHours instance
-func New(value string) (*OpeningHours, error) {
-	oh := &OpeningHours{}
+func New(value string, opts ...Option) (*OpeningHours, error) {
+	oh := &OpeningHours{sunCache: newDayCache[sunKey, sunMinutes]()}
+	for _, opt := range opts {
+		opt(oh)
+	}
 	if err := oh.parse(value); err != nil {
 		return nil, err
 	}
 	return oh, nil
 }
 
+// WithCoordinates sets the coordinates for sunrise/sunset calculations, like SetCoordinates
+func WithCoordinates(latitude, longitude float64) Option {
+	return func(oh *OpeningHours) {
+		oh.SetCoordinates(latitude, longitude)
+	}
+}
+
+// WithHolidayChecker sets the holiday checker, like SetHolidayChecker
+func WithHolidayChecker(hc HolidayChecker) Option {
+	return func(oh *OpeningHours) {
+		oh.SetHolidayChecker(hc)
+	}
+}
+
+// WithSchoolHolidayChecker sets the school holiday checker, like SetSchoolHolidayChecker
+func WithSchoolHolidayChecker(shc SchoolHolidayChecker) Option {
+	return func(oh *OpeningHours) {
+		oh.SetSchoolHolidayChecker(shc)
+	}
+}
+
+// WithTimezone sets the venue's timezone, like SetTimezone
+func WithTimezone(loc *time.Location) Option {
+	return func(oh *OpeningHours) {
+		oh.SetTimezone(loc)
+	}
+}
+
+// WithStrictMode rejects values that parse only with warnings, e.g. values that
+// had to be normalized like "Mo - Fr 9-17". New returns an error with the first
+// warning instead.
+func WithStrictMode() Option {
+	return func(oh *OpeningHours) {
+		oh.strict = true
+	}
+}
+
 // SetHolidayChecker sets the holiday checker for this OpeningHours instance
 func (oh *OpeningHours) SetHolidayChecker(hc HolidayChecker) {
-	oh.holidayChecker = hc
+	oh.holidayChecker = withDayCache(hc)
+	oh.holidayCheckerCtx = nil
 }
 
 // SetSchoolHolidayChecker sets the school holiday checker for this OpeningHours instance
 func (oh *OpeningHours) SetSchoolHolidayChecker(shc SchoolHolidayChecker) {
-	oh.schoolHolidayChecker = shc
+	oh.schoolHolidayChecker = withSchoolDayCache(shc)
+	oh.schoolHolidayCheckerCtx = nil
 }
 
 // SetCoordinates sets the geographic coordinates for sunrise/sunset calculations
@@ -230,37 +295,38 @@ func (oh *OpeningHours) SetCoordinates(latitude, longitude float64) {
 	oh.latitude = latitude
 	oh.longitude = longitude
 	oh.hasCoordinates = true
+	oh.sunCache.clear()
 }
 
-// GetWarnings returns any warnings that were collected during parsing
-func (oh *OpeningHours) GetWarnings() []string {
-	return oh.warnings
+// SetTimezone sets the venue's timezone. Times passed to evaluation methods like
+// GetState, GetNextChange and GetOpenIntervals are converted to loc before their
+// wall clock is matched against the rules, and returned times and intervals are
+// in loc. A nil loc restores the default of using each time's own location.
+func (oh *OpeningHours) SetTimezone(loc *time.Location) {
+	oh.location = loc
+	oh.sunCache.clear()
 }
 
-// addWarning adds a warning message to the warnings list
-func (oh *OpeningHours) addWarning(msg string) {
-	oh.warnings = append(oh.warnings, msg)
+// inLocation converts t to the venue timezone, if one is set
+func (oh *OpeningHours) inLocation(t time.Time) time.Time {
+	if oh.location == nil {
+		return t
+	}
+	return t.In(oh.location)
 }
 
-// resolveVariableTime resolves a variable time (sunrise, sunset, dawn, dusk) to minutes from midnight
+// resolveVariableTime resolves a variable time (sunrise, sunset, dawn, dusk) to minutes from midnight.
+// On polar days and nights the result is clamped to the day, see variableTimeMinutes.
 func (oh *OpeningHours) resolveVariableTime(t time.Time, varType string, offset int) int {
 	var baseTime int
 
-	if oh.hasCoordinates {
-		// Use calculated times based on coordinates
-		switch varType {
-		case "sunrise":
-			baseTime = calculateSunrise(t, oh.latitude, oh.longitude)
-		case "sunset":
-			baseTime = calculateSunset(t, oh.latitude, oh.longitude)
-		case "dawn":
-			baseTime = calculateDawn(t, oh.latitude, oh.longitude)
-		case "dusk":
-			baseTime = calculateDusk(t, oh.latitude, oh.longitude)
-		default:
-			// Fallback to default
-			baseTime = defaultSunrise
+	if oh.hasCoordinates || oh.region != nil {
+		// Use calculated times based on coordinates or the latitude of the region
+		minutes, polar := oh.cachedVariableTimeMinutes(t, varType)
+		if polar {
+			return min(max(minutes+offset, 0), 24*60)
 		}
+		baseTime = minutes
 	} else {
 		// Use default times when no coordinates are set
 		switch varType {
@@ -291,75 +357,26 @@ func (oh *OpeningHours) resolveVariableTime(t time.Time, varType string, offset
 	return result
 }
 
-// GetState returns true if open at the given time
-func (oh *OpeningHours) GetState(t time.Time) bool {
-	// Check for extended midnight continuation in comma-separated rule groups
-	// This handles cases like "Su-Tu 11:00-01:00, We-Th 11:00-03:00" where
-	// Tuesday's opening should extend to Wednesday 03:00 (using We's end time)
-	if oh.checkExtendedMidnightContinuation(t) {
-		return true
-	}
-
-	// Track ruleGroups that had a selector match but no time match
-	// These groups "own" the time but none of their rules matched
-	selectorMatchedGroups := make(map[int]bool)
-
-	// Track if we've seen a selector match that should override
-	var overridingRule *rule
-
-	for i := len(oh.rules) - 1; i >= 0; i-- {
-		r := oh.rules[i]
-		if r.matchesWithOH(t, oh.holidayChecker, oh) {
-			if r.state == StateUnknown {
-				// Primary is unknown, check fallback groups
-				return oh.getStateFromFallback(t)
-			}
-			// If an earlier rule matches, check if an overriding rule already claimed this day
-			if overridingRule != nil {
-				// Only apply override if the overriding rule has a MORE SPECIFIC selector
-				// (i.e., doesn't match the same selector as this rule)
-				if !oh.hasSameSelector(overridingRule, &r, t) {
-					return false
-				}
-			}
-			return r.state == StateOpen
-		}
-		// If the rule's selector (day/date) matches but time doesn't,
-		// and the rule has state=Open (not Off/Closed), this rule may override
-		// earlier rules. This implements the OSM spec where
-		// "We 12:00-18:00" overrides "Mo-Fr 10:00-16:00" for Wednesday entirely.
-		// But "off" rules like "Mo 15:00-16:00 off" should only apply during their time.
-		if r.state == StateOpen && len(r.timeRanges) > 0 &&
-			r.matchesSelectorWithOH(t, oh.holidayChecker, oh) {
-			// This "open" rule owns this day but we're outside its time ranges
-			// For comma-separated rules (same ruleGroup), don't override immediately -
-			// check if other rules in the group might match
-			if r.ruleGroup > 0 {
-				selectorMatchedGroups[r.ruleGroup] = true
-				continue
-			}
-			// Remember this rule as potentially overriding, but check if earlier rules match first
-			if overridingRule == nil {
-				overridingRule = &r
-			}
-		}
-	}
-
-	// If we had an overriding rule and no matching rule was found, return closed
-	if overridingRule != nil {
-		return false
-	}
+// startOfMinute returns t with seconds and sub-second precision dropped.
+// Opening hours have minute resolution: a state holds for the whole minute
+// [hh:mm:00, hh:mm+1:00), so 16:59:59 evaluates like 16:59.
+func startOfMinute(t time.Time) time.Time {
+	return t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+}
 
-	// If any comma-separated group had selector matches but no full match, return closed
-	if len(selectorMatchedGroups) > 0 {
-		return false
-	}
+// startOfNextMinute returns the first instant of the minute following t
+func startOfNextMinute(t time.Time) time.Time {
+	return startOfMinute(t).Add(time.Minute)
+}
 
-	// No match in primary, check fallback groups
-	if len(oh.fallbackGroups) > 0 {
-		return oh.getStateFromFallback(t)
+// GetState returns true if open at the given time.
+// The time is evaluated at minute resolution, see startOfMinute.
+func (oh *OpeningHours) GetState(t time.Time) bool {
+	incMetric(CounterEvaluation)
+	if oh.weekStates != nil {
+		return oh.weekStates.state(oh.inLocation(t)) == StateOpen
 	}
-	return false
+	return oh.evaluate(oh.inLocation(t)).state == StateOpen
 }
 
 // hasSameSelector checks if two rules have the same selector (weekdays, dates, etc.)
@@ -377,8 +394,9 @@ func (oh *OpeningHours) hasSameSelector(r1, r2 *rule, t time.Time) bool {
 			}
 		}
 		if same {
-			// Also check month constraints
-			if r1.monthStart == r2.monthStart && r1.monthEnd == r2.monthEnd {
+			// Also check month constraints and full date ranges
+			if r1.monthStart == r2.monthStart && r1.monthEnd == r2.monthEnd &&
+				r1.dateStart == r2.dateStart && r1.dateEnd == r2.dateEnd {
 				return true
 			}
 		}
@@ -387,7 +405,7 @@ func (oh *OpeningHours) hasSameSelector(r1, r2 *rule, t time.Time) bool {
 	// If neither has weekday constraints, check date constraints
 	if r1.weekdays == nil && r2.weekdays == nil {
 		if r1.monthStart == r2.monthStart && r1.monthEnd == r2.monthEnd &&
-			r1.dayStart == r2.dayStart && r1.dayEnd == r2.dayEnd {
+			r1.dayStart == r2.dayStart && r1.dayEnd == r2.dayEnd && r1.everyMonth == r2.everyMonth && r1.dateOffset == r2.dateOffset {
 			return true
 		}
 	}
@@ -402,29 +420,20 @@ func (oh *OpeningHours) hasSameSelector(r1, r2 *rule, t time.Time) bool {
 // (using We-Th's end time because Wednesday is covered by We-Th).
 func (oh *OpeningHours) checkExtendedMidnightContinuation(t time.Time) bool {
 	minuteOfDay := t.Hour()*60 + t.Minute()
-	weekday := int(t.Weekday())
-	prevWeekday := (weekday + 6) % 7
-
-	// Group rules by ruleGroup
-	rulesByGroup := make(map[int][]rule)
-	for _, r := range oh.rules {
-		if r.ruleGroup > 0 {
-			rulesByGroup[r.ruleGroup] = append(rulesByGroup[r.ruleGroup], r)
-		}
-	}
+	prevDay := t.AddDate(0, 0, -1)
 
 	// For each group, check for extended midnight continuation
-	for _, rules := range rulesByGroup {
+	for _, indexes := range oh.commaGroups {
 		// Find if there's a rule where:
-		// 1. Previous day matches the rule's weekdays
+		// 1. Previous day matches the rule's selector
 		// 2. The rule has midnight-spanning time (end <= start)
 		var prevDayRule *rule
 		var prevDayEndTime int = -1
 
-		for i := range rules {
-			r := &rules[i]
-			if r.weekdays != nil && r.weekdays[prevWeekday] && len(r.timeRanges) > 0 {
-				tr := r.timeRanges[0] // Use first time range
+		for _, i := range indexes {
+			r := &oh.rules[i]
+			if len(r.timeRanges) > 0 && r.matchesSelectorWithOH(prevDay, oh.holidayChecker, oh) {
+				tr := r.timeRanges[0]   // Use first time range
 				if tr.end <= tr.start { // Midnight spanning
 					prevDayRule = r
 					prevDayEndTime = tr.end
@@ -437,18 +446,17 @@ func (oh *OpeningHours) checkExtendedMidnightContinuation(t time.Time) bool {
 			continue
 		}
 
-		// Find if there's a rule in the same group for the current day
-		// with a later end time
-		for i := range rules {
-			r := &rules[i]
-			if r.weekdays != nil && r.weekdays[weekday] && len(r.timeRanges) > 0 {
+		// Find if there's a midnight-spanning rule in the same group for the
+		// current day with a later end time
+		for _, i := range indexes {
+			r := &oh.rules[i]
+			if len(r.timeRanges) > 0 && r.matchesSelectorWithOH(t, oh.holidayChecker, oh) {
 				tr := r.timeRanges[0]
-				extendedEnd := tr.end
-				if tr.end <= tr.start { // Also midnight spanning
-					extendedEnd = tr.end
+				if tr.end > tr.start {
+					continue
 				}
 				// If current day's rule has a later end time and we're before it
-				if extendedEnd > prevDayEndTime && minuteOfDay < extendedEnd {
+				if tr.end > prevDayEndTime && minuteOfDay < tr.end {
 					return true
 				}
 			}
@@ -460,278 +468,151 @@ func (oh *OpeningHours) checkExtendedMidnightContinuation(t time.Time) bool {
 
 // GetUnknown returns true if state is unknown at the given time
 func (oh *OpeningHours) GetUnknown(t time.Time) bool {
-	for i := len(oh.rules) - 1; i >= 0; i-- {
-		r := oh.rules[i]
-		if r.matchesWithOH(t, oh.holidayChecker, oh) {
-			if r.state == StateUnknown {
-				// Primary is unknown
-				// If there are no fallback groups, return true (it's unknown)
-				if len(oh.fallbackGroups) == 0 {
-					return true
-				}
-				// If there are fallback groups, check if they can resolve it
-				return oh.getUnknownFromFallback(t)
-			}
-			return false
-		}
-	}
-	// No match in primary, check fallback groups
-	if len(oh.fallbackGroups) > 0 {
-		return oh.getUnknownFromFallback(t)
+	if oh.weekStates != nil {
+		return oh.weekStates.state(oh.inLocation(t)) == StateUnknown
 	}
-	return false
+	return oh.evaluate(oh.inLocation(t)).state == StateUnknown
 }
 
-// GetComment returns the comment for the given time, or empty string if no comment
+// GetComment returns the comment for the given time, or empty string if no comment.
+// PH and SH rules without a comment return the name of the holiday if the
+// checker knows it, see HolidayNamer and SchoolHolidayNamer.
 func (oh *OpeningHours) GetComment(t time.Time) string {
-	for i := len(oh.rules) - 1; i >= 0; i-- {
-		r := oh.rules[i]
-		if r.matchesWithOH(t, oh.holidayChecker, oh) {
-			return r.comment
+	return oh.evaluate(oh.inLocation(t)).comment
+}
+
+// ruleComment returns the comment of the matching rule r. SH rules without a
+// comment take the name of the school holiday period, see SchoolHolidayNamer.
+func (oh *OpeningHours) ruleComment(r *rule, t time.Time) string {
+	if r.comment == "" && r.state == StateOpen && oh.inOpenEnd(r, t) {
+		return openEndComment
+	}
+	if r.comment != "" {
+		return r.comment
+	}
+	if r.isPH && r.phOffset == 0 {
+		if name := oh.holidayName(t); name != "" {
+			return name
 		}
 	}
-	// No match in primary, check fallback groups
-	if len(oh.fallbackGroups) > 0 {
-		return oh.getCommentFromFallback(t)
+	if r.isSH {
+		return oh.schoolHolidayName(t)
 	}
 	return ""
 }
 
-// getCommentFromFallback returns comment from matching fallback rule
-func (oh *OpeningHours) getCommentFromFallback(t time.Time) string {
-	for _, fallbackGroup := range oh.fallbackGroups {
-		for i := len(fallbackGroup) - 1; i >= 0; i-- {
-			if fallbackGroup[i].matchesWithOH(t, oh.holidayChecker, oh) {
-				return fallbackGroup[i].comment
-			}
-		}
+// holidayName returns the name of the public holiday of t, if the holiday
+// checker is a HolidayNamer and t is a public holiday
+func (oh *OpeningHours) holidayName(t time.Time) string {
+	namer, ok := oh.holidayChecker.(HolidayNamer)
+	if !ok {
+		return ""
 	}
-	return ""
+	name, _ := namer.HolidayName(t)
+	return name
 }
 
-// GetMatchingRule returns the index of the rule that matches for the given time
-// Returns -1 if no rule matches
+// GetMatchingRule returns the index of the rule that decides the state at the
+// given time, which may be a rule of a fallback group. Returns -1 if no rule
+// matches. Rules()[i] describes the rule at index i.
 func (oh *OpeningHours) GetMatchingRule(t time.Time) int {
-	// Iterate through rules in reverse order (later rules have higher priority)
-	for i := len(oh.rules) - 1; i >= 0; i-- {
-		if oh.rules[i].matchesWithOH(t, oh.holidayChecker, oh) {
-			return i
-		}
-	}
-	return -1
+	return oh.ruleIndex(oh.evaluate(oh.inLocation(t)))
 }
 
-// GetOpenDuration returns total open and unknown duration between from and to
+// GetOpenDuration returns total open and unknown duration between from and to.
+// Week stable values are answered from a precomputed week in a few steps per day.
 func (oh *OpeningHours) GetOpenDuration(from, to time.Time) (openDuration, unknownDuration time.Duration) {
-	// Iterate through time in 1-minute increments and sum up open/unknown minutes
-	current := from
-
-	for current.Before(to) {
-		if oh.GetState(current) {
-			openDuration += time.Minute
-		} else if oh.GetUnknown(current) {
-			unknownDuration += time.Minute
+	if oh.weekStates != nil {
+		return oh.weekStates.duration(oh, oh.inLocation(from), oh.inLocation(to))
+	}
+	for _, iv := range oh.GetOpenIntervals(from, to) {
+		if iv.Unknown {
+			unknownDuration += iv.End.Sub(iv.Start)
+		} else {
+			openDuration += iv.End.Sub(iv.Start)
 		}
-
-		current = current.Add(time.Minute)
 	}
-
 	return openDuration, unknownDuration
 }
 
-// GetOpenIntervals returns all open/unknown intervals between from and to
+// GetOpenIntervals returns all open/unknown intervals between from and to.
+// Intervals are built from the time range boundaries of the rules on each day
+// (see changeTimes) instead of scanning minute by minute, evaluated with
+// GetStateInfo. Adjacent spans with the same unknown flag and comment are
+// merged; a change of comment alone starts a new interval.
 func (oh *OpeningHours) GetOpenIntervals(from, to time.Time) []Interval {
+	from, to = oh.inLocation(from), oh.inLocation(to)
 	if from.After(to) || from.Equal(to) {
 		return nil
 	}
 
 	var intervals []Interval
 
-	// Helper function to find the next state change
-	// This handles unknown states correctly, unlike GetNextChange
-	findNextChange := func(t time.Time) time.Time {
-		currentOpen := oh.GetState(t)
-		currentUnknown := oh.GetUnknown(t)
-		currentComment := oh.GetComment(t)
-
-		// Check if always open or always closed (no weekdays, no time ranges)
-		if len(oh.rules) == 1 && oh.rules[0].weekdays == nil && len(oh.rules[0].timeRanges) == 0 {
-			// No next change for 24/7, always closed, or always unknown
-			return time.Time{}
-		}
-
-		// Try GetNextChange first for better performance
-		// It works well for open/closed states, but may not handle unknown states correctly
-		nextChange := oh.GetNextChange(t)
-
-		// If we have a next change, verify it actually represents a state change
-		// This handles unknown states correctly
-		if !nextChange.IsZero() {
-			nextOpen := oh.GetState(nextChange)
-			nextUnknown := oh.GetUnknown(nextChange)
-			nextComment := oh.GetComment(nextChange)
-
-			// If state actually changed, use this time
-			if nextOpen != currentOpen || nextUnknown != currentUnknown || nextComment != currentComment {
-				return nextChange
-			}
+	starts := append([]time.Time{from}, oh.changeTimes(from, to)...)
+	for i, start := range starts {
+		end := to
+		if i+1 < len(starts) {
+			end = starts[i+1]
 		}
 
-		// Fallback: Search minute by minute for state changes
-		// This is slower but handles all cases including unknown states
-		// Search up to 35 days for constrained weekdays like "4th Wednesday"
-		checkTime := t.Add(time.Minute)
-		endTime := t.Add(35 * 24 * time.Hour)
-
-		for checkTime.Before(endTime) {
-			nextOpen := oh.GetState(checkTime)
-			nextUnknown := oh.GetUnknown(checkTime)
-			nextComment := oh.GetComment(checkTime)
-
-			// State changed if any of these changed
-			if nextOpen != currentOpen || nextUnknown != currentUnknown || nextComment != currentComment {
-				return checkTime
-			}
-
-			checkTime = checkTime.Add(time.Minute)
+		info := oh.GetStateInfo(start)
+		if info.State == StateClosed {
+			continue
 		}
+		isUnknown, comment := info.Unknown, info.Comment
 
-		// No change found within 7 days
-		return time.Time{}
-	}
-
-	current := from
-
-	for current.Before(to) {
-		isOpen := oh.GetState(current)
-		isUnknown := oh.GetUnknown(current)
-		comment := oh.GetComment(current)
-
-		if isOpen || isUnknown {
-			// Start of an open/unknown interval
-			intervalStart := current
-
-			// Find when this interval ends
-			nextChange := findNextChange(current)
-
-			var intervalEnd time.Time
-			if nextChange.IsZero() {
-				// No next change (e.g., 24/7) - interval goes to 'to'
-				intervalEnd = to
-			} else if nextChange.After(to) {
-				// Next change is beyond our range
-				intervalEnd = to
-			} else {
-				intervalEnd = nextChange
-			}
-
-			intervals = append(intervals, Interval{
-				Start:   intervalStart,
-				End:     intervalEnd,
-				Unknown: isUnknown,
-				Comment: comment,
-			})
-
-			current = intervalEnd
-		} else {
-			// Currently closed, find next opening
-			nextChange := findNextChange(current)
-
-			if nextChange.IsZero() || nextChange.After(to) {
-				// No more changes or beyond our range
-				break
-			}
-
-			current = nextChange
+		// A comment that changes within an open span, e.g. from a rule that only
+		// overlaps part of it, splits the span
+		if n := len(intervals); n > 0 && intervals[n-1].End.Equal(start) &&
+			intervals[n-1].Unknown == isUnknown && intervals[n-1].Comment == comment && !intervals[n-1].Point && !info.Point {
+			intervals[n-1].End = end
+			continue
 		}
+
+		intervals = append(intervals, Interval{
+			Start:   start,
+			End:     end,
+			State:   info.State,
+			Unknown: isUnknown,
+			Comment: comment,
+			Point:   info.Point,
+		})
 	}
 
 	return intervals
 }
 
 // IsEqualTo compares two OpeningHours objects for semantic equality.
-// Two OpeningHours are considered equal if they produce the same state
-// at all times over a representative period.
+// Two OpeningHours are considered equal if they have the same state, unknown
+// flag and comment at all times of the week starting Monday, January 1, 2024,
+// compared exactly at every change time (see IsEqualToWithin). This is exact
+// for week stable values; values with month, date, holiday or week number
+// selectors may differ outside this week, compare them with IsEqualToWithin.
 func (oh *OpeningHours) IsEqualTo(other *OpeningHours) bool {
-	if other == nil {
-		return false
-	}
-
-	// We sample states over 1 week at 15-minute intervals
-	// to check for semantic equality. This covers most patterns.
-	// For week-stable values, 1 week is sufficient.
-	// For non-week-stable values (holidays, specific dates), we need longer periods.
-
-	// Start from a reference date that ensures we cover all weekdays
 	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Monday
-
-	// Sample for 1 week at 15-minute intervals for week-stable comparison
-	sampleDuration := 7 * 24 * time.Hour
-	sampleInterval := 15 * time.Minute
-
-	current := start
-	end := start.Add(sampleDuration)
-
-	for current.Before(end) {
-		state1 := oh.GetState(current)
-		state2 := other.GetState(current)
-		if state1 != state2 {
-			return false
-		}
-
-		unknown1 := oh.GetUnknown(current)
-		unknown2 := other.GetUnknown(current)
-		if unknown1 != unknown2 {
-			return false
-		}
-
-		comment1 := oh.GetComment(current)
-		comment2 := other.GetComment(current)
-		if comment1 != comment2 {
-			return false
-		}
-
-		current = current.Add(sampleInterval)
-	}
-
-	return true
+	equal, _ := oh.IsEqualToWithin(other, start, start.AddDate(0, 0, 7), 0)
+	return equal
 }
 
-// GetStateString returns "open", "closed", or "unknown" for the given time
+// GetStateString returns "open", "closed", or "unknown" for the given time.
+//
+// Deprecated: Use StateAt, whose State can be compared with the State
+// constants and formats like this string.
 func (oh *OpeningHours) GetStateString(t time.Time) string {
-	for i := len(oh.rules) - 1; i >= 0; i-- {
-		r := oh.rules[i]
-		if r.matchesWithOH(t, oh.holidayChecker, oh) {
-			if r.state == StateUnknown {
-				// Primary is unknown, check fallback groups
-				return oh.getStateStringFromFallback(t)
-			}
-			switch r.state {
-			case StateOpen:
-				return "open"
-			case StateClosed:
-				return "closed"
-			}
-		}
-	}
-	// No match in primary, return closed (don't check fallback)
-	return "closed"
+	return oh.stateAt(t).String()
 }
 
 // IsWeekStable returns true if the opening hours follow a stable weekly pattern
 // (same hours repeat every week without variations like months, years, dates, holidays, or week numbers)
 func (oh *OpeningHours) IsWeekStable() bool {
 	// Check all rules (including fallback groups)
-	allRules := oh.rules
-	for _, fg := range oh.fallbackGroups {
-		allRules = append(allRules, fg...)
-	}
+	allRules := slices.Concat(append([][]rule{oh.rules}, oh.fallbackGroups...)...)
 
 	for _, r := range allRules {
 		// If rule has month constraints (except full year Jan-Dec), not stable
 		if r.monthStart > 0 {
 			// Jan-Dec (1-12) covers full year, so it's still week stable
-			if !(r.monthStart == 1 && r.monthEnd == 12) {
+			if !(r.monthStart == 1 && r.monthEnd == 12 && r.dayStart == 0) {
 				return false
 			}
 		}
@@ -770,126 +651,68 @@ func (oh *OpeningHours) IsWeekStable() bool {
 	return true
 }
 
-// GetNextChange returns the next time the opening state changes
-func (oh *OpeningHours) GetNextChange(t time.Time) time.Time {
-	currentState := oh.GetState(t)
+// wallClockTime returns the first instant on the day of day whose wall clock is
+// minute minutes after midnight. When clocks are set back (DST end), the wall
+// clock times in the repeated period occur twice and the earlier one is used;
+// when clocks skip ahead, time.Date's normalization is used.
+func wallClockTime(day time.Time, minute int) time.Time {
+	t := time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
 
-	// Check if always open or always closed (no weekdays, no time ranges)
-	if len(oh.rules) == 1 && oh.rules[0].weekdays == nil && len(oh.rules[0].timeRanges) == 0 {
+	_, offset := t.Zone()
+	_, earlierOffset := t.Add(-12 * time.Hour).Zone()
+	if earlierOffset > offset {
+		earlier := t.Add(-time.Duration(earlierOffset-offset) * time.Second)
+		if earlier.Day() == t.Day() && earlier.Hour() == t.Hour() && earlier.Minute() == t.Minute() {
+			return earlier
+		}
+	}
+	return t
+}
+
+// GetNextChange returns the next time the opening state (open, closed or unknown) changes.
+// Changes up to a year ahead are found, so that date, week, holiday and Easter
+// rules like "Dec 24-26 10:00-18:00" are covered. Returns zero time if there is none.
+func (oh *OpeningHours) GetNextChange(t time.Time) time.Time {
+	t = oh.inLocation(t)
+	if oh.isConstant() {
 		// No next change for 24/7 or always closed
 		return time.Time{}
 	}
 
-	// Search for next change up to 35 days ahead
-	// (needed for constrained weekdays like "4th Wednesday" which may be ~30 days away)
-	searchTime := t
-	for day := 0; day < 36; day++ {
-		// For the first day, start from current time; for other days, start from midnight
-		var startMinute int
-		if day == 0 {
-			startMinute = searchTime.Hour()*60 + searchTime.Minute()
-		} else {
-			startMinute = 0
-			searchTime = time.Date(searchTime.Year(), searchTime.Month(), searchTime.Day()+1, 0, 0, 0, 0, searchTime.Location())
-		}
-
-		// Collect all transition times for this day
-		transitions := make(map[int]bool) // minute -> has transition
-		weekday := int(searchTime.Weekday())
-		prevWeekday := (weekday + 6) % 7
-
-		for _, r := range oh.rules {
-			// Check if rule applies to this weekday for start times
-			if r.weekdays != nil && r.weekdays[weekday] {
-				// Add all time range boundaries for this day
-				for _, tr := range r.timeRanges {
-					// Resolve variable times for this specific day
-					trStart := tr.start
-					trEnd := tr.end
-					if tr.startVar != "" {
-						trStart = oh.resolveVariableTime(searchTime, tr.startVar, tr.startOffset)
-					}
-					if tr.endVar != "" {
-						trEnd = oh.resolveVariableTime(searchTime, tr.endVar, tr.endOffset)
-					}
-
-					if trStart > startMinute || day > 0 {
-						transitions[trStart] = true
-					}
-					// For midnight-spanning (end <= start), don't add end on same day
-					if trEnd > trStart {
-						if trEnd > startMinute || day > 0 {
-							transitions[trEnd] = true
-						}
-					}
-				}
-			}
-
-			// Check if PREVIOUS day had a midnight-spanning rule that ends today
-			if r.weekdays != nil && r.weekdays[prevWeekday] {
-				for _, tr := range r.timeRanges {
-					trEnd := tr.end
-					if tr.endVar != "" {
-						trEnd = oh.resolveVariableTime(searchTime, tr.endVar, tr.endOffset)
-					}
-					// If end <= start, it spans midnight and ends on TODAY
-					if trEnd <= tr.start {
-						if trEnd > startMinute || day > 0 {
-							transitions[trEnd] = true
-						}
-					}
-				}
-			}
-
-			// Handle rules without weekday constraints
-			if r.weekdays == nil {
-				for _, tr := range r.timeRanges {
-					trStart := tr.start
-					trEnd := tr.end
-					if tr.startVar != "" {
-						trStart = oh.resolveVariableTime(searchTime, tr.startVar, tr.startOffset)
-					}
-					if tr.endVar != "" {
-						trEnd = oh.resolveVariableTime(searchTime, tr.endVar, tr.endOffset)
-					}
-
-					if trStart > startMinute || day > 0 {
-						transitions[trStart] = true
-					}
-					if trEnd > startMinute || day > 0 {
-						transitions[trEnd] = true
-					}
-				}
-			}
-		}
+	next := oh.nextStateChange(t, t.Add(iteratorSearchLimit))
+	if next.IsZero() {
+		incMetric(CounterSlowPath)
+	}
+	return next
+}
 
-		// Sort transitions and find first one where state changes
-		sortedTimes := make([]int, 0, len(transitions))
-		for minute := range transitions {
-			sortedTimes = append(sortedTimes, minute)
-		}
+// isConstant reports whether the state never changes, e.g. for "24/7" or "off"
+func (oh *OpeningHours) isConstant() bool {
+	return len(oh.rules) == 1 && len(oh.fallbackGroups) == 0 &&
+		!oh.rules[0].hasSelectors() && len(oh.rules[0].timeRanges) == 0
+}
 
-		// Simple bubble sort for small arrays
-		for i := 0; i < len(sortedTimes); i++ {
-			for j := i + 1; j < len(sortedTimes); j++ {
-				if sortedTimes[i] > sortedTimes[j] {
-					sortedTimes[i], sortedTimes[j] = sortedTimes[j], sortedTimes[i]
-				}
-			}
+// nextStateChange returns the first time in (t, limit] at which the state
+// string differs from the state at t, or zero time if there is none. Every
+// rule is matched with all of its selectors at the candidate times returned
+// by changeTimes, searching iteratorWindowDays days at a time.
+func (oh *OpeningHours) nextStateChange(t, limit time.Time) time.Time {
+	current := oh.stateAt(t)
+	start := t
+	for start.Before(limit) {
+		end := time.Date(start.Year(), start.Month(), start.Day()+iteratorWindowDays, 0, 0, 0, 0, start.Location())
+		if end.After(limit) {
+			end = limit
 		}
 
-		for _, minute := range sortedTimes {
-			checkTime := time.Date(searchTime.Year(), searchTime.Month(), searchTime.Day(),
-				minute/60, minute%60, 0, 0, searchTime.Location())
-
-			// Check if state is different at this time
-			if oh.GetState(checkTime) != currentState {
-				return checkTime
+		// end is a midnight or the limit, so it has to be checked as well
+		for _, c := range append(oh.changeTimes(start, end), end) {
+			if oh.stateAt(c) != current {
+				return c
 			}
 		}
+		start = end
 	}
-
-	// No change found within 35 days
 	return time.Time{}
 }
 
@@ -897,218 +720,177 @@ func (oh *OpeningHours) GetNextChange(t time.Time) time.Time {
 // but only if it occurs before or at maxdate.
 // If no change is found before maxdate, returns zero time.
 func (oh *OpeningHours) GetNextChangeWithMaxDate(t time.Time, maxdate time.Time) time.Time {
-	currentState := oh.GetState(t)
-
-	// Check if always open or always closed (no weekdays, no time ranges)
-	if len(oh.rules) == 1 && oh.rules[0].weekdays == nil && len(oh.rules[0].timeRanges) == 0 {
+	t = oh.inLocation(t)
+	if oh.isConstant() {
 		// No next change for 24/7 or always closed
 		return time.Time{}
 	}
 
-	// Calculate days to search (limited by maxdate)
-	maxDays := int(maxdate.Sub(t).Hours()/24) + 1
-	if maxDays > 365 {
-		maxDays = 365 // Safety limit
+	// Safety limit
+	limit := oh.inLocation(maxdate)
+	if bound := t.Add(365 * 24 * time.Hour); limit.After(bound) {
+		limit = bound
 	}
+	return oh.nextStateChange(t, limit)
+}
 
-	searchTime := t
-	for day := 0; day < maxDays+1; day++ {
-		// For the first day, start from current time; for other days, start from midnight
-		var startMinute int
-		if day == 0 {
-			startMinute = searchTime.Hour()*60 + searchTime.Minute()
-		} else {
-			startMinute = 0
-			searchTime = time.Date(searchTime.Year(), searchTime.Month(), searchTime.Day()+1, 0, 0, 0, 0, searchTime.Location())
-		}
-
-		// Stop if we've passed maxdate
-		if searchTime.After(maxdate) && day > 0 {
-			break
-		}
-
-		// Collect all transition times for this day
-		transitions := make(map[int]bool) // minute -> has transition
-		weekday := int(searchTime.Weekday())
-		prevWeekday := (weekday + 6) % 7
-
-		for _, r := range oh.rules {
-			// Check if rule applies to this weekday for start times
-			if r.weekdays != nil && r.weekdays[weekday] {
-				// Add all time range boundaries for this day
-				for _, tr := range r.timeRanges {
-					// Resolve variable times for this specific day
-					trStart := tr.start
-					trEnd := tr.end
-					if tr.startVar != "" {
-						trStart = oh.resolveVariableTime(searchTime, tr.startVar, tr.startOffset)
-					}
-					if tr.endVar != "" {
-						trEnd = oh.resolveVariableTime(searchTime, tr.endVar, tr.endOffset)
-					}
-
-					if trStart > startMinute || day > 0 {
-						transitions[trStart] = true
-					}
-					// For midnight-spanning (end <= start), don't add end on same day
-					if trEnd > trStart {
-						if trEnd > startMinute || day > 0 {
-							transitions[trEnd] = true
-						}
-					}
-				}
-			}
-
-			// Check if PREVIOUS day had a midnight-spanning rule that ends today
-			if r.weekdays != nil && r.weekdays[prevWeekday] {
-				for _, tr := range r.timeRanges {
-					trEnd := tr.end
-					if tr.endVar != "" {
-						trEnd = oh.resolveVariableTime(searchTime, tr.endVar, tr.endOffset)
-					}
-					// If end <= start, it spans midnight and ends on TODAY
-					if trEnd <= tr.start {
-						if trEnd > startMinute || day > 0 {
-							transitions[trEnd] = true
-						}
-					}
-				}
-			}
-
-			// Handle rules without weekday constraints
-			if r.weekdays == nil {
-				for _, tr := range r.timeRanges {
-					trStart := tr.start
-					trEnd := tr.end
-					if tr.startVar != "" {
-						trStart = oh.resolveVariableTime(searchTime, tr.startVar, tr.startOffset)
-					}
-					if tr.endVar != "" {
-						trEnd = oh.resolveVariableTime(searchTime, tr.endVar, tr.endOffset)
-					}
-
-					if trStart > startMinute || day > 0 {
-						transitions[trStart] = true
-					}
-					if trEnd > startMinute || day > 0 {
-						transitions[trEnd] = true
-					}
-				}
-			}
-		}
+// nthWeekdayOfMonth returns which occurrence (1-indexed) of the weekday this date is in its month
+// e.g., if t is the 3rd Monday of the month, returns 3
+func nthWeekdayOfMonth(t time.Time) int {
+	day := t.Day()
+	return (day-1)/7 + 1
+}
 
-		// Sort transitions and find first one where state changes
-		sortedTimes := make([]int, 0, len(transitions))
-		for minute := range transitions {
-			sortedTimes = append(sortedTimes, minute)
-		}
+// nthWeekdayFromEnd returns which occurrence from the end (1-indexed) the date is
+// e.g., if t is the last Friday of the month, returns 1
+func nthWeekdayFromEnd(t time.Time) int {
+	// Get the last day of the month. Day 0 of the next month is normalized to
+	// it, where subtracting 24 hours would end up a day early after the clocks
+	// are set forward.
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	return (lastDay-t.Day())/7 + 1
+}
 
-		// Simple bubble sort for small arrays
-		for i := 0; i < len(sortedTimes); i++ {
-			for j := i + 1; j < len(sortedTimes); j++ {
-				if sortedTimes[i] > sortedTimes[j] {
-					sortedTimes[i], sortedTimes[j] = sortedTimes[j], sortedTimes[i]
-				}
-			}
+// matchesWeekdayConstraints checks if t is selected by any of the rule's
+// constrained weekdays like "Sa[-1]" or "Sa[-1] +1 day"
+func (r *rule) matchesWeekdayConstraints(t time.Time) bool {
+	for _, c := range r.weekdayConstraints {
+		if c.matches(t) {
+			return true
 		}
+	}
+	return false
+}
 
-		for _, minute := range sortedTimes {
-			checkTime := time.Date(searchTime.Year(), searchTime.Month(), searchTime.Day(),
-				minute/60, minute%60, 0, 0, searchTime.Location())
-
-			// Check if we've exceeded maxdate
-			if checkTime.After(maxdate) {
-				return time.Time{}
-			}
-
-			// Check if state is different at this time
-			if oh.GetState(checkTime) != currentState {
-				return checkTime
-			}
-		}
+// matches checks if t is the nth weekday of c in its month, or the day c.offset
+// days after it
+func (c weekdayConstraint) matches(t time.Time) bool {
+	t = t.AddDate(0, 0, -c.offset)
+	if int(t.Weekday()) != c.weekday {
+		return false
 	}
 
-	// No change found within maxdate range
-	return time.Time{}
+	nthFromStart := nthWeekdayOfMonth(t)
+	nthFromEnd := nthWeekdayFromEnd(t)
+	switch {
+	case c.nthFrom > 0 && c.nthTo == 0:
+		// Single value like [1]
+		return nthFromStart == c.nthFrom
+	case c.nthFrom > 0:
+		// Range like [1-2]
+		return nthFromStart >= c.nthFrom && nthFromStart <= c.nthTo
+	case c.nthTo == 0:
+		// Single value from the end like [-1]
+		return nthFromEnd == -c.nthFrom
+	}
+	// Range from the end like [-2--1]
+	return nthFromEnd >= -c.nthTo && nthFromEnd <= -c.nthFrom
 }
 
-// getStateFromFallback checks fallback groups and returns the state
-// Returns the state from the first fallback group that doesn't return unknown
-func (oh *OpeningHours) getStateFromFallback(t time.Time) bool {
-	for _, fallbackGroup := range oh.fallbackGroups {
-		for i := len(fallbackGroup) - 1; i >= 0; i-- {
-			if fallbackGroup[i].matchesWithOH(t, oh.holidayChecker, oh) {
-				if fallbackGroup[i].state == StateUnknown {
-					// This fallback is also unknown, try next fallback group
-					break
-				}
-				return fallbackGroup[i].state == StateOpen
-			}
+// matchesYear checks the rule's year selector (e.g., "2024", "2020-2030/2", "2020+")
+func (r *rule) matchesYear(t time.Time) bool {
+	if r.yearStart == 0 {
+		return true
+	}
+	// A full date range covers every day between its start and end date,
+	// including whole years in between
+	if r.dateStart > 0 {
+		date := t.Year()*10000 + int(t.Month())*100 + t.Day()
+		return date >= r.dateStart && date <= r.dateEnd
+	}
+	year := t.Year()
+	if year < r.yearStart || year > r.yearEnd {
+		return false
+	}
+	// Check year interval if specified (e.g., 2020-2030/2 means every other year)
+	if r.yearInterval > 0 {
+		yearOffset := year - r.yearStart
+		if yearOffset%r.yearInterval != 0 {
+			return false
 		}
 	}
-	return false
+	return true
 }
 
-// getUnknownFromFallback checks fallback groups and returns if state is unknown
-// Returns true only if all checked fallback groups also return unknown
-func (oh *OpeningHours) getUnknownFromFallback(t time.Time) bool {
-	for _, fallbackGroup := range oh.fallbackGroups {
-		for i := len(fallbackGroup) - 1; i >= 0; i-- {
-			if fallbackGroup[i].matchesWithOH(t, oh.holidayChecker, oh) {
-				if fallbackGroup[i].state == StateUnknown {
-					// This fallback is also unknown, try next fallback group
-					break
-				}
-				// Found a definite state (open or closed), so not unknown
-				return false
-			}
+// easterRange returns the first and last day (midnight UTC) of the rule's
+// Easter range starting in year. A fixed start after any date Easter can fall
+// on, like "Oct 01-easter", ends at Easter of the next year. Otherwise a range
+// like "Apr 01-easter" stays in year, and is empty in years when Easter comes
+// before its fixed start.
+func (r *rule) easterRange(year int) (time.Time, time.Time) {
+	endpoint := func(year, date, offset int) time.Time {
+		if date > 0 {
+			return time.Date(year, time.Month(date/100), date%100, 0, 0, 0, 0, time.UTC)
 		}
+		easter := r.easterDate(year)
+		return time.Date(year, easter.Month(), easter.Day()+offset, 0, 0, 0, 0, time.UTC)
 	}
-	// All fallback groups returned unknown or no match
-	return false
+	start := endpoint(year, r.easterStartDate, r.easterOffset)
+	end := endpoint(year, r.easterEndDate, r.easterOffsetEnd)
+	if r.easterWraps {
+		end = endpoint(year+1, r.easterEndDate, r.easterOffsetEnd)
+	}
+	return start, end
 }
 
-// getStateStringFromFallback checks fallback groups and returns the state string
-func (oh *OpeningHours) getStateStringFromFallback(t time.Time) string {
-	for _, fallbackGroup := range oh.fallbackGroups {
-		for i := len(fallbackGroup) - 1; i >= 0; i-- {
-			if fallbackGroup[i].matchesWithOH(t, oh.holidayChecker, oh) {
-				if fallbackGroup[i].state == StateUnknown {
-					// This fallback is also unknown, try next fallback group
-					break
-				}
-				switch fallbackGroup[i].state {
-				case StateOpen:
-					return "open"
-				case StateClosed:
-					return "closed"
-				}
-			}
-		}
+// movableDateWindow returns the earliest and latest month and day (mmdd) of
+// the rule's movable date plus offset days, over a century of years
+func (r *rule) movableDateWindow(offset int) (earliest, latest int) {
+	earliest, latest = 1231, 101
+	for year := 2000; year < 2100; year++ {
+		d := r.easterDate(year).AddDate(0, 0, offset)
+		mmdd := int(d.Month())*100 + d.Day()
+		earliest, latest = min(earliest, mmdd), max(latest, mmdd)
 	}
-	return "closed"
+	return earliest, latest
 }
 
-// nthWeekdayOfMonth returns which occurrence (1-indexed) of the weekday this date is in its month
-// e.g., if t is the 3rd Monday of the month, returns 3
-func nthWeekdayOfMonth(t time.Time) int {
-	day := t.Day()
-	return (day-1)/7 + 1
+// inEasterRange checks if the day of t is within the rule's Easter range
+func (r *rule) inEasterRange(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// Ranges may start in the previous year ("Oct 01-easter", "easter -100 days-easter")
+	for _, year := range []int{t.Year() - 1, t.Year(), t.Year() + 1} {
+		start, end := r.easterRange(year)
+		if !day.Before(start) && !day.After(end) {
+			return true
+		}
+	}
+	return false
 }
 
-// nthWeekdayFromEnd returns which occurrence from the end (1-indexed) the date is
-// e.g., if t is the last Friday of the month, returns 1
-func nthWeekdayFromEnd(t time.Time) int {
-	// Get the last day of the month
-	nextMonth := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
-	lastDay := nextMonth.Add(-24 * time.Hour).Day()
-	return (lastDay-t.Day())/7 + 1
+// selectorDate returns the date that the rule's year and month/day selectors
+// are checked against on the day of t, e.g. Dec 25 on Dec 28 for "Dec 25 +3 days",
+// or Sunday, Oct 31 on Monday, Nov 1 for "Oct Su[-1] +1 day"
+func (r *rule) selectorDate(t time.Time) time.Time {
+	offset := r.dateOffset
+	if len(r.weekdayConstraints) > 0 {
+		// The offset applies to all constraints, see parseWeekdaysAndTimeWithConstraintsAndHolidays
+		offset += r.weekdayConstraints[0].offset
+	}
+	if offset == 0 {
+		return t
+	}
+	return t.AddDate(0, 0, -offset)
 }
 
 func (r *rule) matches(t time.Time, hc HolidayChecker) bool {
 	return r.matchesWithOH(t, hc, nil)
 }
 
+// matchesPublicHoliday checks the PH selector of r: whether the day of t is a
+// public holiday, or phOffset days from one. Offset rules don't match on the
+// holiday itself.
+func (r *rule) matchesPublicHoliday(t time.Time, hc HolidayChecker) bool {
+	if hc == nil {
+		return false
+	}
+	if r.phOffset == 0 {
+		return hc.IsHoliday(t)
+	}
+	// To check if today is N days after a holiday, we check if (today - N days) is a holiday
+	return hc.IsHoliday(t.AddDate(0, 0, -r.phOffset)) && !hc.IsHoliday(t)
+}
+
 // isOffsetHolidayDay checks if the given day is N days away from a holiday
 // This is used to prevent regular rules from matching on days that should be handled by PH offset rules
 func isOffsetHolidayDay(t time.Time, hc HolidayChecker, rules []rule) bool {
@@ -1117,11 +899,25 @@ func isOffsetHolidayDay(t time.Time, hc HolidayChecker, rules []rule) bool {
 	}
 
 	// Check if any PH offset rule would apply to this day
+	// Holiday sets usually differ per year, so the checker is queried for the
+	// actual adjacent date (which may lie in the previous or next year)
 	for _, r := range rules {
-		if r.isPH && r.phOffset != 0 {
-			// Check if this day is r.phOffset days after a holiday
-			checkDate := t.AddDate(0, 0, -r.phOffset)
-			if hc.IsHoliday(checkDate) && !hc.IsHoliday(t) {
+		// The other selectors must match as well, like the weekdays of "PH +1 day Mo-Fr"
+		if r.isPH && r.phOffset != 0 && r.matchesPublicHoliday(t, hc) && r.couldApplyOnPublicHoliday(t, nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHolidayRuleFor checks if any PH rule (without offset) could apply to the given day.
+// A rule like "2024 PH off" only takes over holidays in 2024 and "PH Mo-Fr off"
+// only holidays on weekdays, so regular rules still apply to the other holidays.
+func (oh *OpeningHours) hasHolidayRuleFor(t time.Time) bool {
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			if r.isPH && r.phOffset == 0 && r.couldApplyOnPublicHoliday(t, oh) {
 				return true
 			}
 		}
@@ -1129,10 +925,64 @@ func isOffsetHolidayDay(t time.Time, hc HolidayChecker, rules []rule) bool {
 	return false
 }
 
+// matchesAfterMidnight checks if t is in the part after midnight of a time
+// range spanning midnight that starts on the previous day. The range belongs
+// to the day it starts on, so all selectors are matched on that day: the
+// range of "PH 20:00-02:00" or "Dec 24 20:00-02:00" extends into the next day
+// whatever that day is, and the Friday range of "Nov-Feb Fr 20:00-03:00"
+// extends into Saturday, March 1.
+func (r *rule) matchesAfterMidnight(t time.Time, hc HolidayChecker, oh *OpeningHours) bool {
+	minuteOfDay := t.Hour()*60 + t.Minute()
+	prevDay := t.AddDate(0, 0, -1)
+	for _, tr := range r.timeRanges {
+		start, end := tr.start, tr.end
+		if tr.startVar != "" && oh != nil {
+			start = oh.resolveVariableTime(prevDay, tr.startVar, tr.startOffset)
+		}
+		if tr.endVar != "" && oh != nil {
+			end = oh.resolveVariableTime(prevDay, tr.endVar, tr.endOffset)
+		}
+		if end > 24*60 {
+			end -= 24 * 60
+		} else if end > start {
+			continue
+		}
+		// The range ends on the day of t, when that day's event happens
+		if tr.endVar != "" && oh != nil {
+			end = oh.resolveVariableTime(t, tr.endVar, tr.endOffset)
+		}
+		if start >= 24*60 {
+			continue
+		}
+		if tr.interval > 0 {
+			if !oh.atPeriodicPoint(tr, minuteOfDay+24*60-start, end+24*60-start) {
+				continue
+			}
+		} else if minuteOfDay >= end {
+			continue
+		}
+		// The range is open at its start on the previous day if the selectors match then
+		if r.matchesOnDay(time.Date(prevDay.Year(), prevDay.Month(), prevDay.Day(), start/60, start%60, 0, 0, t.Location()), hc, oh) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesSelectorWithOH checks if the rule's selector (weekday, date, holiday, etc.)
 // matches the given time, WITHOUT checking time ranges.
 // This is used to determine if a later rule "owns" a day even if outside its time ranges.
 func (r *rule) matchesSelectorWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) bool {
+	if r.holidayUnion {
+		parts, n := r.holidayUnionParts()
+		for i := 0; i < n; i++ {
+			if parts[i].matchesSelectorWithOH(t, hc, oh) {
+				return true
+			}
+		}
+		return false
+	}
+
 	// Rules without weekday constraints (time-only rules like "10:00-18:00") don't own any day
 	if r.weekdays == nil && !r.isPH && !r.isSH && !r.isEaster &&
 		r.monthStart == 0 && r.yearStart == 0 && len(r.weekConstraints) == 0 &&
@@ -1141,30 +991,15 @@ func (r *rule) matchesSelectorWithOH(t time.Time, hc HolidayChecker, oh *Opening
 	}
 
 	// Check year constraints
-	if r.yearStart > 0 {
-		year := t.Year()
-		if year < r.yearStart || year > r.yearEnd {
-			return false
-		}
-		// Check year interval if specified (e.g., 2020-2030/2 means every other year)
-		if r.yearInterval > 0 {
-			yearOffset := year - r.yearStart
-			if yearOffset%r.yearInterval != 0 {
-				return false
-			}
-		}
+	if !r.matchesYear(r.selectorDate(t)) {
+		return false
 	}
 
 	// Check Easter rules
 	if r.isEaster {
-		easterDate := calculateEaster(t.Year())
+		easterDate := r.easterDate(t.Year())
 		if r.isEasterRange {
-			startDate := easterDate.AddDate(0, 0, r.easterOffset)
-			endDate := easterDate.AddDate(0, 0, r.easterOffsetEnd)
-			tDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
-			startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
-			endDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
-			if tDay.Before(startDay) || tDay.After(endDay) {
+			if !r.inEasterRange(t) {
 				return false
 			}
 		} else {
@@ -1176,150 +1011,31 @@ func (r *rule) matchesSelectorWithOH(t time.Time, hc HolidayChecker, oh *Opening
 		return true // Easter selector matches
 	}
 
-	// Check school holidays
-	if r.isSH {
-		// Need to check if it's actually a school holiday
-		if oh != nil && oh.schoolHolidayChecker != nil && oh.schoolHolidayChecker.IsSchoolHoliday(t) {
-			return true
-		}
+	// Check school holidays. The other selectors must match as well, like the
+	// weekdays of "SH Mo-Fr".
+	if r.isSH && (oh == nil || oh.schoolHolidayChecker == nil || !oh.schoolHolidayChecker.IsSchoolHoliday(t)) {
 		return false
 	}
 
-	// Check public holidays
-	if r.isPH {
-		if hc != nil && hc.IsHoliday(t) {
-			return true
-		}
+	// Check public holidays, or the days around them like "PH +1 day". The
+	// other selectors must match as well, like the weekdays of "PH Mo-Fr".
+	if r.isPH && !r.matchesPublicHoliday(t, hc) {
 		return false
 	}
 
 	// Check month/day constraints
-	if r.monthStart > 0 {
-		month := int(t.Month())
-		day := t.Day()
-
-		if r.dayStart > 0 {
-			inRange := false
-			if r.monthStart == r.monthEnd {
-				if month == r.monthStart && day >= r.dayStart && day <= r.dayEnd {
-					// Check day interval if specified (e.g., Jan 01-31/8 means every 8th day)
-					if r.dayInterval > 0 {
-						dayOffset := day - r.dayStart
-						if dayOffset%r.dayInterval == 0 {
-							inRange = true
-						}
-					} else {
-						inRange = true
-					}
-				}
-			} else if r.monthStart < r.monthEnd {
-				if month > r.monthStart && month < r.monthEnd {
-					inRange = true
-				} else if month == r.monthStart && day >= r.dayStart {
-					inRange = true
-				} else if month == r.monthEnd && day <= r.dayEnd {
-					inRange = true
-				}
-			} else {
-				if month > r.monthStart || month < r.monthEnd {
-					inRange = true
-				} else if month == r.monthStart && day >= r.dayStart {
-					inRange = true
-				} else if month == r.monthEnd && day <= r.dayEnd {
-					inRange = true
-				}
-			}
-			if !inRange {
-				return false
-			}
-		} else {
-			inRange := false
-			if r.monthStart == r.monthEnd {
-				if month == r.monthStart {
-					inRange = true
-				}
-			} else if r.monthStart < r.monthEnd {
-				if month >= r.monthStart && month <= r.monthEnd {
-					inRange = true
-				}
-			} else {
-				if month >= r.monthStart || month <= r.monthEnd {
-					inRange = true
-				}
-			}
-			if !inRange {
-				return false
-			}
-		}
+	if r.monthStart > 0 && !r.matchesMonthDay(r.selectorDate(t)) {
+		return false
 	}
 
 	// Check week number constraints
-	if len(r.weekConstraints) > 0 {
-		_, week := t.ISOWeek()
-		inRange := false
-		for _, wc := range r.weekConstraints {
-			if wc.weekStart == wc.weekEnd {
-				if week == wc.weekStart {
-					inRange = true
-					break
-				}
-			} else {
-				if week >= wc.weekStart && week <= wc.weekEnd {
-					if wc.weekInterval > 0 {
-						weekOffset := week - wc.weekStart
-						if weekOffset%wc.weekInterval == 0 {
-							inRange = true
-							break
-						}
-					} else {
-						inRange = true
-						break
-					}
-				}
-			}
-		}
-		if !inRange {
-			return false
-		}
+	if len(r.weekConstraints) > 0 && !r.matchesWeeks(t) {
+		return false
 	}
 
 	// Check weekday constraints
 	if len(r.weekdayConstraints) > 0 {
-		weekday := int(t.Weekday())
-		constraintMatched := false
-		for _, constraint := range r.weekdayConstraints {
-			if constraint.weekday != weekday {
-				continue
-			}
-			nthFromStart := nthWeekdayOfMonth(t)
-			nthFromEnd := nthWeekdayFromEnd(t)
-			if constraint.nthFrom > 0 {
-				if constraint.nthTo == 0 {
-					if nthFromStart == constraint.nthFrom {
-						constraintMatched = true
-						break
-					}
-				} else {
-					if nthFromStart >= constraint.nthFrom && nthFromStart <= constraint.nthTo {
-						constraintMatched = true
-						break
-					}
-				}
-			} else {
-				if constraint.nthTo == 0 {
-					if nthFromEnd == -constraint.nthFrom {
-						constraintMatched = true
-						break
-					}
-				} else {
-					if nthFromEnd >= -constraint.nthTo && nthFromEnd <= -constraint.nthFrom {
-						constraintMatched = true
-						break
-					}
-				}
-			}
-		}
-		return constraintMatched
+		return r.matchesWeekdayConstraints(t)
 	}
 
 	// Check regular weekday
@@ -1333,37 +1049,34 @@ func (r *rule) matchesSelectorWithOH(t time.Time, hc HolidayChecker, oh *Opening
 }
 
 func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) bool {
-	// Check year constraints first
-	if r.yearStart > 0 {
-		year := t.Year()
-		if year < r.yearStart || year > r.yearEnd {
-			return false
-		}
-		// Check year interval if specified (e.g., 2020-2030/2 means every other year)
-		if r.yearInterval > 0 {
-			yearOffset := year - r.yearStart
-			if yearOffset%r.yearInterval != 0 {
-				return false
+	if r.holidayUnion {
+		parts, n := r.holidayUnionParts()
+		for i := 0; i < n; i++ {
+			if parts[i].matchesWithOH(t, hc, oh) {
+				return true
 			}
 		}
+		return false
+	}
+
+	return r.matchesAfterMidnight(t, hc, oh) || r.matchesOnDay(t, hc, oh)
+}
+
+// matchesOnDay checks the rule on the day of t, without the part after
+// midnight of ranges starting on the previous day, see matchesAfterMidnight
+func (r *rule) matchesOnDay(t time.Time, hc HolidayChecker, oh *OpeningHours) bool {
+	// Check year constraints first
+	if !r.matchesYear(r.selectorDate(t)) {
+		return false
 	}
 
 	// Check Easter rules
 	if r.isEaster {
-		easterDate := calculateEaster(t.Year())
+		easterDate := r.easterDate(t.Year())
 
 		if r.isEasterRange {
-			// Check if we're in the Easter date range
-			startDate := easterDate.AddDate(0, 0, r.easterOffset)
-			endDate := easterDate.AddDate(0, 0, r.easterOffsetEnd)
-
-			// Normalize dates to start of day for comparison
-			tDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
-			startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
-			endDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
-
 			// Check if current date is within the range (inclusive)
-			if tDay.Before(startDay) || tDay.After(endDay) {
+			if !r.inEasterRange(t) {
 				return false
 			}
 
@@ -1393,9 +1106,9 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 		// If it's an SH rule and today is a school holiday, continue checking time ranges below
 	} else {
 		// This is a regular rule (not SH)
-		// If today is a school holiday and we have a school holiday checker, don't match regular rules
+		// If today is a school holiday handled by an SH rule, don't match regular rules
 		// This allows SH rules to override regular weekday rules
-		if oh != nil && oh.schoolHolidayChecker != nil && oh.schoolHolidayChecker.IsSchoolHoliday(t) {
+		if oh != nil && oh.schoolHolidayChecker != nil && oh.hasSchoolHolidayRuleFor(t) && oh.schoolHolidayChecker.IsSchoolHoliday(t) {
 			return false
 		}
 	}
@@ -1403,34 +1116,16 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 	// Check if this is a PH (public holiday) rule
 	if r.isPH {
 		// This rule only applies on public holidays (or offset from holidays)
-		if hc == nil {
+		if !r.matchesPublicHoliday(t, hc) {
 			return false
 		}
-
-		if r.phOffset == 0 {
-			// Actual holiday (no offset)
-			if !hc.IsHoliday(t) {
-				return false
-			}
-		} else {
-			// Check if offset days ago/ahead is a holiday
-			// To check if today is N days after a holiday, we check if (today - N days) is a holiday
-			checkDate := t.AddDate(0, 0, -r.phOffset)
-			if !hc.IsHoliday(checkDate) {
-				return false
-			}
-			// Also make sure TODAY is not a holiday (for offset rules)
-			// This ensures offset rules don't match on the holiday itself
-			if hc.IsHoliday(t) {
-				return false
-			}
-		}
-		// If it's a PH rule and conditions are met, continue checking time ranges below
+		// If it's a PH rule and conditions are met, continue checking the
+		// other selectors like the weekdays of "PH Mo-Fr" and time ranges below
 	} else {
 		// This is a regular rule (not PH)
-		// If today is a public holiday and we have a holiday checker, don't match regular rules
+		// If today is a public holiday handled by a PH rule, don't match regular rules
 		// This allows PH rules to override regular weekday rules
-		if hc != nil && hc.IsHoliday(t) {
+		if hc != nil && (oh == nil || oh.hasHolidayRuleFor(t)) && hc.IsHoliday(t) {
 			return false
 		}
 		// Also check if today is a "PH offset day" (e.g., day after/before a holiday)
@@ -1441,158 +1136,19 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 	}
 
 	// Check month/day constraints first
-	if r.monthStart > 0 {
-		month := int(t.Month())
-		day := t.Day()
-
-		// Check if we're in a month-day range
-		if r.dayStart > 0 {
-			// We have specific day constraints
-			// Check if we're in the range (which might span months)
-			inRange := false
-
-			if r.monthStart == r.monthEnd {
-				// Same month range
-				if month == r.monthStart && day >= r.dayStart && day <= r.dayEnd {
-					// Check day interval if specified (e.g., Jan 01-31/8 means every 8th day)
-					if r.dayInterval > 0 {
-						dayOffset := day - r.dayStart
-						if dayOffset%r.dayInterval == 0 {
-							inRange = true
-						}
-					} else {
-						inRange = true
-					}
-				}
-			} else if r.monthStart < r.monthEnd {
-				// Normal month range (e.g., Mar-May)
-				if month > r.monthStart && month < r.monthEnd {
-					inRange = true
-				} else if month == r.monthStart && day >= r.dayStart {
-					inRange = true
-				} else if month == r.monthEnd && day <= r.dayEnd {
-					inRange = true
-				}
-			} else {
-				// Wrapping month range (e.g., Dec-Jan)
-				if month > r.monthStart || month < r.monthEnd {
-					inRange = true
-				} else if month == r.monthStart && day >= r.dayStart {
-					inRange = true
-				} else if month == r.monthEnd && day <= r.dayEnd {
-					inRange = true
-				}
-			}
-
-			if !inRange {
-				return false
-			}
-		} else {
-			// Just month constraint, no specific days
-			inRange := false
-			if r.monthStart == r.monthEnd {
-				// Single month
-				if month == r.monthStart {
-					inRange = true
-				}
-			} else if r.monthStart < r.monthEnd {
-				// Normal month range
-				if month >= r.monthStart && month <= r.monthEnd {
-					inRange = true
-				}
-			} else {
-				// Wrapping month range
-				if month >= r.monthStart || month <= r.monthEnd {
-					inRange = true
-				}
-			}
-
-			if !inRange {
-				return false
-			}
-		}
+	if r.monthStart > 0 && !r.matchesMonthDay(r.selectorDate(t)) {
+		return false
 	}
 
 	// Check week number constraints
-	if len(r.weekConstraints) > 0 {
-		_, week := t.ISOWeek()
-		inRange := false
-		for _, wc := range r.weekConstraints {
-			if wc.weekStart == wc.weekEnd {
-				// Single week
-				if week == wc.weekStart {
-					inRange = true
-					break
-				}
-			} else {
-				// Week range
-				if week >= wc.weekStart && week <= wc.weekEnd {
-					if wc.weekInterval > 0 {
-						weekOffset := week - wc.weekStart
-						if weekOffset%wc.weekInterval == 0 {
-							inRange = true
-							break
-						}
-					} else {
-						inRange = true
-						break
-					}
-				}
-			}
-		}
-		if !inRange {
-			return false
-		}
+	if len(r.weekConstraints) > 0 && !r.matchesWeeks(t) {
+		return false
 	}
 
 	// Check weekday constraints if present
 	constraintMatched := false
 	if len(r.weekdayConstraints) > 0 {
-		weekday := int(t.Weekday())
-
-		for _, constraint := range r.weekdayConstraints {
-			if constraint.weekday != weekday {
-				continue
-			}
-
-			// Calculate which occurrence this is
-			nthFromStart := nthWeekdayOfMonth(t)
-			nthFromEnd := nthWeekdayFromEnd(t)
-
-			// Check if this matches the constraint
-			if constraint.nthFrom > 0 {
-				// Positive index (from start)
-				if constraint.nthTo == 0 {
-					// Single value like [1]
-					if nthFromStart == constraint.nthFrom {
-						constraintMatched = true
-						break
-					}
-				} else {
-					// Range like [1-2]
-					if nthFromStart >= constraint.nthFrom && nthFromStart <= constraint.nthTo {
-						constraintMatched = true
-						break
-					}
-				}
-			} else {
-				// Negative index (from end)
-				if constraint.nthTo == 0 {
-					// Single value like [-1]
-					if nthFromEnd == -constraint.nthFrom {
-						constraintMatched = true
-						break
-					}
-				} else {
-					// Range like [-2--1] (not typical but handle it)
-					if nthFromEnd >= -constraint.nthTo && nthFromEnd <= -constraint.nthFrom {
-						constraintMatched = true
-						break
-					}
-				}
-			}
-		}
-
+		constraintMatched = r.matchesWeekdayConstraints(t)
 		if !constraintMatched {
 			return false
 		}
@@ -1627,6 +1183,11 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 		if tr.endVar != "" && oh != nil {
 			trEnd = oh.resolveVariableTime(t, tr.endVar, tr.endOffset)
 		}
+		// In polar night the sun rises and sets at solar noon: a range from a
+		// rise to a set is empty then, instead of spanning the whole day
+		if trStart == trEnd && isRiseEvent(tr.startVar) && isSetEvent(tr.endVar) {
+			continue
+		}
 
 		// Check if this is a midnight-spanning range or extended hours (25:00, 26:00 etc.)
 		// Extended hours means end time > 24:00 (1440 minutes), which wraps to next day
@@ -1649,19 +1210,26 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 				prevWeekday := (weekday + 6) % 7 // Previous day
 
 				// Case 1: Current day is a valid start day and time >= start
-				if r.weekdays[weekday] && minuteOfDay >= trStart {
+				if r.weekdays[weekday] && minuteOfDay >= trStart &&
+					(tr.interval == 0 || oh.atPeriodicPoint(tr, minuteOfDay-trStart, trEnd+24*60-trStart)) {
 					return true
 				}
 
 				// Case 2: Previous day was a valid start day and current time < end
-				if r.weekdays[prevWeekday] && minuteOfDay < trEnd {
+				// for ranges starting at 24:00; the part after midnight of other
+				// ranges is matched on the previous day, see matchesAfterMidnight
+				if trStart >= 24*60 && r.weekdays[prevWeekday] && minuteOfDay < trEnd {
 					return true
 				}
-			} else {
-				// Without weekday constraints or with constrained weekdays: match if time >= start OR time < end
-				if minuteOfDay >= trStart || minuteOfDay < trEnd {
+			} else if minuteOfDay >= trStart && tr.interval > 0 {
+				if oh.atPeriodicPoint(tr, minuteOfDay-trStart, trEnd+24*60-trStart) {
 					return true
 				}
+			} else if minuteOfDay >= trStart || (trStart >= 24*60 && minuteOfDay < trEnd) {
+				// Without weekday constraints or with constrained weekdays the
+				// part after midnight is matched on the previous day, see
+				// matchesAfterMidnight, unless the range starts at 24:00
+				return true
 			}
 		} else {
 			// Normal non-spanning range
@@ -1672,19 +1240,15 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 				}
 			}
 
-			if minuteOfDay >= trStart && minuteOfDay < trEnd {
-				// Check time interval if specified (e.g., 10:00-16:00/01:30)
-				// Interval means alternating open/closed periods within the range
-				if tr.interval > 0 {
-					offset := minuteOfDay - trStart
-					slotIndex := offset / tr.interval
-					// Even slots are open, odd slots are closed
-					if slotIndex%2 == 0 {
-						return true
-					}
-					// In a closed slot, don't match
-					continue
+			// Periodic ranges like 10:00-16:00/01:30 are points in time
+			if tr.interval > 0 {
+				if oh.atPeriodicPoint(tr, minuteOfDay-trStart, trEnd-trStart) {
+					return true
 				}
+				continue
+			}
+
+			if minuteOfDay >= trStart && minuteOfDay < trEnd {
 				return true
 			}
 		}
@@ -1694,26 +1258,46 @@ func (r *rule) matchesWithOH(t time.Time, hc HolidayChecker, oh *OpeningHours) b
 }
 
 func (oh *OpeningHours) parse(value string) error {
+	incMetric(CounterParse)
+	oh.source = value
+	if err := oh.parseValue(value); err != nil {
+		return err
+	}
+	if oh.strict && len(oh.warnings) > 0 {
+		return fmt.Errorf("strict mode: %s", oh.warnings[0].Message)
+	}
+	oh.orderAllRules()
+	return nil
+}
+
+// parseValue parses value into the primary and fallback groups of rules
+func (oh *OpeningHours) parseValue(value string) error {
 	value = strings.TrimSpace(value)
+	if err := checkComplexity(value); err != nil {
+		return err
+	}
+
+	oh.parsingRule = -1
 
 	// Check for short time format BEFORE normalization
-	// Pattern: number-number that is NOT preceded or followed by a colon or another digit
-	shortTimePattern := regexp.MustCompile(`(?:^|[^\d:])(\d{1,2})-(\d{1,2})(?:[^\d:]|$)`)
-	if shortTimePattern.MatchString(value) {
-		// Additional check: make sure the matched portion doesn't have colons
-		// by checking the entire value doesn't have time format around it
-		match := shortTimePattern.FindStringSubmatch(value)
-		if len(match) >= 3 {
-			start, err1 := strconv.Atoi(match[1])
-			end, err2 := strconv.Atoi(match[2])
-			// Only warn if both are valid hour values (0-24)
-			if err1 == nil && err2 == nil && start >= 0 && start <= 24 && end >= 0 && end <= 24 {
-				oh.addWarning("Abbreviated time format: use HH:MM instead of H")
-			}
-		}
+	if span := shortTimeMatch(value); span != "" {
+		oh.addWarning(WarnAbbreviatedTime, span, "Abbreviated time format: use HH:MM instead of H")
+	}
+
+	if span := fullWidthMatch(value); span != "" {
+		oh.addWarning(WarnFullWidth, span, "Full-width characters were converted to ASCII digits, colons and hyphens")
+	}
+
+	if span := weekdayTimePattern.FindString(value); span != "" {
+		oh.addWarning(WarnMissingSpace, span, "A space was inserted between weekdays and times, e.g. use Mo-Fr 09:00-17:00 instead of Mo-Fr09:00-17:00")
+	}
+
+	if span := spacedRangeMatch(NormalizeDotTimes(value)); span != "" {
+		oh.addWarning(WarnSpacedRange, span, "Spaces around range hyphens were removed, e.g. use Mo-Fr instead of Mo - Fr")
 	}
 
-	value = normalizeTimeString(value)
+	value = oh.normalize(value)
+	oh.value = value
 
 	// Handle special cases
 	lower := strings.ToLower(value)
@@ -1753,7 +1337,7 @@ func (oh *OpeningHours) parse(value string) error {
 
 	// Parse fallback groups (groups after ||)
 	for i := 1; i < len(groups); i++ {
-		var fallbackRules []rule
+		fallbackRules := oh.reusableFallbackGroup(i - 1)
 		if err := oh.parseRuleGroup(groups[i], &fallbackRules); err != nil {
 			return err
 		}
@@ -1764,22 +1348,66 @@ func (oh *OpeningHours) parse(value string) error {
 		return fmt.Errorf("unable to parse: %s", value)
 	}
 
+	// Share identical slices between rules, mostly across fallback groups
+	oh.internRules()
+
 	// Check for redundant 24/7: if first rule is 24/7 and there are more rules
 	if len(oh.rules) > 1 {
 		firstRule := oh.rules[0]
 		// Check if first rule is 24/7 (open all the time with no constraints)
 		if firstRule.state == StateOpen && firstRule.weekdays == nil &&
-		   len(firstRule.timeRanges) == 0 && firstRule.monthStart == 0 &&
-		   firstRule.yearStart == 0 && len(firstRule.weekConstraints) == 0 &&
-		   len(firstRule.weekdayConstraints) == 0 && !firstRule.isPH &&
-		   !firstRule.isSH && !firstRule.isEaster {
-			oh.addWarning("Redundant 24/7: additional rules override parts of 24/7")
+			len(firstRule.timeRanges) == 0 && firstRule.monthStart == 0 &&
+			firstRule.yearStart == 0 && len(firstRule.weekConstraints) == 0 &&
+			len(firstRule.weekdayConstraints) == 0 && !firstRule.isPH &&
+			!firstRule.isSH && !firstRule.isEaster {
+			oh.parsingRule = 0
+			first, _, _ := strings.Cut(groups[0], ";")
+			oh.addWarning(WarnRedundant247, strings.TrimSpace(first), "Redundant 24/7: additional rules override parts of 24/7")
+			oh.parsingRule = -1
 		}
 	}
 
 	return nil
 }
 
+// replaceAllDayTimes replaces "24/7" after selectors by "00:00-24:00" outside
+// of comments: "Sa-Su 24/7" -> "Sa-Su 00:00-24:00". A rule of "24/7" alone
+// keeps it and stays an open rule without selectors.
+func replaceAllDayTimes(s string) string {
+	parts := strings.Split(s, `"`)
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = allDayTimePattern.ReplaceAllString(parts[i], "${1}00:00-24:00${2}")
+	}
+	return strings.Join(parts, `"`)
+}
+
+// shortTimeMatch returns the first hour range in s like "10-12", or "". Week
+// ranges ("week 02-20"), day ranges ("Jan 01-15") and occurrences ("Su[1-2]")
+// are not hour ranges.
+func shortTimeMatch(s string) string {
+	for _, loc := range shortTimePattern.FindAllStringSubmatchIndex(s, -1) {
+		start, err1 := strconv.Atoi(s[loc[2]:loc[3]])
+		end, err2 := strconv.Atoi(s[loc[4]:loc[5]])
+		// Only hours if both are valid hour values (0-24)
+		if err1 != nil || err2 != nil || start > 24 || end > 24 {
+			continue
+		}
+		// Occurrences of constrained weekdays like "Su[1-2]"
+		if strings.Count(s[:loc[2]], "[") > strings.Count(s[:loc[2]], "]") {
+			continue
+		}
+		words := strings.Fields(s[:loc[2]])
+		if len(words) > 0 {
+			prev := words[len(words)-1]
+			if endsWithMonth(prev) || strings.EqualFold(prev, "week") {
+				continue
+			}
+		}
+		return s[loc[2]:loc[5]]
+	}
+	return ""
+}
+
 // parseRuleGroup parses a group of rules separated by semicolons
 func (oh *OpeningHours) parseRuleGroup(groupStr string, rules *[]rule) error {
 	groupStr = strings.TrimSpace(groupStr)
@@ -1799,11 +1427,25 @@ func (oh *OpeningHours) parseRuleGroup(groupStr string, rules *[]rule) error {
 		return nil
 	}
 
+	// Index of the first rule of the group among all rules, for warnings
+	firstRule := len(oh.rules)
+	for _, group := range oh.fallbackGroups {
+		firstRule += len(group)
+	}
+	defer func() { oh.parsingRule = -1 }()
+
 	// Counter for ruleGroup IDs (used for comma-separated rules)
 	ruleGroupCounter := 1
+	// Counter for monthList IDs (used to prettify expanded month lists as lists)
+	monthListCounter := 1
+
+	groupStr = replaceAllDayTimes(groupStr)
 
 	// Split by semicolon for multiple rules
-	ruleParts := strings.Split(groupStr, ";")
+	ruleParts := oh.splitMissingSemicolons(strings.Split(groupStr, ";"))
+	if oh.mergeSplitDates {
+		ruleParts = oh.mergeSplitDateRules(ruleParts)
+	}
 	for _, rulePart := range ruleParts {
 		rulePart = strings.TrimSpace(rulePart)
 		if rulePart == "" {
@@ -1828,15 +1470,24 @@ func (oh *OpeningHours) parseRuleGroup(groupStr string, rules *[]rule) error {
 			// Create a rule for each year
 			for _, year := range years {
 				yearRule := fmt.Sprintf("%d %s", year, remainingPart)
+				oh.parsingRule = firstRule + len(*rules)
 				r, err := parseRule(yearRule, oh)
 				if err != nil {
 					return err
 				}
 				*rules = append(*rules, r)
+				if err := oh.checkRuleCount(rules); err != nil {
+					return err
+				}
 			}
 		} else {
 			// First, expand any month lists (e.g., "Jun-Aug,Dec Mo 10:00-12:00")
 			monthExpandedRules := expandMonthList(rulePart)
+			listID := 0
+			if len(monthExpandedRules) > 1 {
+				listID = monthListCounter
+				monthListCounter++
+			}
 
 			for _, monthRule := range monthExpandedRules {
 				// Check if this rule has comma-separated weekday+time combinations
@@ -1851,12 +1502,19 @@ func (oh *OpeningHours) parseRuleGroup(groupStr string, rules *[]rule) error {
 				}
 
 				for _, subRule := range subRules {
+					oh.parsingRule = firstRule + len(*rules)
 					r, err := parseRule(subRule, oh)
 					if err != nil {
 						return err
 					}
 					r.ruleGroup = groupID
+					if len(subRules) == 1 {
+						r.monthList = listID
+					}
 					*rules = append(*rules, r)
+					if err := oh.checkRuleCount(rules); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -1866,8 +1524,9 @@ func (oh *OpeningHours) parseRuleGroup(groupStr string, rules *[]rule) error {
 }
 
 // splitByCommaOutsideBracketsAndTime splits a rule by comma, but only when
-// both parts are complete weekday+time combinations
+// both parts are complete selector+time combinations
 // e.g., "Mo-Fr 10:00-16:00, We 12:00-18:00" -> ["Mo-Fr 10:00-16:00", "We 12:00-18:00"]
+// and "Jan Mo-Fr 10:00-16:00, Jul-Aug Mo-Fr 08:00-20:00" -> two parts
 // but "Mo,Th 10:00-12:00" stays as one part (Mo,Th share the time)
 // and "10:00-12:00, 14:00-18:00" stays together (just time ranges)
 func splitByCommaOutsideBracketsAndTime(s string) []string {
@@ -1889,11 +1548,20 @@ func splitByCommaOutsideBracketsAndTime(s string) []string {
 			currentPart := strings.TrimSpace(current.String())
 			rest := strings.TrimSpace(string(runes[i+1:]))
 
-			// Only split if current part also has a time, and rest starts with weekday+time
-			if hasWeekdayAndTime(currentPart) && startsWithWeekday(rest) {
-				// Split here - both parts are complete weekday+time combinations
+			// Only split if both parts are rules: selectors with a time or a modifier
+			if (hasSelectorAndTime(currentPart) || hasSelectorAndModifier(currentPart)) &&
+				(hasSelectorAndTime(rest) || hasSelectorAndModifier(rest)) {
+				// Split here - both parts are complete selector+time combinations
 				parts = append(parts, currentPart)
 				current.Reset()
+			} else if endsWithModifier(currentPart) && startsWithTime(rest) {
+				// Times after a modifier have their own state and comment, with the
+				// selectors of the part before: "Mo 10:00-12:00 open, 14:00-16:00 unknown"
+				parts = append(parts, currentPart)
+				current.Reset()
+				if selectors := leadingSelectors(currentPart); selectors != "" {
+					current.WriteString(selectors + " ")
+				}
 			} else {
 				// Keep the comma - this is either a weekday list or time range separator
 				current.WriteRune(ch)
@@ -1913,36 +1581,28 @@ func splitByCommaOutsideBracketsAndTime(s string) []string {
 	return parts
 }
 
-// hasWeekdayAndTime checks if a string contains both weekday and time components
-func hasWeekdayAndTime(s string) bool {
-	s = strings.TrimSpace(s)
-
-	// Must have a space separating weekday from time
-	spaceIdx := strings.Index(s, " ")
-	if spaceIdx < 0 {
-		return false
-	}
-
-	weekdayPart := s[:spaceIdx]
-	timePart := strings.TrimSpace(s[spaceIdx+1:])
-
-	// Check weekday part contains weekday
-	if !containsWeekday(weekdayPart) {
-		return false
+// hasSelectorAndTime checks if a string starts with one or more selectors
+// (weekdays, months, dates, years, PH/SH) followed by a time, like
+// "We 12:00-18:00", "Jul-Aug Mo-Fr 08:00-20:00" or "2024 Dec 24 10:00-14:00",
+// not just a selector list like "Mo,Th"
+func hasSelectorAndTime(s string) bool {
+	fields := strings.Fields(s)
+	i := 0
+	for i < len(fields) && isSelectorToken(fields[i]) {
+		i++
 	}
-
-	// Check time part contains a time (starts with digit or variable time)
-	if len(timePart) == 0 {
+	if i == 0 || i == len(fields) {
 		return false
 	}
 
+	timePart := fields[i]
 	// Time should start with digit
 	if timePart[0] >= '0' && timePart[0] <= '9' {
 		return true
 	}
 
 	// Could be a variable time
-	variableTimes := []string{"sunrise", "sunset", "dawn", "dusk"}
+	variableTimes := []string{"sunrise", "sunset", "dawn", "dusk", "("}
 	lowerTime := strings.ToLower(timePart)
 	for _, vt := range variableTimes {
 		if strings.HasPrefix(lowerTime, vt) {
@@ -1953,51 +1613,93 @@ func hasWeekdayAndTime(s string) bool {
 	return false
 }
 
-// startsWithWeekdayAndTime checks if a string starts with a weekday+time combination
-// like "We 12:00-18:00" or "Mo-Fr 10:00-16:00", not just a weekday like "Mo,Th"
-func startsWithWeekday(s string) bool {
-	// Pattern: weekday (with optional range/list) followed by space and time
-	// We need to find a weekday selector followed by a space and a time
-	s = strings.TrimSpace(s)
-	if len(s) < 7 { // Minimum: "Mo 0:00"
+// hasSelectorAndModifier checks if a string starts with one or more selectors
+// followed by a state or a comment, like "PH off" or "Su unknown", which is an
+// additional rule within a comma-separated rule ("Mo-Fr 08:00-18:00, PH off")
+func hasSelectorAndModifier(s string) bool {
+	fields := strings.Fields(s)
+	i := 0
+	for i < len(fields) && isSelectorToken(fields[i]) {
+		i++
+	}
+	if i == 0 || i == len(fields) {
 		return false
 	}
-
-	// Find the first space
-	spaceIdx := strings.Index(s, " ")
-	if spaceIdx < 0 {
-		return false // No space, can't be weekday+time
+	switch strings.ToLower(strings.TrimSuffix(fields[i], ",")) {
+	case "open", "closed", "off", "unknown":
+		return true
 	}
+	return strings.HasPrefix(fields[i], `"`)
+}
 
-	// The part before space should be a weekday selector
-	weekdayPart := s[:spaceIdx]
-	timePart := strings.TrimSpace(s[spaceIdx+1:])
-
-	// Check if weekday part looks like weekday selectors (Mo, Mo-Fr, Mo,Tu,We, etc.)
-	// It should contain at least one weekday abbreviation
-	if !containsWeekday(weekdayPart) {
+// endsWithModifier checks if a rule string ends with a state or a comment,
+// like "Mo 10:00-12:00 unknown" or "Mo 10:00-12:00 \"by appointment\""
+func endsWithModifier(s string) bool {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
 		return false
 	}
+	switch strings.ToLower(fields[len(fields)-1]) {
+	case "open", "closed", "off", "unknown":
+		return true
+	}
+	return strings.HasSuffix(s, `"`)
+}
 
-	// Check if the time part starts with a time (digits followed by : or .)
-	if len(timePart) == 0 {
+// startsWithTime checks if a string starts with a time like "14:00-16:00" or
+// "sunset-22:00" rather than a selector
+func startsWithTime(s string) bool {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || isSelectorToken(fields[0]) {
 		return false
 	}
-
-	// Time should start with digit
-	if timePart[0] < '0' || timePart[0] > '9' {
-		// Could also be a variable time like "sunrise"
-		variableTimes := []string{"sunrise", "sunset", "dawn", "dusk"}
-		lowerTime := strings.ToLower(timePart)
-		for _, vt := range variableTimes {
-			if strings.HasPrefix(lowerTime, vt) {
-				return true
-			}
+	first := strings.ToLower(fields[0])
+	if first[0] >= '0' && first[0] <= '9' {
+		return strings.Contains(first, ":")
+	}
+	for _, vt := range []string{"sunrise", "sunset", "dawn", "dusk", "("} {
+		if strings.HasPrefix(first, vt) {
+			return true
 		}
-		return false
 	}
+	return false
+}
 
-	return true
+// leadingSelectors returns the selectors before the times of a rule string,
+// like "Jan Mo-Fr" of "Jan Mo-Fr 10:00-12:00 unknown"
+func leadingSelectors(s string) string {
+	fields := strings.Fields(s)
+	i := 0
+	for i < len(fields) && isSelectorToken(fields[i]) {
+		i++
+	}
+	return strings.Join(fields[:i], " ")
+}
+
+// isSelectorToken checks if a space-separated token is a selector rather than a time
+func isSelectorToken(tok string) bool {
+	lower := strings.ToLower(tok)
+	if lower == "ph" || lower == "sh" || lower == "week" || lower == "easter" {
+		return true
+	}
+	// "sunrise"/"sunset" contain "su" but are times
+	if strings.HasPrefix(lower, "sunrise") || strings.HasPrefix(lower, "sunset") {
+		return false
+	}
+	// Years, day numbers and week numbers: only digits and separators, no time colon
+	if strings.Trim(lower, "0123456789-,/") == "" {
+		return true
+	}
+	if containsWeekday(lower) {
+		return !strings.ContainsAny(lower, ":")
+	}
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	for _, m := range months {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+	return false
 }
 
 // containsWeekday checks if a string contains a weekday abbreviation
@@ -2027,6 +1729,20 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 	if lower == "off" || lower == "closed" {
 		return rule{state: StateClosed, comment: comment}, nil
 	}
+	if lower == "unknown" {
+		return rule{state: StateUnknown, comment: comment}, nil
+	}
+	// A rule with only a comment is unknown, e.g. the fallback in
+	// "Mo-Fr 10:00-16:00 || \"call us\""
+	if lower == "" && comment != "" {
+		return rule{state: StateUnknown, comment: comment}, nil
+	}
+
+	// Movable dates like "whitsun" are parsed like "easter"
+	s, err := parseVariableDate(&r, s, oh)
+	if err != nil {
+		return r, err
+	}
 
 	// Check for state at the end (off, closed, open, unknown)
 	lower = strings.ToLower(s)
@@ -2043,6 +1759,17 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 		s = strings.TrimSpace(s[:len(s)-len(" unknown")])
 	}
 
+	// Full date ranges with a year on both ends ("2025 Jun 01-2026 Sep 30") span
+	// years and replace both the year and the month/day selectors
+	s, dateStart, dateEnd, err := parseFullDateRange(s)
+	if err != nil {
+		return r, err
+	}
+	if dateStart > 0 {
+		r.yearStart, r.yearEnd = dateStart/10000, dateEnd/10000
+		r.dateStart, r.dateEnd = dateStart, dateEnd
+	}
+
 	// Try to extract year first
 	s, yearStart, yearEnd, yearInterval, years, err := parseYearWithList(s)
 	if err != nil {
@@ -2055,9 +1782,13 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 		return r, fmt.Errorf("internal error: multiple years should be handled in parse()")
 	}
 
-	r.yearStart = yearStart
-	r.yearEnd = yearEnd
-	r.yearInterval = yearInterval
+	if dateStart == 0 {
+		r.yearStart = yearStart
+		r.yearEnd = yearEnd
+		r.yearInterval = yearInterval
+	} else if yearStart > 0 {
+		return r, fmt.Errorf("year selector after full date range: %d", yearStart)
+	}
 
 	// Parse week number constraints first (e.g., "week 01 Jan Mo" - need to extract week before month)
 	s, weekConstraints, err := parseWeekNumbers(s)
@@ -2066,16 +1797,71 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 	}
 	r.weekConstraints = weekConstraints
 
-	// Try to extract month/date ranges
-	s, monthStart, monthEnd, dayStart, dayEnd, dayInterval, err := parseMonthDate(s)
+	// Easter ranges with a fixed month-day endpoint ("Jan 06-easter", "easter-Oct 31")
+	s, err = parseEasterDateRange(&r, s)
+	if err != nil {
+		return r, err
+	}
+
+	// Month days counted from the end of the month ("Jan -1", "Jan-Dec -1")
+	s, err = parseMonthDayFromEnd(&r, s)
 	if err != nil {
 		return r, err
 	}
-	r.monthStart = monthStart
-	r.monthEnd = monthEnd
-	r.dayStart = dayStart
-	r.dayEnd = dayEnd
-	r.dayInterval = dayInterval
+
+	// Try to extract month/date ranges
+	monthStart, monthEnd, dayStart, dayEnd, dayInterval := r.monthStart, r.monthEnd, r.dayStart, r.dayEnd, 0
+	if monthStart == 0 {
+		s, monthStart, monthEnd, dayStart, dayEnd, dayInterval, err = parseMonthDate(s)
+		if err != nil {
+			return r, err
+		}
+		r.monthStart = monthStart
+		r.monthEnd = monthEnd
+		r.dayStart = dayStart
+		r.dayEnd = dayEnd
+		r.dayInterval = dayInterval
+	}
+
+	// A single date may be moved by days ("Dec 25 +3 days")
+	if dayStart > 0 && monthStart == monthEnd && dayStart == dayEnd && dayInterval == 0 &&
+		(strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")) {
+		if match := phOffsetPattern.FindStringSubmatch(s); match != nil {
+			offset, err := strconv.Atoi(match[1])
+			if err != nil {
+				return r, fmt.Errorf("invalid date offset: %s", match[1])
+			}
+			r.dateOffset = offset
+			s = strings.TrimSpace(s[len(match[0]):])
+		}
+	}
+
+	// A single year before a month/day range that wraps into the next year
+	// ("2024 Dec 24-Jan 05") is the start of a full date range
+	if r.yearStart > 0 && r.yearEnd == r.yearStart && r.yearInterval == 0 && dayStart > 0 && dayEnd > 0 && dayInterval == 0 &&
+		monthEnd*100+dayEnd < monthStart*100+dayStart {
+		r.dateStart = r.yearStart*10000 + monthStart*100 + dayStart
+		r.dateEnd = (r.yearStart+1)*10000 + monthEnd*100 + dayEnd
+		r.yearEnd = r.yearStart + 1
+		r.monthStart, r.monthEnd, r.dayStart, r.dayEnd = 0, 0, 0, 0
+	}
+
+	// The week selector may also follow the month selector ("Apr-Sep week 02-20 Mo")
+	if len(r.weekConstraints) == 0 {
+		s, weekConstraints, err = parseWeekNumbers(s)
+		if err != nil {
+			return r, err
+		}
+		r.weekConstraints = weekConstraints
+	}
+
+	// Accept weekday selectors separated by spaces ("Mo[1] Tu[2]") like comma lists
+	if joined, usedSpaces := joinWeekdaySelectors(s); joined != s {
+		if usedSpaces && oh != nil {
+			oh.addWarning(WarnSpaceSeparatedWeekdays, s, "Weekday selectors should be separated by commas, not spaces")
+		}
+		s = joined
+	}
 
 	// Check for Easter patterns
 	lower = strings.ToLower(s)
@@ -2113,7 +1899,7 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 
 			if match[1] != "" {
 				// Parse offset like "+1 day" or "-2 days"
-				offsetStr := strings.TrimSpace(match[1])
+				offsetStr := strings.ToLower(strings.TrimSpace(match[1]))
 				offsetStr = strings.TrimSuffix(offsetStr, "days")
 				offsetStr = strings.TrimSuffix(offsetStr, "day")
 				offsetStr = strings.TrimSpace(offsetStr)
@@ -2134,8 +1920,8 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 		}
 	}
 
-	// Check if this is a PH (public holiday) rule
-	if strings.HasPrefix(strings.ToUpper(s), "PH") {
+	// Check if this is a PH (public holiday) rule. "PH,Su" is a list, parsed below.
+	if strings.HasPrefix(strings.ToUpper(s), "PH") && !isHolidayList(s) {
 		r.isPH = true
 		// Remove "PH" prefix
 		s = strings.TrimSpace(s[2:])
@@ -2174,7 +1960,7 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 	}
 
 	// Check if this is a SH (school holiday) rule
-	if strings.HasPrefix(strings.ToUpper(s), "SH") {
+	if strings.HasPrefix(strings.ToUpper(s), "SH") && !isHolidayList(s) {
 		r.isSH = true
 		// Remove "SH" prefix and parse the rest (may contain weekdays and/or time ranges)
 		s = strings.TrimSpace(s[2:])
@@ -2207,13 +1993,15 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 	r.weekdays = weekdays
 	r.weekdayConstraints = constraints
 
-	// Set holiday flags if PH/SH were found in the weekday list (e.g., "Su,PH off")
+	// Set holiday flags if PH/SH were found in the weekday list (e.g., "Su,PH off").
+	// The rule applies on the listed weekdays and on the holidays.
 	if hasPH {
 		r.isPH = true
 	}
 	if hasSH {
 		r.isSH = true
 	}
+	r.holidayUnion = hasPH || hasSH
 
 	if timeStr != "" {
 		timeRanges, err := parseTimeRanges(timeStr, oh)
@@ -2226,6 +2014,78 @@ func parseRule(s string, oh *OpeningHours) (rule, error) {
 	return r, nil
 }
 
+var fullDateRangePattern = regexp.MustCompile(`^(\d{4})\s+([A-Za-z]{3})\s+(\d{1,2})\s*-\s*(\d{4})\s+([A-Za-z]{3})\s+(\d{1,2})(?:\s+|$)`)
+
+// parseEasterDateRange parses an Easter range with a fixed month-day endpoint
+// like "Jan 06-easter", "Apr 01-easter +1 day" or "easter -2 days-Oct 31" at
+// the start of s into r. Returns the remaining string.
+func parseEasterDateRange(r *rule, s string) (string, error) {
+	var monthName, dayStr, offsetStr string
+	match := dateToEasterPattern.FindStringSubmatch(s)
+	if match != nil {
+		monthName, dayStr, offsetStr = match[1], match[2], match[3]
+	} else if match = easterToDatePattern.FindStringSubmatch(s); match != nil {
+		offsetStr, monthName, dayStr = match[1], match[2], match[3]
+	} else {
+		return s, nil
+	}
+
+	month, ok := monthNames[strings.ToLower(monthName)]
+	if !ok {
+		return s, nil
+	}
+	day, _ := strconv.Atoi(dayStr)
+	if day < 1 || day > 31 {
+		return s, fmt.Errorf("invalid day in Easter range: %s %s", monthName, dayStr)
+	}
+	offset, _ := strconv.Atoi(offsetStr)
+
+	r.isEaster = true
+	r.isEasterRange = true
+	earliest, latest := r.movableDateWindow(offset)
+	if strings.HasPrefix(strings.ToLower(s), "easter") {
+		r.easterOffset = offset
+		r.easterEndDate = month*100 + day
+		r.easterWraps = r.easterEndDate < earliest
+	} else {
+		r.easterStartDate = month*100 + day
+		r.easterOffsetEnd = offset
+		r.easterWraps = r.easterStartDate > latest
+	}
+	return strings.TrimSpace(s[len(match[0]):]), nil
+}
+
+// parseFullDateRange parses a date range with a year on both ends like
+// "2025 Jun 01-2026 Sep 30" and returns the dates as yyyymmdd
+func parseFullDateRange(s string) (string, int, int, error) {
+	s = strings.TrimSpace(s)
+	match := fullDateRangePattern.FindStringSubmatch(s)
+	if match == nil {
+		return s, 0, 0, nil
+	}
+
+	dates := [2]int{}
+	for i, m := range [][]string{match[1:4], match[4:7]} {
+		year, _ := strconv.Atoi(m[0])
+		month, ok := monthNames[strings.ToLower(m[1])]
+		if !ok {
+			return s, 0, 0, nil
+		}
+		day, _ := strconv.Atoi(m[2])
+		if day < 1 || day > 31 {
+			return s, 0, 0, fmt.Errorf("invalid day in date range: %s %s", m[1], m[2])
+		}
+		dates[i] = year*10000 + month*100 + day
+	}
+
+	if dates[1] < dates[0] {
+		return s, 0, 0, fmt.Errorf("date range ends before it starts: %s", strings.TrimSpace(match[0]))
+	}
+
+	remaining := strings.TrimSpace(s[len(match[0]):])
+	return remaining, dates[0], dates[1], nil
+}
+
 var yearPattern = regexp.MustCompile(`^(\d{4}(?:,\d{4})*)(?:-(\d{4})(/\d+)?|\+)?\s+`)
 
 func parseYearWithList(s string) (string, int, int, int, []int, error) {
@@ -2295,6 +2155,7 @@ func parseYearWithList(s string) (string, int, int, int, []int, error) {
 
 // expandMonthList expands comma-separated month lists in a rule string
 // e.g., "Jun-Aug,Dec Mo 10:00-12:00" -> ["Jun-Aug Mo 10:00-12:00", "Dec Mo 10:00-12:00"]
+// The list may follow a year and a week selector: "week 02-20 Apr,Jun Mo".
 // Returns a list of expanded rule strings, or the original string if no expansion needed
 func expandMonthList(s string) []string {
 	s = strings.TrimSpace(s)
@@ -2307,10 +2168,39 @@ func expandMonthList(s string) []string {
 		return []string{s}
 	}
 
+	// Skip a leading year and week selector, which every expanded rule keeps
+	skip := 0
+	if yearPattern.MatchString(parts[0] + " ") {
+		skip++
+	}
+	if len(parts) > skip+1 && strings.ToLower(parts[skip]) == "week" {
+		skip += 2
+	}
+	if skip >= len(parts) {
+		return []string{s}
+	}
+	original := s
+	offset := 0
+	for _, part := range parts[:skip] {
+		offset = strings.Index(s[offset:], part) + offset + len(part)
+	}
+	prefix := strings.TrimSpace(s[:offset])
+	if prefix != "" {
+		prefix += " "
+	}
+	parts = parts[skip:]
+	s = strings.TrimSpace(s[offset:])
+
 	// Check if first part contains comma-separated months
 	firstPart := strings.ToLower(parts[0])
 	if !strings.Contains(firstPart, ",") {
-		return []string{s}
+		if days := expandMonthDayList(s); days != nil {
+			for i, day := range days {
+				days[i] = prefix + day
+			}
+			return days
+		}
+		return []string{original}
 	}
 
 	// Check if this looks like a month list (not a weekday list like "Mo,Tu")
@@ -2324,13 +2214,13 @@ func expandMonthList(s string) []string {
 			_, isMonth1 := monthNames[rangeParts[0]]
 			_, isMonth2 := monthNames[rangeParts[1]]
 			if !isMonth1 || !isMonth2 {
-				return []string{s}
+				return []string{original}
 			}
 		} else {
 			// Single month like "dec"
 			_, isMonth := monthNames[mp]
 			if !isMonth {
-				return []string{s}
+				return []string{original}
 			}
 		}
 	}
@@ -2345,10 +2235,66 @@ func expandMonthList(s string) []string {
 			mp = strings.ToUpper(string(mp[0])) + strings.ToLower(mp[1:])
 		}
 		if remaining != "" {
-			result = append(result, mp+" "+remaining)
+			result = append(result, prefix+mp+" "+remaining)
 		} else {
-			result = append(result, mp)
+			result = append(result, prefix+mp)
+		}
+	}
+	return result
+}
+
+// monthDayListPattern matches a leading list of month days like
+// "Jan 01,May 01,Dec 25-26" or "Dec 24,31"; items after the first may omit the month
+var monthDayListPattern = regexp.MustCompile(`^\p{L}+ \d{1,2}(?:-(?:\p{L}+ )?\d{1,2})?(?:\s*,\s*(?:\p{L}+ )?\d{1,2}(?:-(?:\p{L}+ )?\d{1,2})?)+(?:\s|$)`)
+var monthDayItemPattern = regexp.MustCompile(`^(?:(\p{L}+) )?(\d{1,2})(?:-(?:(\p{L}+) )?(\d{1,2}))?$`)
+
+// expandMonthDayList expands a leading list of month days in a rule string
+// e.g., "Jan 01,May 01,Dec 25-26 off" -> ["Jan 01 off", "May 01 off", "Dec 25-26 off"]
+// Items without a month use the month of the previous item ("Dec 24,31").
+// Returns nil if s doesn't start with such a list.
+func expandMonthDayList(s string) []string {
+	list := monthDayListPattern.FindString(s)
+	if list == "" {
+		return nil
+	}
+	remaining := strings.TrimSpace(s[len(list):])
+
+	capitalize := func(name string) string {
+		return strings.ToUpper(name[:1]) + strings.ToLower(name[1:])
+	}
+
+	var result []string
+	month := ""
+	for _, item := range strings.Split(strings.TrimSpace(list), ",") {
+		m := monthDayItemPattern.FindStringSubmatch(strings.TrimSpace(item))
+		if m == nil {
+			return nil
+		}
+		if m[1] != "" {
+			if _, ok := monthNames[strings.ToLower(m[1])]; !ok {
+				return nil
+			}
+			month = capitalize(m[1])
+		}
+		if month == "" {
+			return nil
+		}
+		day := month + " " + m[2]
+		if m[4] != "" {
+			day += "-"
+			if m[3] != "" {
+				if _, ok := monthNames[strings.ToLower(m[3])]; !ok {
+					return nil
+				}
+				month = capitalize(m[3])
+				day += month + " "
+			}
+			day += m[4]
+		}
+		if remaining != "" {
+			day += " " + remaining
 		}
+		result = append(result, day)
 	}
 	return result
 }
@@ -2474,9 +2420,59 @@ func parseMonthDate(s string) (string, int, int, int, int, int, error) {
 	return remaining, month1, month1, 0, 0, 0, nil
 }
 
+// stateKeywords are the rule modifier states, which may follow a comment:
+// "Mo 10:00-12:00 \"by appointment\" unknown"
+var stateKeywords = []string{"open", "closed", "off", "unknown"}
+
+// extractComment returns the rule without its comment, and the comment. The
+// comment may end the rule, come before or after the state ("Mo 10:00-12:00
+// \"note\" open" keeps "open" at the end of the remaining rule), come between
+// the selectors and the times ("Mo \"note\" 10:00-12:00"), or describe the
+// rule before its selectors, with or without a colon ("\"note\": Mo 10:00-12:00").
 func extractComment(s string, oh *OpeningHours) (string, string) {
-	// Look for quoted string at the end
 	s = strings.TrimSpace(s)
+
+	// A comment before the state, like "Mo 10:00-12:00 \"note\" off"
+	lower := strings.ToLower(s)
+	for _, state := range stateKeywords {
+		if !strings.HasSuffix(lower, " "+state) {
+			continue
+		}
+		rest := strings.TrimSpace(s[:len(s)-len(state)])
+		if strings.HasSuffix(rest, "\"") {
+			remaining, comment := extractComment(rest, oh)
+			return strings.TrimSpace(remaining + " " + s[len(s)-len(state):]), comment
+		}
+	}
+
+	// A comment describing the rule, like "\"note\": Mo 10:00-12:00"
+	if strings.HasPrefix(s, "\"") {
+		if end := strings.Index(s[1:], "\""); end != -1 && strings.HasPrefix(strings.TrimSpace(s[end+2:]), ":") {
+			comment := s[1 : end+1]
+			remaining := strings.TrimSpace(strings.TrimSpace(s[end+2:])[1:])
+			if rest, trailing := extractComment(remaining, oh); trailing != "" {
+				return rest, trailing
+			}
+			if comment == "" && oh != nil {
+				oh.addWarning(WarnEmptyComment, `""`, "Empty comment")
+			}
+			return remaining, comment
+		}
+	}
+
+	// A comment before the selectors or between the selectors and the times,
+	// like "\"note\" Mo 10:00-12:00" or "Mo \"note\" 10:00-12:00"
+	if !strings.HasSuffix(s, "\"") && strings.Count(s, "\"") == 2 {
+		start := strings.Index(s, "\"")
+		end := start + 1 + strings.Index(s[start+1:], "\"")
+		comment := s[start+1 : end]
+		if comment == "" && oh != nil {
+			oh.addWarning(WarnEmptyComment, `""`, "Empty comment")
+		}
+		return strings.TrimSpace(strings.TrimSpace(s[:start]) + " " + strings.TrimSpace(s[end+1:])), comment
+	}
+
+	// Look for quoted string at the end
 	if !strings.HasSuffix(s, "\"") {
 		return s, ""
 	}
@@ -2499,7 +2495,7 @@ func extractComment(s string, oh *OpeningHours) (string, string) {
 
 	// Warn if comment is empty
 	if comment == "" && oh != nil {
-		oh.addWarning("Empty comment")
+		oh.addWarning(WarnEmptyComment, `""`, "Empty comment")
 	}
 
 	remaining := strings.TrimSpace(s[:startQuote])
@@ -2507,7 +2503,7 @@ func extractComment(s string, oh *OpeningHours) (string, string) {
 }
 
 var startsWithTimePattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})`)
-var startsWithVariableTimePattern = regexp.MustCompile(`^\(?(sunrise|sunset|dawn|dusk)`)
+var startsWithVariableTimePattern = regexp.MustCompile(`(?i)^\(?(sunrise|sunset|dawn|dusk)`)
 var startsWithShortTimePattern = regexp.MustCompile(`^\d{1,2}-\d{1,2}$`)
 
 func parseWeekdaysAndTimeWithConstraints(s string) ([]bool, []weekdayConstraint, string, error) {
@@ -2523,13 +2519,13 @@ func parseWeekdaysAndTimeWithConstraintsAndHolidays(s string) ([]bool, []weekday
 	}
 
 	// Check if this starts with "PH" (public holiday)
-	if strings.HasPrefix(strings.ToUpper(s), "PH") {
+	if strings.HasPrefix(strings.ToUpper(s), "PH") && !isHolidayList(s) {
 		// PH is handled separately, not as a weekday
 		return nil, nil, s, false, false, nil
 	}
 
 	// Check if this starts with "SH" (school holiday)
-	if strings.HasPrefix(strings.ToUpper(s), "SH") {
+	if strings.HasPrefix(strings.ToUpper(s), "SH") && !isHolidayList(s) {
 		// SH is handled separately, not as a weekday
 		return nil, nil, s, false, false, nil
 	}
@@ -2564,7 +2560,20 @@ func parseWeekdaysAndTimeWithConstraintsAndHolidays(s string) ([]bool, []weekday
 		return nil, nil, s, false, false, nil
 	}
 
-	return weekdays, constraints, parts[1], hasPH, hasSH, nil
+	// A day offset after constrained weekdays, like "Sa[-1] +1 day"
+	rest := parts[1]
+	if match := phOffsetPattern.FindStringSubmatch(rest); match != nil && len(constraints) > 0 {
+		offset, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, nil, "", false, false, fmt.Errorf("invalid weekday offset: %s", match[1])
+		}
+		for i := range constraints {
+			constraints[i].offset = offset
+		}
+		rest = strings.TrimSpace(rest[len(match[0]):])
+	}
+
+	return weekdays, constraints, rest, hasPH, hasSH, nil
 }
 
 func parseWeekdaysAndTime(s string) ([]bool, string, error) {
@@ -2577,6 +2586,36 @@ func parseWeekdays(s string) ([]bool, []weekdayConstraint, error) {
 	return weekdays, constraints, err
 }
 
+// isHolidayList reports whether s starts with PH or SH as the first item of a
+// list of weekdays and holidays, e.g. "PH,Su 10:00-12:00"
+func isHolidayList(s string) bool {
+	return len(s) > 2 && s[2] == ','
+}
+
+// holidayUnionParts splits a rule with holidayUnion into rules that each select
+// either the weekdays or one kind of holiday. The rule matches if any part matches.
+func (r *rule) holidayUnionParts() (parts [3]rule, n int) {
+	base := *r
+	base.holidayUnion, base.isPH, base.isSH = false, false, false
+	if slices.Contains(r.weekdays, true) || len(r.weekdayConstraints) > 0 {
+		parts[n] = base
+		n++
+	}
+
+	base.weekdays, base.weekdayConstraints = nil, nil
+	if r.isPH {
+		parts[n] = base
+		parts[n].isPH = true
+		n++
+	}
+	if r.isSH {
+		parts[n] = base
+		parts[n].isSH = true
+		n++
+	}
+	return parts, n
+}
+
 // parseWeekdaysWithHolidays parses weekdays and also returns flags for PH and SH if found
 func parseWeekdaysWithHolidays(s string) ([]bool, []weekdayConstraint, bool, bool, error) {
 	weekdays := make([]bool, 7)
@@ -2635,6 +2674,81 @@ func parseWeekdaysWithHolidays(s string) ([]bool, []weekdayConstraint, bool, boo
 	return weekdays, constraints, hasPH, hasSH, nil
 }
 
+// joinWeekdaySelectors joins leading weekday selectors separated by spaces or by
+// commas followed by spaces into a single comma-separated selector, e.g.
+// "Mo[1] Tu[2] 10:00-12:00" -> "Mo[1],Tu[2] 10:00-12:00".
+// usedSpaces reports whether any selectors were separated by spaces only.
+func joinWeekdaySelectors(s string) (joined string, usedSpaces bool) {
+	tokens := strings.Fields(s)
+
+	count := 0
+	for count < len(tokens) && isWeekdaySelectorList(tokens[count]) {
+		count++
+	}
+	if count < 2 {
+		return s, false
+	}
+
+	var result strings.Builder
+	for i := 0; i < count; i++ {
+		token := tokens[i]
+		if i > 0 {
+			prev := tokens[i-1]
+			if !strings.HasSuffix(prev, ",") && !strings.HasPrefix(token, ",") {
+				result.WriteString(",")
+				usedSpaces = true
+			}
+		}
+		result.WriteString(token)
+	}
+
+	rest := strings.Join(tokens[count:], " ")
+	if rest == "" {
+		return result.String(), usedSpaces
+	}
+	return result.String() + " " + rest, usedSpaces
+}
+
+// isWeekdaySelectorList reports whether s only consists of weekday selectors
+// like "Mo", "Mo-Fr", "We[1]" or "Mo,Tu[2]," (leading/trailing commas allowed)
+func isWeekdaySelectorList(s string) bool {
+	scratch := make([]bool, 7)
+	found := false
+	var current strings.Builder
+	bracketDepth := 0
+
+	check := func() bool {
+		part := current.String()
+		current.Reset()
+		if part == "" {
+			return true
+		}
+		if _, err := parseWeekdaySelectorWithConstraint(part, scratch); err != nil {
+			return false
+		}
+		found = true
+		return true
+	}
+
+	for _, ch := range s {
+		switch {
+		case ch == '[':
+			bracketDepth++
+			current.WriteRune(ch)
+		case ch == ']':
+			bracketDepth--
+			current.WriteRune(ch)
+		case ch == ',' && bracketDepth == 0:
+			if !check() {
+				return false
+			}
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	return check() && found
+}
+
 // Matches weekday with optional constraint: We, We[1], We[1-3], We[1,3], We[1,3,5]
 var weekdayConstraintPattern = regexp.MustCompile(`^([A-Za-z]{2,3})(\[([^\]]+)\])?$`)
 
@@ -2786,12 +2900,12 @@ func parseTimeRanges(s string, oh *OpeningHours) ([]timeRange, error) {
 			for j := i + 1; j < len(ranges); j++ {
 				// Only check fixed time ranges (not variable times)
 				if ranges[i].start >= 0 && ranges[i].end >= 0 &&
-				   ranges[j].start >= 0 && ranges[j].end >= 0 {
+					ranges[j].start >= 0 && ranges[j].end >= 0 {
 					// Check if ranges overlap (not just touch)
 					// Range i: [start_i, end_i), Range j: [start_j, end_j)
 					// They overlap if start_i < end_j AND start_j < end_i
 					if ranges[i].start < ranges[j].end && ranges[j].start < ranges[i].end {
-						oh.addWarning("Overlapping time ranges detected")
+						oh.addWarning(WarnOverlappingTimes, s, "Overlapping time ranges detected")
 						// Only warn once
 						goto done
 					}
@@ -2811,10 +2925,13 @@ func parseTimeRange(s string, oh *OpeningHours, originalInput string) (timeRange
 	if match := openEndPattern.FindStringSubmatch(s); match != nil {
 		startHour, _ := strconv.Atoi(match[1])
 		startMin, _ := strconv.Atoi(match[2])
+		// A point in time with open end: an event starting at that time, see inOpenEnd
 		return timeRange{
 			start:   startHour*60 + startMin,
 			end:     24 * 60, // End of day
 			openEnd: true,
+			minEnd:  startHour*60 + startMin,
+			point:   true,
 		}, nil
 	}
 
@@ -2824,11 +2941,14 @@ func parseTimeRange(s string, oh *OpeningHours, originalInput string) (timeRange
 	if match := openEndRangePattern.FindStringSubmatch(s); match != nil {
 		startHour, _ := strconv.Atoi(match[1])
 		startMin, _ := strconv.Atoi(match[2])
+		endHour, _ := strconv.Atoi(match[3])
+		endMin, _ := strconv.Atoi(match[4])
 		// endHour and endMin are the "at least until" times, but we extend to end of day
 		return timeRange{
 			start:   startHour*60 + startMin,
 			end:     24 * 60, // Extend to end of day since close time is uncertain
 			openEnd: true,
+			minEnd:  endHour*60 + endMin,
 		}, nil
 	}
 
@@ -2869,6 +2989,10 @@ func parseTimeRange(s string, oh *OpeningHours, originalInput string) (timeRange
 			if variableTimePattern.MatchString(parts[0]) {
 				startPart = parts[0]
 				endPart = strings.Join(parts[1:], "-")
+			} else if singleTimePattern.MatchString(parts[0]) && variableTimePattern.MatchString(strings.Join(parts[1:], "-")) {
+				// A fixed start with a variable end like "08:00-sunset"
+				startPart = parts[0]
+				endPart = strings.Join(parts[1:], "-")
 			}
 		}
 	}
@@ -2942,11 +3066,12 @@ func parseTimeRange(s string, oh *OpeningHours, originalInput string) (timeRange
 		}
 
 		startMinutes := hour*60 + min
-		endMinutes := startMinutes + 1 // 1-minute interval
+		endMinutes := startMinutes + oh.eventMinutes()
 
 		return timeRange{
 			start: startMinutes,
 			end:   endMinutes,
+			point: true,
 		}, nil
 	}
 
@@ -2975,6 +3100,16 @@ func parseTimeRange(s string, oh *OpeningHours, originalInput string) (timeRange
 			interval = intervalHour*60 + intervalMin
 		}
 
+		// Equal start and end (e.g., "We 22:00-22:00") is not a zero-length range:
+		// like the JS reference implementation, it spans midnight and covers 24 hours.
+		// The part on the next day is a continuation like that of "22:00-04:00":
+		// later rules with times for the next day replace it from midnight, and
+		// later off rules close only their own times. This is easy to write by
+		// accident, so warn about it.
+		if startHour*60+startMin == endHour*60+endMin && oh != nil {
+			oh.addWarning(WarnEqualStartEnd, s, fmt.Sprintf("Time range %s has equal start and end and is interpreted as 24 hours", s))
+		}
+
 		return timeRange{
 			start:    startHour*60 + startMin,
 			end:      endHour*60 + endMin,
@@ -3031,251 +3166,3 @@ func parseFixedTime(s string) (int, error) {
 
 	return hour*60 + min, nil
 }
-
-// Iterator provides efficient traversal of state changes
-type Iterator struct {
-	oh      *OpeningHours
-	current time.Time
-}
-
-// GetIterator creates an iterator starting at the given time
-func (oh *OpeningHours) GetIterator(start time.Time) *Iterator {
-	return &Iterator{
-		oh:      oh,
-		current: start,
-	}
-}
-
-// GetDate returns the current time of the iterator
-func (it *Iterator) GetDate() time.Time {
-	return it.current
-}
-
-// SetDate sets the iterator to a specific time
-func (it *Iterator) SetDate(t time.Time) {
-	it.current = t
-}
-
-// GetState returns the opening state at the current iterator time
-func (it *Iterator) GetState() bool {
-	return it.oh.GetState(it.current)
-}
-
-// GetStateString returns the state as a string ("open", "closed", or "unknown")
-func (it *Iterator) GetStateString() string {
-	return it.oh.GetStateString(it.current)
-}
-
-// GetComment returns any comment associated with the current state
-func (it *Iterator) GetComment() string {
-	return it.oh.GetComment(it.current)
-}
-
-// Advance moves the iterator to the next state change and returns the new time
-// Returns zero time if there are no more state changes (e.g., for 24/7 or always closed)
-func (it *Iterator) Advance() time.Time {
-	nextChange := it.oh.GetNextChange(it.current)
-
-	// If there's a next change, update current time
-	if !nextChange.IsZero() {
-		it.current = nextChange
-	}
-
-	return nextChange
-}
-
-// PrettifyValue returns a normalized/canonicalized version of the opening hours string
-func (oh *OpeningHours) PrettifyValue() string {
-	var parts []string
-
-	// Handle special cases first
-	if len(oh.rules) == 1 && oh.rules[0].weekdays == nil && len(oh.rules[0].timeRanges) == 0 {
-		if oh.rules[0].state == StateOpen {
-			return "24/7"
-		} else if oh.rules[0].state == StateClosed {
-			return "off"
-		}
-	}
-
-	// Check if single rule with 00:00-24:00 (equivalent to 24/7)
-	if len(oh.rules) == 1 && oh.rules[0].weekdays == nil && len(oh.rules[0].timeRanges) == 1 {
-		tr := oh.rules[0].timeRanges[0]
-		if tr.start == 0 && tr.end == 1440 && !tr.openEnd && tr.startVar == "" && tr.endVar == "" {
-			return "24/7"
-		}
-	}
-
-	for _, r := range oh.rules {
-		part := prettifyRule(r)
-		if part != "" {
-			parts = append(parts, part)
-		}
-	}
-
-	return strings.Join(parts, "; ")
-}
-
-func prettifyRule(r rule) string {
-	var result strings.Builder
-
-	// Add year if specified
-	if r.yearStart > 0 {
-		if r.yearStart == r.yearEnd {
-			result.WriteString(fmt.Sprintf("%d ", r.yearStart))
-		} else {
-			result.WriteString(fmt.Sprintf("%d-%d ", r.yearStart, r.yearEnd))
-		}
-	}
-
-	// Add month if specified
-	if r.monthStart > 0 {
-		result.WriteString(monthName(r.monthStart))
-		if r.dayStart > 0 {
-			result.WriteString(fmt.Sprintf(" %02d", r.dayStart))
-			if r.dayEnd > r.dayStart {
-				result.WriteString(fmt.Sprintf("-%02d", r.dayEnd))
-			}
-		}
-		if r.monthEnd != r.monthStart {
-			result.WriteString("-")
-			result.WriteString(monthName(r.monthEnd))
-			if r.dayEnd > 0 {
-				result.WriteString(fmt.Sprintf(" %02d", r.dayEnd))
-			}
-		}
-		result.WriteString(" ")
-	}
-
-	// Add weekdays
-	if r.weekdays != nil {
-		result.WriteString(prettifyWeekdays(r.weekdays, r.weekdayConstraints))
-	}
-
-	// Add PH/SH
-	if r.isPH {
-		if result.Len() > 0 {
-			result.WriteString(" ")
-		}
-		result.WriteString("PH")
-		if r.phOffset != 0 {
-			if r.phOffset > 0 {
-				result.WriteString(fmt.Sprintf(" +%d day", r.phOffset))
-			} else {
-				result.WriteString(fmt.Sprintf(" %d day", r.phOffset))
-			}
-		}
-	}
-	if r.isSH {
-		if result.Len() > 0 {
-			result.WriteString(" ")
-		}
-		result.WriteString("SH")
-	}
-
-	// Add time ranges
-	if len(r.timeRanges) > 0 {
-		if result.Len() > 0 {
-			result.WriteString(" ")
-		}
-		timeStrs := make([]string, len(r.timeRanges))
-		for i, tr := range r.timeRanges {
-			timeStrs[i] = prettifyTimeRange(tr)
-		}
-		result.WriteString(strings.Join(timeStrs, ","))
-	}
-
-	// Add state
-	switch r.state {
-	case StateClosed:
-		if result.Len() > 0 {
-			result.WriteString(" ")
-		}
-		result.WriteString("off")
-	case StateUnknown:
-		if result.Len() > 0 {
-			result.WriteString(" ")
-		}
-		result.WriteString("unknown")
-	}
-
-	// Add comment
-	if r.comment != "" {
-		result.WriteString(fmt.Sprintf(" \"%s\"", r.comment))
-	}
-
-	return strings.TrimSpace(result.String())
-}
-
-func prettifyWeekdays(weekdays []bool, constraints []weekdayConstraint) string {
-	// Convert bool array to weekday range string
-	names := []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
-	var parts []string
-
-	// Handle constraints first
-	if len(constraints) > 0 {
-		for _, c := range constraints {
-			name := names[c.weekday]
-			if c.nthTo > 0 {
-				parts = append(parts, fmt.Sprintf("%s[%d-%d]", name, c.nthFrom, c.nthTo))
-			} else {
-				parts = append(parts, fmt.Sprintf("%s[%d]", name, c.nthFrom))
-			}
-		}
-		return strings.Join(parts, ",")
-	}
-
-	// Find ranges in weekdays, starting from Monday (index 1) instead of Sunday (index 0)
-	// This gives more natural output like "Mo-Fr" instead of "Su,Mo-Fr"
-	// However, we need to handle wraparound cases like "Sa-Su" correctly
-
-	// First, try starting from Monday (1) and wrap around if needed
-	startIdx := 1 // Monday
-	for j := 0; j < 7; j++ {
-		i := (startIdx + j) % 7
-		if weekdays[i] {
-			start := i
-			// Find the end of this consecutive range
-			count := 0
-			for count < 7 && weekdays[(start+count)%7] {
-				count++
-			}
-			end := (start + count - 1) % 7
-
-			if count == 1 {
-				// Single day
-				parts = append(parts, names[start])
-			} else if count == 3 {
-				// Exactly 3 consecutive days: list individually
-				for k := 0; k < count; k++ {
-					parts = append(parts, names[(start+k)%7])
-				}
-			} else {
-				// 2 days or 4+ days: use range
-				parts = append(parts, fmt.Sprintf("%s-%s", names[start], names[end]))
-			}
-
-			// Skip the days we've already processed
-			j += count - 1
-		}
-	}
-
-	return strings.Join(parts, ",")
-}
-
-func prettifyTimeRange(tr timeRange) string {
-	startH, startM := tr.start/60, tr.start%60
-	endH, endM := tr.end/60, tr.end%60
-
-	if tr.openEnd {
-		return fmt.Sprintf("%02d:%02d+", startH, startM)
-	}
-	return fmt.Sprintf("%02d:%02d-%02d:%02d", startH, startM, endH, endM)
-}
-
-func monthName(m int) string {
-	names := []string{"", "Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
-	if m >= 1 && m <= 12 {
-		return names[m]
-	}
-	return ""
-}
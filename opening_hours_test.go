@@ -351,6 +351,68 @@ func TestCommentsWithUnknownState(t *testing.T) {
 	}
 }
 
+func TestCommentPositions(t *testing.T) {
+	tests := []struct {
+		value   string
+		state   State
+		comment string
+	}{
+		{`Mo-Fr 08:30-09:00 open "delivery only"`, StateOpen, "delivery only"},
+		{`Mo-Fr 08:30-09:00 "delivery only" open`, StateOpen, "delivery only"},
+		{`Mo-Fr 08:30-09:00 "delivery only" unknown`, StateUnknown, "delivery only"},
+		{`Mo-Fr 08:30-09:00 "delivery only" off`, StateClosed, "delivery only"},
+		{`"delivery only": Mo-Fr 08:30-09:00`, StateOpen, "delivery only"},
+		{`"delivery only": Mo-Fr 08:30-09:00 unknown`, StateUnknown, "delivery only"},
+		{`Mo-Fr "delivery only" off`, StateClosed, "delivery only"},
+		{`Mo-Fr 08:30-09:00 "delivery only" unknown, 10:00-12:00 "shop"`, StateUnknown, "delivery only"},
+		{`"delivery only" Mo-Fr 08:30-09:00`, StateOpen, "delivery only"},
+		{`"delivery only" Mo-Fr 08:30-09:00 unknown`, StateUnknown, "delivery only"},
+		{`Mo-Fr "delivery only" 08:30-09:00`, StateOpen, "delivery only"},
+		{`Mo-Fr "delivery only" 08:30-09:00 off`, StateClosed, "delivery only"},
+		{`Mo-Fr "delivery only" 08:30-09:00; Sa 10:00-12:00`, StateOpen, "delivery only"},
+	}
+
+	monday := time.Date(2024, 1, 15, 8, 45, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			info := oh.GetStateInfo(monday)
+			if info.State != tt.state || info.Comment != tt.comment {
+				t.Errorf("GetStateInfo() = %v %q, want %v %q", info.State, info.Comment, tt.state, tt.comment)
+			}
+		})
+	}
+}
+
+func TestCommentPositions_BetweenTimeRanges(t *testing.T) {
+	oh, err := New(`Mo 08:30-09:00 "delivery only" unknown, 10:00-12:00 "shop"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	at := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	if info := oh.GetStateInfo(at); info.State != StateOpen || info.Comment != "shop" {
+		t.Errorf("GetStateInfo() = %v %q, want open %q", info.State, info.Comment, "shop")
+	}
+	if got, want := oh.PrettifyValue(), `Mo 08:30-09:00 unknown "delivery only", Mo 10:00-12:00 "shop"`; got != want {
+		t.Errorf("PrettifyValue() = %q, want %q", got, want)
+	}
+}
+
+func TestCommentPositions_Prettify(t *testing.T) {
+	for _, value := range []string{`"delivery only" Mo-Fr 08:30-09:00`, `Mo-Fr "delivery only" 08:30-09:00`} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if got, want := oh.PrettifyValue(), `Mo-Fr 08:30-09:00 "delivery only"`; got != want {
+			t.Errorf("%q: PrettifyValue() = %q, want %q", value, got, want)
+		}
+	}
+}
+
 func TestGetNextChange_CurrentlyOpen(t *testing.T) {
 	// Currently open, should return closing time
 	oh, err := New("09:00-17:00")
@@ -734,3 +796,132 @@ func TestIsWeekStable_ComplexStable(t *testing.T) {
 	}
 }
 
+
+func TestCommaGroupsWithDifferentMonths(t *testing.T) {
+	// Comma-separated rules may start with month selectors, not only weekdays
+	oh, err := New("Jan Mo-Fr 10:00-16:00, Jul-Aug Mo-Fr 08:00-20:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		date time.Time
+		want bool
+		desc string
+	}{
+		{time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), true, "Jan 15 (Monday) 11:00 - January hours"},
+		{time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), false, "Jan 15 (Monday) 09:00 - before January opening"},
+		{time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC), true, "Jul 15 (Monday) 09:00 - summer hours"},
+		{time.Date(2024, 7, 15, 17, 0, 0, 0, time.UTC), true, "Jul 15 (Monday) 17:00 - summer hours"},
+		{time.Date(2024, 7, 13, 12, 0, 0, 0, time.UTC), false, "Jul 13 (Saturday) - weekend"},
+		{time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC), false, "Mar 15 (Friday) - month not covered"},
+	}
+
+	for _, tt := range tests {
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestCommaGroupsWithSunTimes(t *testing.T) {
+	// "sunrise" contains "su" but must not be mistaken for a weekday selector
+	oh, err := New("Mo sunrise-sunset, Tu 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Tuesday Jan 16, 2024
+	if !oh.GetState(time.Date(2024, 1, 16, 11, 0, 0, 0, time.UTC)) {
+		t.Error("expected open on Tuesday 11:00")
+	}
+	if oh.GetState(time.Date(2024, 1, 16, 14, 0, 0, 0, time.UTC)) {
+		t.Error("expected closed on Tuesday 14:00")
+	}
+}
+
+// TestMidnightSpanning_CommaGroupFollowedByDayRange tests that a midnight-spanning
+// range of a comma-separated group is not extended to the end of the next day's range
+func TestMidnightSpanning_CommaGroupFollowedByDayRange(t *testing.T) {
+	oh, err := New("We 18:00-01:00, Th 18:00-20:00")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2024, 1, 18, 0, 30, 0, 0, time.UTC), true},
+		{time.Date(2024, 1, 18, 1, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 1, 18, 3, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 1, 18, 19, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		if got := oh.GetState(tt.time); got != tt.expected {
+			t.Errorf("at %v: GetState = %v, want %v", tt.time, got, tt.expected)
+		}
+	}
+}
+
+// TestAllDayTime tests "24/7" used as the time of a rule with selectors
+func TestAllDayTime(t *testing.T) {
+	tests := []struct {
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		{"Fr-Su 24/7", time.Date(2024, 1, 20, 3, 0, 0, 0, time.UTC), true},
+		{"Fr-Su 24/7", time.Date(2024, 1, 17, 3, 0, 0, 0, time.UTC), false},
+		{"May-Sep 24/7", time.Date(2024, 6, 8, 23, 30, 0, 0, time.UTC), true},
+		{"May-Sep 24/7", time.Date(2024, 10, 8, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Fr 08:00-18:00, Sa 24/7", time.Date(2024, 1, 20, 20, 0, 0, 0, time.UTC), true},
+		{"24/7; Sa 24/7 off", time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC), false},
+		{"24/7; Sa 24/7 off", time.Date(2024, 1, 21, 12, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("New(%q): unexpected parse error: %v", tt.value, err)
+		}
+		if got := oh.GetState(tt.time); got != tt.expected {
+			t.Errorf("New(%q).GetState(%v) = %v, want %v", tt.value, tt.time, got, tt.expected)
+		}
+	}
+}
+
+// TestMidnightSpanning_MonthRange tests that the part after midnight of a
+// range belongs to the day the range starts on, with all its selectors
+func TestMidnightSpanning_MonthRange(t *testing.T) {
+	oh, err := New("Nov-Feb Fr,Sa 20:00-03:00")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2025, 3, 1, 1, 0, 0, 0, time.UTC), true},   // Saturday, from Friday Feb 28
+		{time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC), false},  // Saturday, after the range
+		{time.Date(2025, 3, 1, 21, 0, 0, 0, time.UTC), false}, // Saturday March 1 evening
+		{time.Date(2025, 3, 2, 1, 0, 0, 0, time.UTC), false},  // Sunday, from Saturday March 1
+		{time.Date(2024, 11, 1, 1, 0, 0, 0, time.UTC), false}, // Friday, from Thursday Oct 31
+		{time.Date(2024, 11, 2, 1, 0, 0, 0, time.UTC), true},  // Saturday, from Friday Nov 1
+		{time.Date(2024, 11, 3, 1, 0, 0, 0, time.UTC), true},  // Sunday, from Saturday Nov 2
+	}
+	for _, tt := range tests {
+		if got := oh.GetState(tt.time); got != tt.expected {
+			t.Errorf("at %v: GetState = %v, want %v", tt.time, got, tt.expected)
+		}
+	}
+
+	from := time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC)
+	got := oh.GetOpenIntervals(from, from.AddDate(0, 0, 4))
+	want := [][2]time.Time{
+		{time.Date(2025, 2, 28, 20, 0, 0, 0, time.UTC), time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)},
+	}
+	if len(got) != len(want) || !got[0].Start.Equal(want[0][0]) || !got[0].End.Equal(want[0][1]) {
+		t.Errorf("GetOpenIntervals() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package openinghours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptions_EqualToSetters(t *testing.T) {
+	berlin := loadBerlin(t)
+	value := "Mo-Fr sunrise-sunset; PH off; SH 10:00-12:00"
+	hc := &mockHolidayChecker{holidays: map[string]bool{"2024-01-01": true}}
+	shc := &mockSchoolHolidayChecker{holidays: map[string]bool{"2024-01-03": true}}
+
+	withOptions, err := New(value,
+		WithCoordinates(52.52, 13.40),
+		WithHolidayChecker(hc),
+		WithSchoolHolidayChecker(shc),
+		WithTimezone(berlin),
+	)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	withSetters, err := New(value)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	withSetters.SetCoordinates(52.52, 13.40)
+	withSetters.SetHolidayChecker(hc)
+	withSetters.SetSchoolHolidayChecker(shc)
+	withSetters.SetTimezone(berlin)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	got := withOptions.GetOpenIntervals(from, to)
+	want := withSetters.GetOpenIntervals(from, to)
+	if len(got) == 0 || len(got) != len(want) {
+		t.Fatalf("got %d intervals, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) || got[i].Start.Location() != berlin {
+			t.Errorf("interval %d = %v-%v, want %v-%v in Berlin", i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+	}
+
+	// Holiday on Monday, school holiday on Wednesday
+	if withOptions.GetState(time.Date(2024, 1, 1, 12, 0, 0, 0, berlin)) {
+		t.Errorf("expected closed on the public holiday")
+	}
+	if withOptions.GetState(time.Date(2024, 1, 3, 13, 0, 0, 0, berlin)) {
+		t.Errorf("expected closed after the school holiday hours")
+	}
+}
+
+func TestOptions_StrictMode(t *testing.T) {
+	if _, err := New("Mo-Fr 09:00-17:00; PH off", WithStrictMode()); err != nil {
+		t.Errorf("unexpected error for a canonical value: %v", err)
+	}
+
+	_, err := New("Mo - Fr 9-17", WithStrictMode())
+	if err == nil || !strings.Contains(err.Error(), "strict mode") {
+		t.Fatalf("expected a strict mode error, got %v", err)
+	}
+
+	// The same value parses with warnings by default
+	oh, err := New("Mo - Fr 9-17")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(oh.GetWarnings()) == 0 {
+		t.Errorf("expected warnings without strict mode")
+	}
+
+	// Options apply to every value of a Parser
+	p := NewParser(WithStrictMode())
+	if _, err := p.Parse("Mo-Fr 09:00-17:00"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := p.Parse("Mo-Fr 9-17"); err == nil {
+		t.Errorf("expected a strict mode error from the parser")
+	}
+}
@@ -0,0 +1,75 @@
+package openinghours
+
+import "sync"
+
+// Parser parses many values with the same options while reusing memory between
+// parses. It is meant for bulk workloads (e.g. pipelines over millions of OSM
+// objects) where allocating a fresh OpeningHours per value dominates GC time.
+// A Parser is safe for concurrent use.
+type Parser struct {
+	opts []Option
+	pool sync.Pool
+}
+
+// NewParser returns a Parser that applies opts to every parsed value, like New
+func NewParser(opts ...Option) *Parser {
+	return &Parser{opts: opts}
+}
+
+// Parse parses value like New. The result may reuse the memory of an
+// OpeningHours previously passed to Release.
+func (p *Parser) Parse(value string) (*OpeningHours, error) {
+	oh, _ := p.pool.Get().(*OpeningHours)
+	if oh == nil {
+		oh = &OpeningHours{}
+	}
+	if err := p.ParseInto(oh, value); err != nil {
+		p.pool.Put(oh)
+		return nil, err
+	}
+	return oh, nil
+}
+
+// ParseInto parses value into the caller-provided oh, reusing its rule and
+// warning slices. Everything previously set on oh is reset, including holiday
+// checkers and coordinates, and slices returned by earlier calls like
+// GetWarnings must no longer be used. On error oh is left empty.
+func (p *Parser) ParseInto(oh *OpeningHours, value string) error {
+	oh.reset()
+	for _, opt := range p.opts {
+		opt(oh)
+	}
+	if err := oh.parse(value); err != nil {
+		oh.reset()
+		return err
+	}
+	return nil
+}
+
+// Release returns oh to the parser for reuse by a later Parse. oh must not be
+// used after it has been released.
+func (p *Parser) Release(oh *OpeningHours) {
+	if oh != nil {
+		p.pool.Put(oh)
+	}
+}
+
+// reset clears oh while keeping the capacity of its slices
+func (oh *OpeningHours) reset() {
+	*oh = OpeningHours{
+		rules:          oh.rules[:0],
+		fallbackGroups: oh.fallbackGroups[:0],
+		ruleOrder:      oh.ruleOrder[:0],
+		warnings:       oh.warnings[:0],
+		sunCache:       newDayCache[sunKey, sunMinutes](),
+	}
+}
+
+// reusableFallbackGroup returns an empty rule slice for the i-th fallback group,
+// backed by the group of a previous parse if oh has been reset
+func (oh *OpeningHours) reusableFallbackGroup(i int) []rule {
+	if i < cap(oh.fallbackGroups) {
+		return oh.fallbackGroups[:cap(oh.fallbackGroups)][i][:0]
+	}
+	return nil
+}
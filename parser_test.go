@@ -0,0 +1,133 @@
+package openinghours
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+var parserTestValues = []string{
+	"Mo-Fr 09:00-17:00",
+	"24/7",
+	"off",
+	"Mo-Fr 08:00-12:00,13:00-18:00; Sa 10:00-14:00; PH off",
+	"Mo-Fr 10:00-16:00, We 12:00-18:00",
+	"Mo-Fr 09:00-17:00 || Sa 10:00-12:00 unknown || \"by appointment\"",
+	"9-17",
+}
+
+func TestParser_MatchesNew(t *testing.T) {
+	p := NewParser()
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	for _, value := range parserTestValues {
+		want, err := New(value)
+		if err != nil {
+			t.Fatalf("unexpected parse error for %q: %v", value, err)
+		}
+		got, err := p.Parse(value)
+		if err != nil {
+			t.Fatalf("Parser.Parse(%q) returned error: %v", value, err)
+		}
+
+		if len(got.GetOpenIntervals(from, to)) != len(want.GetOpenIntervals(from, to)) || !got.IsEqualTo(want) {
+			t.Errorf("Parser.Parse(%q) differs from New", value)
+		}
+		if len(got.GetWarnings()) != len(want.GetWarnings()) {
+			t.Errorf("Parser.Parse(%q) warnings = %v, want %v", value, got.GetWarnings(), want.GetWarnings())
+		}
+		p.Release(got)
+	}
+}
+
+func TestParser_ParseIntoResets(t *testing.T) {
+	p := NewParser()
+	oh := &OpeningHours{}
+
+	if err := p.ParseInto(oh, "Mo-Fr 09:00-17:00 || Sa 10:00-12:00 || Su 10:00-11:00; 9-17"); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-01-15": true}})
+	oh.SetCoordinates(52.52, 13.405)
+
+	if err := p.ParseInto(oh, "Mo-Fr 09:00-17:00; PH off"); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(oh.fallbackGroups) != 0 {
+		t.Errorf("fallback groups of the previous value were kept: %d", len(oh.fallbackGroups))
+	}
+	if len(oh.GetWarnings()) != 0 {
+		t.Errorf("warnings of the previous value were kept: %v", oh.GetWarnings())
+	}
+	// Holiday checker was reset, so Monday Jan 15 is a regular day
+	if !oh.GetState(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected open on Monday 10:00 after reset")
+	}
+
+	if err := p.ParseInto(oh, "invalid value here"); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if len(oh.rules) != 0 {
+		t.Errorf("expected empty OpeningHours after error, got %d rules", len(oh.rules))
+	}
+}
+
+func TestParser_Options(t *testing.T) {
+	upper := func(s string) string { return "Mo-Fr 09:00-17:00" }
+	p := NewParser(WithNormalizers(upper))
+
+	oh, err := p.Parse("anything")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if !oh.GetState(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Error("expected parser options to be applied")
+	}
+}
+
+func TestParser_Concurrent(t *testing.T) {
+	p := NewParser()
+	monday := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				oh, err := p.Parse("Mo-Fr 09:00-17:00; Sa 10:00-12:00")
+				if err != nil {
+					t.Errorf("unexpected parse error: %v", err)
+					return
+				}
+				if !oh.GetState(monday) {
+					t.Error("expected open on Monday 10:00")
+				}
+				p.Release(oh)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkParseNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := New(parserTestValues[i%len(parserTestValues)]); err != nil {
+			b.Fatalf("unexpected parse error: %v", err)
+		}
+	}
+}
+
+func BenchmarkParseParser(b *testing.B) {
+	p := NewParser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		oh, err := p.Parse(parserTestValues[i%len(parserTestValues)])
+		if err != nil {
+			b.Fatalf("unexpected parse error: %v", err)
+		}
+		p.Release(oh)
+	}
+}
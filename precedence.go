@@ -0,0 +1,223 @@
+package openinghours
+
+import "time"
+
+// Rule precedence
+//
+// Every group of rules (the primary group and each fallback group after "||")
+// is evaluated in an order fixed at parse time by orderRules. The first rule in
+// that order whose selectors and times match t decides the state. Later rules
+// precede earlier ones, so "Mo-Fr 10:00-18:00; Fr off" is closed on Friday.
+//
+// Besides matching, a rule can claim the days its selectors match, which hides
+// earlier rules on those days even outside of the rule's times. How a rule
+// claims days depends on its kind:
+//
+//   - ruleOverride: an open rule with times, separated by ";". It claims its
+//     days, so "Mo-Fr 10:00-16:00; We 12:00-18:00" is closed on Wednesday at
+//     11:00. Earlier rules with the same selector are not hidden, their times
+//     add up ("Mo 10:00-12:00; Mo 14:00-16:00").
+//   - ruleAdditional: an open rule with times following another rule after
+//     ",". It claims nothing, so it augments the rules before it rather than
+//     hiding them ("Mo-Fr 10:00-12:00, We 14:00-16:00"). The first rule of the
+//     comma-separated expression claims its days like any rule after ";".
+//   - ruleModifier: any other rule, e.g. "Fr 12:00-14:00 off", "PH unknown" or
+//     "Sa". It only applies during its times and claims nothing, also within a
+//     comma-separated expression ("Mo-Fr 08:00-18:00, PH off").
+//
+// A new selector only needs to be matched by matchesSelectorWithOH and
+// matchesWithOH; its precedence follows from the kind of its rule.
+
+// ruleKind describes how a rule claims the days matched by its selectors
+type ruleKind int
+
+const (
+	ruleModifier ruleKind = iota
+	ruleOverride
+	ruleAdditional
+)
+
+// ruleRef is an entry of the evaluation order of a group of rules
+type ruleRef struct {
+	index int      // index of the rule in its group
+	kind  ruleKind // how the rule claims its days
+}
+
+// kind returns how r claims the days matched by its selectors. additional is
+// true if r follows another rule of its comma-separated expression.
+func (r *rule) kind(additional bool) ruleKind {
+	if r.state != StateOpen || len(r.timeRanges) == 0 {
+		return ruleModifier
+	}
+	if additional {
+		return ruleAdditional
+	}
+	return ruleOverride
+}
+
+// orderRules returns the evaluation order of rules, highest precedence first
+func orderRules(rules []rule, order []ruleRef) []ruleRef {
+	order = order[:0]
+	for i := len(rules) - 1; i >= 0; i-- {
+		additional := rules[i].ruleGroup > 0 && i > 0 && rules[i-1].ruleGroup == rules[i].ruleGroup
+		order = append(order, ruleRef{index: i, kind: rules[i].kind(additional)})
+	}
+	return order
+}
+
+// orderAllRules sets the evaluation order of the primary and fallback groups
+// and the comma-separated groups of the primary rules, and precomputes the week
+// of week stable values. It must be called whenever the rules change.
+func (oh *OpeningHours) orderAllRules() {
+	oh.ruleOrder = orderRules(oh.rules, oh.ruleOrder)
+	oh.fallbackOrders = oh.fallbackOrders[:0]
+	for _, group := range oh.fallbackGroups {
+		oh.fallbackOrders = append(oh.fallbackOrders, orderRules(group, nil))
+	}
+	oh.commaGroups = groupRules(oh.rules)
+	oh.weekStates = oh.computeWeekStates()
+}
+
+// groupRules returns the indexes of the rules of each comma-separated group in
+// order of their first rule, e.g. for extended midnight continuation
+func groupRules(rules []rule) [][]int {
+	var groups [][]int
+	position := make(map[int]int) // ruleGroup -> index in groups
+	for i, r := range rules {
+		if r.ruleGroup == 0 {
+			continue
+		}
+		g, ok := position[r.ruleGroup]
+		if !ok {
+			g = len(groups)
+			position[r.ruleGroup] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// firstMatch returns the index of the rule of group with the highest precedence
+// that matches t, or -1 if no rule matches. order is the evaluation order of group.
+func (oh *OpeningHours) firstMatch(group []rule, order []ruleRef, t time.Time) int {
+	for _, ref := range order {
+		if group[ref.index].matchesWithOH(t, oh.holidayChecker, oh) {
+			return ref.index
+		}
+	}
+	return -1
+}
+
+// Fallback groups
+//
+// The groups are evaluated in order, each as described above. A group applies
+// at t if one of its rules matches t and is not hidden by a claim; otherwise the
+// next group is evaluated, so "Mo-Fr 10:00-16:00 || \"call us\"" is unknown on
+// Monday at 17:00. If the matching rule is unknown, the following groups may
+// resolve it: "Mo-Fr unknown || Mo-Fr 09:00-17:00" is open on Monday at 10:00.
+// If none does, the state stays unknown. GetState, GetUnknown, GetComment and
+// GetMatchingRule, and the intervals and changes built on them, all use this
+// single evaluation, see evaluate.
+
+// evaluation is the outcome of evaluating all groups of rules at a time
+type evaluation struct {
+	state   State
+	group   int    // 0 for the primary group, n for the nth fallback group, -1 if no group applies
+	index   int    // index of the deciding rule in its group, -1 if none
+	comment string // see GetComment
+}
+
+// rulesOf returns the rules and evaluation order of group g, see evaluation
+func (oh *OpeningHours) rulesOf(g int) ([]rule, []ruleRef) {
+	if g == 0 {
+		return oh.rules, oh.ruleOrder
+	}
+	return oh.fallbackGroups[g-1], oh.fallbackOrders[g-1]
+}
+
+// evaluate resolves the state at t across the primary and fallback groups. The
+// comment is the one of the rule that resolves the state, or if that rule has
+// none, of the first unknown rule with one, e.g. "Mo-Fr unknown \"call us\" ||
+// Mo-Fr 10:00-16:00".
+func (oh *OpeningHours) evaluate(t time.Time) evaluation {
+	if c, ok := oh.closureAt(t); ok {
+		return evaluation{state: StateClosed, group: -1, index: -1, comment: c.comment}
+	}
+	result := evaluation{state: StateClosed, group: -1, index: -1}
+	unknownComment := ""
+	for g := 0; g <= len(oh.fallbackGroups); g++ {
+		e, applies := oh.evaluateGroup(g, t)
+		if !applies {
+			continue
+		}
+		result = e
+		if e.state != StateUnknown {
+			if result.comment == "" {
+				result.comment = unknownComment
+			}
+			break
+		}
+		if group, _ := oh.rulesOf(g); unknownComment == "" && e.index >= 0 {
+			unknownComment = group[e.index].comment
+		}
+		if unknownComment != "" {
+			result.comment = unknownComment
+		}
+	}
+	if oh.holidaysUnknown(t) || oh.schoolHolidaysUnknown(t) {
+		result.state = StateUnknown
+	}
+	return result
+}
+
+// evaluateGroup evaluates group g at t. applies is false if no rule of the
+// group matches t, or the matching rule is hidden by a claim.
+func (oh *OpeningHours) evaluateGroup(g int, t time.Time) (e evaluation, applies bool) {
+	group, order := oh.rulesOf(g)
+	matched := func(i int) evaluation {
+		r := &group[i]
+		return evaluation{state: oh.ruleState(r, t), group: g, index: i, comment: oh.ruleComment(r, t)}
+	}
+
+	// Extended midnight continuation of comma-separated primary rules, e.g.
+	// "Su-Tu 11:00-01:00, We-Th 11:00-03:00" is open on Wednesday at 02:00
+	if g == 0 && oh.checkExtendedMidnightContinuation(t) {
+		e = evaluation{group: g, index: -1}
+		if i := oh.firstMatch(group, order, t); i >= 0 {
+			e = matched(i)
+		}
+		e.state = StateOpen
+		return e, true
+	}
+
+	var claim *rule
+	for _, ref := range order {
+		r := &group[ref.index]
+		if r.matchesWithOH(t, oh.holidayChecker, oh) {
+			e = matched(ref.index)
+			// An unknown rule defers to the following groups even if its day is claimed
+			if e.state != StateUnknown && claim != nil && !oh.hasSameSelector(claim, r, t) {
+				return evaluation{}, false
+			}
+			return e, true
+		}
+		if claim == nil && ref.kind == ruleOverride && r.matchesSelectorWithOH(t, oh.holidayChecker, oh) {
+			claim = r
+		}
+	}
+	return evaluation{}, false
+}
+
+// ruleIndex returns the index of the deciding rule of e in Rules(), or -1
+func (oh *OpeningHours) ruleIndex(e evaluation) int {
+	if e.index < 0 {
+		return -1
+	}
+	index := e.index
+	for g := 0; g < e.group; g++ {
+		group, _ := oh.rulesOf(g)
+		index += len(group)
+	}
+	return index
+}
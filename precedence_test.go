@@ -0,0 +1,77 @@
+package openinghours
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRuleOrder_Kinds(t *testing.T) {
+	oh, err := New("Mo-Fr 10:00-16:00; We 12:00-18:00, Sa 10:00-12:00; Fr 12:00-13:00 off; PH unknown || Su 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	want := []ruleRef{
+		{index: 4, kind: ruleModifier},
+		{index: 3, kind: ruleModifier},
+		{index: 2, kind: ruleAdditional},
+		{index: 1, kind: ruleOverride},
+		{index: 0, kind: ruleOverride},
+	}
+	if !reflect.DeepEqual(oh.ruleOrder, want) {
+		t.Errorf("ruleOrder = %+v, want %+v", oh.ruleOrder, want)
+	}
+	if len(oh.fallbackOrders) != 1 || !reflect.DeepEqual(oh.fallbackOrders[0], []ruleRef{{index: 0, kind: ruleOverride}}) {
+		t.Errorf("fallbackOrders = %+v", oh.fallbackOrders)
+	}
+}
+
+func TestRuleOrder_Precedence(t *testing.T) {
+	testCases := []struct {
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		// A later open rule with times claims its days
+		{"Mo-Fr 10:00-16:00; We 12:00-18:00", time.Date(2024, 1, 17, 11, 0, 0, 0, time.UTC), false},
+		{"Mo-Fr 10:00-16:00; We 12:00-18:00", time.Date(2024, 1, 18, 11, 0, 0, 0, time.UTC), true},
+		// Rules with the same selector add up
+		{"Mo 10:00-12:00; Mo 14:00-16:00", time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), true},
+		// Comma-separated rules never hide each other
+		{"Mo-Fr 10:00-12:00, We 14:00-16:00", time.Date(2024, 1, 17, 11, 0, 0, 0, time.UTC), true},
+		// The first rule of a comma-separated expression claims its days
+		{"Mo-Fr 10:00-16:00; We 12:00-18:00, Sa 10:00-12:00", time.Date(2024, 1, 17, 11, 0, 0, 0, time.UTC), false},
+		{"Mo-Fr 10:00-16:00; We 12:00-18:00, Sa 10:00-12:00", time.Date(2024, 1, 17, 13, 0, 0, 0, time.UTC), true},
+		// Modifiers only apply during their times
+		{"Mo-Fr 10:00-16:00; Fr 12:00-13:00 off", time.Date(2024, 1, 19, 11, 0, 0, 0, time.UTC), true},
+		{"Mo-Fr 10:00-16:00; Fr 12:00-13:00 off", time.Date(2024, 1, 19, 12, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tc.value, err)
+		}
+		if got := oh.GetState(tc.time); got != tc.expected {
+			t.Errorf("%q at %v: GetState = %v, want %v", tc.value, tc.time, got, tc.expected)
+		}
+	}
+}
+
+func TestRuleOrder_ParserReuse(t *testing.T) {
+	p := NewParser()
+	oh, err := p.Parse("Mo 10:00-12:00; Tu 10:00-12:00; We 10:00-12:00 || unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	p.Release(oh)
+
+	oh, err = p.Parse("24/7")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(oh.ruleOrder) != 1 || len(oh.fallbackOrders) != 0 {
+		t.Errorf("ruleOrder = %+v, fallbackOrders = %+v", oh.ruleOrder, oh.fallbackOrders)
+	}
+}
@@ -0,0 +1,93 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPrecision_GetStateWithinMinute(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 15, 2024 is Monday
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2024, 1, 15, 8, 59, 59, 999999999, time.UTC), false},
+		{time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 1, 15, 16, 59, 59, 0, time.UTC), true},
+		{time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 1, 15, 17, 0, 30, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		if got := oh.GetState(tt.time); got != tt.expected {
+			t.Errorf("GetState(%v) = %v, want %v", tt.time, got, tt.expected)
+		}
+	}
+}
+
+func TestPrecision_GetOpenDurationPartialMinutes(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 15, 16, 59, 30, 0, time.UTC)
+	to := time.Date(2024, 1, 15, 17, 0, 30, 0, time.UTC)
+
+	open, unknown := oh.GetOpenDuration(from, to)
+	if open != 30*time.Second {
+		t.Errorf("open duration = %v, want 30s", open)
+	}
+	if unknown != 0 {
+		t.Errorf("unknown duration = %v, want 0", unknown)
+	}
+
+	from = time.Date(2024, 1, 15, 8, 59, 45, 0, time.UTC)
+	to = time.Date(2024, 1, 15, 9, 1, 15, 0, time.UTC)
+
+	open, _ = oh.GetOpenDuration(from, to)
+	if open != 75*time.Second {
+		t.Errorf("open duration = %v, want 1m15s", open)
+	}
+}
+
+func TestPrecision_IntervalsEndOnMinuteBoundary(t *testing.T) {
+	oh, err := New("Mo-Fr 10:00-12:00 unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 15, 10, 0, 30, 0, time.UTC)
+	to := time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC)
+
+	intervals := oh.GetOpenIntervals(from, to)
+	if len(intervals) != 1 {
+		t.Fatalf("expected 1 interval, got %d: %v", len(intervals), intervals)
+	}
+
+	if !intervals[0].Start.Equal(from) {
+		t.Errorf("interval start = %v, want %v", intervals[0].Start, from)
+	}
+	wantEnd := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if !intervals[0].End.Equal(wantEnd) {
+		t.Errorf("interval end = %v, want %v", intervals[0].End, wantEnd)
+	}
+}
+
+func TestPrecision_GetNextChangeFromMidMinute(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 15, 16, 59, 59, 0, time.UTC)
+	want := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+	if got := oh.GetNextChange(from); !got.Equal(want) {
+		t.Errorf("GetNextChange(%v) = %v, want %v", from, got, want)
+	}
+}
@@ -0,0 +1,548 @@
+package openinghours
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PrettifyOption configures optional transformations of PrettifyValueWithOptions
+type PrettifyOption func(*prettifyOptions)
+
+type prettifyOptions struct {
+	PrettifyOptions
+	mergeWeekdays bool
+}
+
+// PrettifyOptions controls the formatting of PrettifyValueWithOptions. The zero
+// value formats like PrettifyValue. Values formatted with localized names can
+// not be parsed again.
+type PrettifyOptions struct {
+	RuleSeparator  string     // between rules, "; " if empty
+	ListSeparator  string     // between months, weekdays, holidays and time ranges, "," if empty
+	NoLeadingZeros bool       // "9:00" instead of "09:00" and "Jan 5" instead of "Jan 05"
+	WeekdayNames   [7]string  // names for Su-Sa, the two-letter English names if empty
+	MonthNames     [12]string // names for Jan-Dec, the three-letter English names if empty
+	StateNames     [3]string  // names for the states by State, "open", "off" and "unknown" if empty
+}
+
+var defaultWeekdayNames = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+var defaultMonthNames = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// WithMergedWeekdays merges consecutive rules that differ only in their weekdays,
+// e.g. "Mo 09:00-17:00; Tu 09:00-17:00; We 09:00-17:00" becomes "Mo-We 09:00-17:00".
+// Runs of three weekdays are written as a range instead of a list.
+func WithMergedWeekdays() PrettifyOption {
+	return func(o *prettifyOptions) {
+		o.mergeWeekdays = true
+	}
+}
+
+// WithPrettifyOptions sets separators, leading zeros and weekday/month names
+func WithPrettifyOptions(po PrettifyOptions) PrettifyOption {
+	return func(o *prettifyOptions) {
+		o.PrettifyOptions = po
+	}
+}
+
+// prettifyLocales are the PrettifyOptions of PrettifyValueLocale by language
+var prettifyLocales = map[string]PrettifyOptions{
+	"en": {},
+	"de": {
+		WeekdayNames: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		MonthNames:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		StateNames:   [3]string{"geöffnet", "geschlossen", "unbekannt"},
+	},
+	"fr": {
+		WeekdayNames: [7]string{"di", "lu", "ma", "me", "je", "ve", "sa"},
+		MonthNames:   [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+		StateNames:   [3]string{"ouvert", "fermé", "inconnu"},
+	},
+}
+
+// PrettifyValueLocale is like PrettifyValue with the weekday, month and state
+// names of a language: "en", "de" or "fr", optionally with a region like
+// "de-AT". Like opening_hours.js with a prettify locale, it is meant to show
+// values to local mappers; the syntax is kept, but the result can not be
+// parsed again. An empty locale is the language of the region if supported
+// (see SetRegion), or English.
+func (oh *OpeningHours) PrettifyValueLocale(locale string) (string, error) {
+	if locale == "" {
+		locale = oh.language()
+		if _, ok := prettifyLocales[locale]; !ok {
+			locale = "en"
+		}
+	}
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	language, _, _ = strings.Cut(language, "_")
+	po, ok := prettifyLocales[language]
+	if !ok {
+		return "", fmt.Errorf("unsupported locale: %s", locale)
+	}
+	return oh.prettify(prettifyOptions{PrettifyOptions: po}), nil
+}
+
+// PrettifyValue returns a normalized/canonicalized version of the opening hours string.
+// Parsing the result yields an OpeningHours that is equal to oh (see IsEqualTo).
+func (oh *OpeningHours) PrettifyValue() string {
+	return oh.prettify(prettifyOptions{})
+}
+
+// PrettifyValueWithOptions is like PrettifyValue but applies the given opt-in
+// transformations. Without options it returns the same value as PrettifyValue.
+func (oh *OpeningHours) PrettifyValueWithOptions(opts ...PrettifyOption) string {
+	var o prettifyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return oh.prettify(o)
+}
+
+func (oh *OpeningHours) prettify(o prettifyOptions) string {
+	// Check if single rule with 00:00-24:00 (equivalent to 24/7)
+	if len(oh.rules) == 1 && len(oh.fallbackGroups) == 0 && !oh.rules[0].hasSelectors() &&
+		oh.rules[0].state == StateOpen && oh.rules[0].comment == "" && len(oh.rules[0].timeRanges) == 1 {
+		tr := oh.rules[0].timeRanges[0]
+		if tr.start == 0 && tr.end == 1440 && !tr.openEnd && tr.startVar == "" && tr.endVar == "" && tr.interval == 0 {
+			return "24/7"
+		}
+	}
+
+	groups := make([]string, 0, 1+len(oh.fallbackGroups))
+	for _, rules := range append([][]rule{oh.rules}, oh.fallbackGroups...) {
+		groups = append(groups, prettifyRuleGroup(rules, o))
+	}
+	return strings.Join(groups, " || ")
+}
+
+// prettifyRuleGroup joins the rules of a group. Rules from the same comma-separated
+// expression are joined with a comma, since they are evaluated together.
+func prettifyRuleGroup(rules []rule, o prettifyOptions) string {
+	if o.mergeWeekdays {
+		rules = mergeWeekdayRules(rules)
+	}
+
+	separator := o.RuleSeparator
+	if separator == "" {
+		separator = "; "
+	}
+
+	var result strings.Builder
+	for i := 0; i < len(rules); i++ {
+		r := rules[i]
+		// Rules expanded from a month list are written as the list again
+		list := 1
+		for i+list < len(rules) && continuesMonthList(rules[i+list-1], rules[i+list]) {
+			list++
+		}
+		part := prettifyMonthList(rules[i:i+list], o)
+		i += list - 1
+		if part == "" {
+			continue
+		}
+		if result.Len() > 0 {
+			if r.ruleGroup > 0 && i > 0 && rules[i-1].ruleGroup == r.ruleGroup {
+				// Times without selectors would continue the time list of an
+				// open rule: "10:00-12:00 open, 14:00-16:00 unknown"
+				if prev := rules[i-1]; !r.hasSelectors() && prev.state == StateOpen && prev.comment == "" && len(prev.timeRanges) > 0 {
+					result.WriteString(" open")
+				}
+				result.WriteString(", ")
+			} else {
+				result.WriteString(separator)
+			}
+		}
+		result.WriteString(part)
+	}
+	return result.String()
+}
+
+// hasSelectors reports whether the rule is limited to some days
+func (r *rule) hasSelectors() bool {
+	return len(r.weekdays) > 0 || len(r.weekdayConstraints) > 0 || len(r.weekConstraints) > 0 ||
+		r.yearStart > 0 || r.monthStart > 0 || r.isPH || r.isSH || r.isEaster
+}
+
+func prettifyRule(r rule, o prettifyOptions) string {
+	return prettifyMonthList([]rule{r}, o)
+}
+
+// continuesMonthList reports whether b follows a in the same month list and
+// differs from it only in its months, like "May 01 off" after "Jan 01 off"
+func continuesMonthList(a, b rule) bool {
+	if a.monthList == 0 || a.monthList != b.monthList || a.dateStart > 0 || b.dateStart > 0 {
+		return false
+	}
+	a.monthStart, a.monthEnd, a.dayStart, a.dayEnd = 0, 0, 0, 0
+	b.monthStart, b.monthEnd, b.dayStart, b.dayEnd = 0, 0, 0, 0
+	a.metadata, b.metadata = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+// prettifyMonthList formats rules[0] with the month selectors of all rules
+// joined, e.g. "Jan 01,May 01,Dec 25-26 off"
+func prettifyMonthList(rules []rule, o prettifyOptions) string {
+	r := rules[0]
+	var parts []string
+
+	listSeparator := o.ListSeparator
+	if listSeparator == "" {
+		listSeparator = ","
+	}
+
+	// Wide range selectors: year, week, month/date, easter
+	if r.dateStart > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s-%d %s",
+			r.dateStart/10000, o.monthDay(r.dateStart/100%100, r.dateStart%100),
+			r.dateEnd/10000, o.monthDay(r.dateEnd/100%100, r.dateEnd%100)))
+	} else if r.yearStart > 0 {
+		parts = append(parts, prettifyYears(r))
+	}
+
+	if len(r.weekConstraints) > 0 {
+		weeks := make([]string, len(r.weekConstraints))
+		for i, wc := range r.weekConstraints {
+			weeks[i] = o.number(wc.weekStart)
+			if wc.weekEnd != wc.weekStart {
+				weeks[i] += "-" + o.number(wc.weekEnd)
+			}
+			if wc.weekInterval > 0 {
+				weeks[i] += fmt.Sprintf("/%d", wc.weekInterval)
+			}
+		}
+		parts = append(parts, "week "+strings.Join(weeks, ","))
+	}
+
+	if r.monthStart > 0 && r.dateStart == 0 {
+		months := make([]string, len(rules))
+		for i, listed := range rules {
+			months[i] = o.prettifyMonths(listed)
+		}
+		parts = append(parts, strings.Join(months, listSeparator))
+	}
+
+	if r.isEaster {
+		name := r.easterName()
+		if r.isEasterRange && (r.easterStartDate > 0 || r.easterEndDate > 0) {
+			parts = append(parts, o.easterEndpoint(name, r.easterStartDate, r.easterOffset)+"-"+o.easterEndpoint(name, r.easterEndDate, r.easterOffsetEnd))
+		} else if r.isEasterRange {
+			parts = append(parts, fmt.Sprintf("%s %s-%s %s", name, dayOffset(r.easterOffset), name, dayOffset(r.easterOffsetEnd)))
+		} else if r.easterOffset != 0 {
+			parts = append(parts, name+" "+dayOffset(r.easterOffset))
+		} else {
+			parts = append(parts, name)
+		}
+	}
+
+	// Small range selectors: weekdays and holidays
+	weekdays := o.prettifyWeekdays(r.weekdays, r.weekdayConstraints)
+	if r.holidayUnion {
+		// Weekdays or holidays: "Mo-Fr,PH"
+		var days []string
+		if weekdays != "" {
+			days = append(days, weekdays)
+		}
+		if r.isPH {
+			days = append(days, "PH")
+		}
+		if r.isSH {
+			days = append(days, "SH")
+		}
+		parts = append(parts, strings.Join(days, listSeparator))
+	} else {
+		// Holidays on some weekdays: "PH Su", "PH +1 day Mo"
+		if r.isPH {
+			if r.phOffset != 0 {
+				parts = append(parts, "PH "+dayOffset(r.phOffset))
+			} else {
+				parts = append(parts, "PH")
+			}
+		}
+		if r.isSH {
+			parts = append(parts, "SH")
+		}
+		if weekdays != "" {
+			parts = append(parts, weekdays)
+		}
+	}
+
+	// Add time ranges
+	if len(r.timeRanges) > 0 {
+		timeStrs := make([]string, len(r.timeRanges))
+		for i, tr := range r.timeRanges {
+			timeStrs[i] = o.prettifyTimeRange(tr)
+		}
+		parts = append(parts, strings.Join(timeStrs, listSeparator))
+	}
+
+	// Add state. A rule without selectors and times applies all the time, and
+	// is unknown if it only has a comment. Open days in a comma-separated rule
+	// keep their state ("Mo-Fr 08:00-18:00, Sa open"), which would read as a
+	// weekday list otherwise.
+	switch r.state {
+	case StateOpen:
+		if len(parts) == 0 {
+			parts = append(parts, "24/7")
+		} else if r.ruleGroup > 0 && len(r.timeRanges) == 0 && r.comment == "" {
+			parts = append(parts, o.stateName(StateOpen))
+		}
+	case StateUnknown:
+		if len(parts) > 0 || r.comment == "" {
+			parts = append(parts, o.stateName(r.state))
+		}
+	case StateClosed:
+		parts = append(parts, o.stateName(r.state))
+	}
+
+	// Add comment
+	if r.comment != "" {
+		parts = append(parts, fmt.Sprintf("\"%s\"", r.comment))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// prettifyYears formats the year selector: "2024", "2020-2030/2" or "2020+"
+func prettifyYears(r rule) string {
+	switch {
+	case r.yearEnd == 9999:
+		return fmt.Sprintf("%d+", r.yearStart)
+	case r.yearEnd == r.yearStart:
+		return fmt.Sprintf("%d", r.yearStart)
+	case r.yearInterval > 0:
+		return fmt.Sprintf("%d-%d/%d", r.yearStart, r.yearEnd, r.yearInterval)
+	}
+	return fmt.Sprintf("%d-%d", r.yearStart, r.yearEnd)
+}
+
+// prettifyMonths formats the month selector: "Jan", "Jun-Aug", "Dec 25",
+// "Jan 01-31/8", "Dec 24-Jan 02", "Dec 25 +3 days", "Feb -1" or "Jan-Dec -1"
+func (o prettifyOptions) prettifyMonths(r rule) string {
+	if r.everyMonth {
+		return o.monthName(r.monthStart) + "-" + o.monthName(r.monthEnd) + " " + o.number(r.dayStart)
+	}
+	if r.dayStart == 0 {
+		if r.monthEnd == r.monthStart {
+			return o.monthName(r.monthStart)
+		}
+		return o.monthName(r.monthStart) + "-" + o.monthName(r.monthEnd)
+	}
+
+	result := o.monthDay(r.monthStart, r.dayStart)
+	if r.monthEnd != r.monthStart {
+		return result + "-" + o.monthDay(r.monthEnd, r.dayEnd)
+	}
+	if r.dayEnd != r.dayStart {
+		result += "-" + o.number(r.dayEnd)
+	}
+	if r.dayInterval > 0 {
+		result += fmt.Sprintf("/%d", r.dayInterval)
+	}
+	if r.dateOffset != 0 {
+		result += " " + dayOffset(r.dateOffset)
+	}
+	return result
+}
+
+// easterEndpoint formats an endpoint of an Easter range: the fixed mmdd date
+// like "Jan 06", or the movable date name with an optional offset like
+// "easter +1 day"
+func (o prettifyOptions) easterEndpoint(name string, date, offset int) string {
+	switch {
+	case date > 0:
+		return o.monthDay(date/100, date%100)
+	case offset != 0:
+		return name + " " + dayOffset(offset)
+	}
+	return name
+}
+
+// dayOffset formats a day offset like "+1 day" or "-2 days"
+func dayOffset(days int) string {
+	if days == 1 || days == -1 {
+		return fmt.Sprintf("%+d day", days)
+	}
+	return fmt.Sprintf("%+d days", days)
+}
+
+// prettifyWeekdays converts the weekday flags and nth-weekday constraints to a
+// selector string. Runs of exactly three days are listed individually
+// ("Mo,Tu,We") unless weekdays are merged.
+func (o prettifyOptions) prettifyWeekdays(weekdays []bool, constraints []weekdayConstraint) string {
+	names := defaultWeekdayNames
+	for i, name := range o.WeekdayNames {
+		if name != "" {
+			names[i] = name
+		}
+	}
+	separator := o.ListSeparator
+	if separator == "" {
+		separator = ","
+	}
+
+	var parts, nthParts []string
+
+	for _, c := range constraints {
+		name := names[c.weekday]
+		if c.nthTo != 0 {
+			nthParts = append(nthParts, fmt.Sprintf("%s[%d-%d]", name, c.nthFrom, c.nthTo))
+		} else {
+			nthParts = append(nthParts, fmt.Sprintf("%s[%d]", name, c.nthFrom))
+		}
+	}
+	if len(constraints) == 0 || constraints[0].offset == 0 {
+		parts, nthParts = nthParts, nil
+	}
+
+	if len(weekdays) == 7 {
+		// Start from Monday (index 1) instead of Sunday (index 0), giving more
+		// natural output like "Mo-Fr" instead of "Su,Mo-Fr". If Sunday continues
+		// a run through Monday ("Su-Tu"), start after the first gap instead.
+		startIdx := 1
+		for weekdays[(startIdx+6)%7] && startIdx < 8 {
+			startIdx++
+		}
+
+		for j := 0; j < 7; j++ {
+			i := (startIdx + j) % 7
+			if !weekdays[i] {
+				continue
+			}
+
+			// Find the end of this consecutive range
+			count := 0
+			for count < 7-j && weekdays[(i+count)%7] {
+				count++
+			}
+			end := (i + count - 1) % 7
+
+			if count == 1 {
+				parts = append(parts, names[i])
+			} else if count == 3 && !o.mergeWeekdays {
+				// Exactly 3 consecutive days: list individually
+				for k := 0; k < count; k++ {
+					parts = append(parts, names[(i+k)%7])
+				}
+			} else {
+				// 2 days or 4+ days: use range
+				parts = append(parts, fmt.Sprintf("%s-%s", names[i], names[end]))
+			}
+
+			// Skip the days we've already processed
+			j += count - 1
+		}
+	}
+
+	// The day offset of constrained weekdays follows them at the end, like
+	// "Mo,Sa[-1] +1 day"
+	if len(nthParts) > 0 {
+		parts = append(parts, nthParts...)
+		return strings.Join(parts, separator) + " " + dayOffset(constraints[0].offset)
+	}
+	return strings.Join(parts, separator)
+}
+
+// prettifyTimeRange formats a time range, e.g. "09:00-17:00", "12:00", "17:00+", "14:00-17:00+",
+// "(sunrise+01:00)-sunset" or "10:00-16:00/01:30"
+func (o prettifyOptions) prettifyTimeRange(tr timeRange) string {
+	start := o.prettifyTime(tr.start, tr.startVar, tr.startOffset)
+	if tr.openEnd {
+		if tr.minEnd != tr.start {
+			return start + "-" + o.prettifyTime(tr.minEnd, "", 0) + "+"
+		}
+		return start + "+"
+	}
+	if tr.point {
+		return start
+	}
+
+	result := start + "-" + o.prettifyTime(tr.end, tr.endVar, tr.endOffset)
+	if tr.interval > 0 {
+		result += fmt.Sprintf("/%02d:%02d", tr.interval/60, tr.interval%60)
+	}
+	return result
+}
+
+// prettifyTime formats a fixed time in minutes or a variable time with its offset
+func (o prettifyOptions) prettifyTime(minutes int, variable string, offset int) string {
+	if variable == "" {
+		if o.NoLeadingZeros {
+			return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+		}
+		return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	}
+	if offset == 0 {
+		return variable
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("(%s%s%02d:%02d)", variable, sign, offset/60, offset%60)
+}
+
+// monthDay formats a month and day like "Jan 05"
+func (o prettifyOptions) monthDay(month, day int) string {
+	return o.monthName(month) + " " + o.number(day)
+}
+
+// number formats a day or week number with a leading zero unless disabled
+func (o prettifyOptions) number(n int) string {
+	if o.NoLeadingZeros {
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%02d", n)
+}
+
+// stateName returns the name of s after the selectors of a rule, like "off"
+func (o prettifyOptions) stateName(s State) string {
+	if o.StateNames[s] != "" {
+		return o.StateNames[s]
+	}
+	return [3]string{"open", "off", "unknown"}[s]
+}
+
+func (o prettifyOptions) monthName(m int) string {
+	if m < 1 || m > 12 {
+		return ""
+	}
+	if o.MonthNames[m-1] != "" {
+		return o.MonthNames[m-1]
+	}
+	return defaultMonthNames[m-1]
+}
+
+// mergeWeekdayRules merges each rule into the previous one when both have plain
+// weekday selectors and are otherwise identical. Only consecutive rules are merged
+// so that the result keeps the override order of the original value.
+func mergeWeekdayRules(rules []rule) []rule {
+	var merged []rule
+	for _, r := range rules {
+		if n := len(merged); n > 0 && canMergeWeekdays(merged[n-1], r) {
+			weekdays := make([]bool, 7)
+			for i := range weekdays {
+				weekdays[i] = merged[n-1].weekdays[i] || r.weekdays[i]
+			}
+			merged[n-1].weekdays = weekdays
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// canMergeWeekdays checks if two rules differ only in their plain weekday selectors
+func canMergeWeekdays(a, b rule) bool {
+	if len(a.weekdays) != 7 || len(b.weekdays) != 7 ||
+		len(a.weekdayConstraints) > 0 || len(b.weekdayConstraints) > 0 ||
+		a.isPH || b.isPH || a.isSH || b.isSH {
+		return false
+	}
+	// Group membership only affects evaluation, not the prettified output
+	a.weekdays, b.weekdays = nil, nil
+	a.ruleGroup, b.ruleGroup = 0, 0
+	a.monthList, b.monthList = 0, 0
+	return reflect.DeepEqual(a, b)
+}
@@ -1,7 +1,9 @@
 package openinghours
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestPrettify_TimeFormat(t *testing.T) {
@@ -176,6 +178,16 @@ func TestPrettify_24_7(t *testing.T) {
 			input:    "24/7",
 			expected: "24/7",
 		},
+		{
+			name:     "24/7 after weekdays",
+			input:    "Sa-Su 24/7",
+			expected: "Sa-Su 00:00-24:00",
+		},
+		{
+			name:     "24/7 after months with comment",
+			input:    `Mo-Fr 08:00-18:00; May-Sep 24/7 "open 24/7 in summer"`,
+			expected: `Mo-Fr 08:00-18:00; May-Sep 00:00-24:00 "open 24/7 in summer"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -307,6 +319,11 @@ func TestPrettify_EdgeCases(t *testing.T) {
 			input:    "Mo 9:00+",
 			expected: "Mo 09:00+",
 		},
+		{
+			name:     "open end with minimum",
+			input:    "Mo 14:00-17:00+",
+			expected: "Mo 14:00-17:00+",
+		},
 		{
 			name:     "normalize spaces with comma-separated times",
 			input:    "Mo 09:00-12:00 , 14:00-18:00",
@@ -359,3 +376,184 @@ func TestPrettify_PreserveComments(t *testing.T) {
 		})
 	}
 }
+
+func TestPrettify_MergedWeekdays(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "adjacent weekdays with identical times",
+			input:    "Mo 09:00-17:00; Tu 09:00-17:00; We 09:00-17:00",
+			expected: "Mo-We 09:00-17:00",
+		},
+		{
+			name:     "different times are kept apart",
+			input:    "Mo 09:00-17:00; Tu 10:00-17:00",
+			expected: "Mo 09:00-17:00; Tu 10:00-17:00",
+		},
+		{
+			name:     "only consecutive rules are merged",
+			input:    "Mo 09:00-17:00; Tu 10:00-12:00; We 09:00-17:00",
+			expected: "Mo 09:00-17:00; Tu 10:00-12:00; We 09:00-17:00",
+		},
+		{
+			name:     "merged ranges and single days",
+			input:    "Mo-Fr 08:00-12:00; Sa 08:00-12:00; Su off",
+			expected: "Mo-Sa 08:00-12:00; Su off",
+		},
+		{
+			name:     "comments must match",
+			input:    "Mo 09:00-17:00 \"a\"; Tu 09:00-17:00 \"b\"",
+			expected: "Mo 09:00-17:00 \"a\"; Tu 09:00-17:00 \"b\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oh, err := New(tt.input)
+			if err != nil {
+				t.Fatalf("failed to parse input %q: %v", tt.input, err)
+			}
+			result := oh.PrettifyValueWithOptions(WithMergedWeekdays())
+			if result != tt.expected {
+				t.Errorf("PrettifyValueWithOptions() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrettify_MergedWeekdaysIsOptIn(t *testing.T) {
+	oh, err := New("Mo 09:00-17:00; Tu 09:00-17:00; We 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	want := "Mo 09:00-17:00; Tu 09:00-17:00; We 09:00-17:00"
+	if got := oh.PrettifyValue(); got != want {
+		t.Errorf("PrettifyValue() = %q, want %q", got, want)
+	}
+	if got := oh.PrettifyValueWithOptions(); got != want {
+		t.Errorf("PrettifyValueWithOptions() = %q, want %q", got, want)
+	}
+}
+
+func TestPrettify_RoundTrip(t *testing.T) {
+	values := []string{
+		"Mo-Fr 09:00-17:00; Sa unknown || Su 10:00-12:00 || \"by appointment\"",
+		"week 01-10/2 Mo 10:00-12:00; week 05,07 Jan Mo 14:00-16:00",
+		"easter 10:00-12:00; easter +1 day off; easter -2 days-easter +1 day 10:00-14:00",
+		"(sunrise+01:00)-(sunset-01:30); Sa dawn-dusk; Su sunrise-18:00",
+		"10:00-16:00/01:30",
+		"2020-2030/2 Jun 10:00-12:00; 2020+ Jul 10:00-12:00",
+		"Jan 01-31/8 10:00-12:00; Jan 05-Feb 10 14:00-16:00; Dec 24-Jan 02 off",
+		"Mo-Fr 10:00-18:00; Su,PH off; PH +1 day 10:00-12:00; SH Mo-Fr 10:00-12:00",
+		"Mo[1],Fr 10:00-12:00; Fr[-1] 14:00-16:00",
+		"Su-Tu 11:00-01:00, We-Th 11:00-03:00",
+		"24/7; Dec 25 off",
+		"2025 Jun 01-2026 Sep 30 Sa 10:00-14:00",
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range values {
+		t.Run(value, func(t *testing.T) {
+			oh, err := New(value)
+			if err != nil {
+				t.Fatalf("failed to parse input %q: %v", value, err)
+			}
+			pretty := oh.PrettifyValue()
+			again, err := New(pretty)
+			if err != nil {
+				t.Fatalf("failed to parse prettified value %q: %v", pretty, err)
+			}
+			if again.PrettifyValue() != pretty {
+				t.Errorf("PrettifyValue() is not stable: %q -> %q", pretty, again.PrettifyValue())
+			}
+
+			oh.SetHolidayChecker(&yearlyHolidayChecker{})
+			again.SetHolidayChecker(&yearlyHolidayChecker{})
+			if !reflect.DeepEqual(oh.GetOpenIntervals(from, to), again.GetOpenIntervals(from, to)) {
+				t.Errorf("%q evaluates differently than %q", pretty, value)
+			}
+		})
+	}
+}
+
+func TestPrettify_Options(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-12:00,13:00-17:00; Dec 05 off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		options  PrettifyOptions
+		expected string
+	}{
+		{"separators", PrettifyOptions{RuleSeparator: " ; ", ListSeparator: ", "}, "Mo-Fr 09:00-12:00, 13:00-17:00 ; Dec 05 off"},
+		{"no leading zeros", PrettifyOptions{NoLeadingZeros: true}, "Mo-Fr 9:00-12:00,13:00-17:00; Dec 5 off"},
+		{"localized names", PrettifyOptions{
+			WeekdayNames: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+			MonthNames:   [12]string{11: "Dez"},
+		}, "Mo-Fr 09:00-12:00,13:00-17:00; Dez 05 off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := oh.PrettifyValueWithOptions(WithPrettifyOptions(tt.options)); got != tt.expected {
+				t.Errorf("PrettifyValueWithOptions() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrettifyValueLocale(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00; Sa 10:00-12:00 unknown; Dec 24-Mar 01 off; Su,PH off "Ruhetag"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"en", `Mo-Fr 09:00-17:00; Sa 10:00-12:00 unknown; Dec 24-Mar 01 off; Su,PH off "Ruhetag"`},
+		{"de", `Mo-Fr 09:00-17:00; Sa 10:00-12:00 unbekannt; Dez 24-Mär 01 geschlossen; So,PH geschlossen "Ruhetag"`},
+		{"de-AT", `Mo-Fr 09:00-17:00; Sa 10:00-12:00 unbekannt; Dez 24-Mär 01 geschlossen; So,PH geschlossen "Ruhetag"`},
+		{"fr_FR", `lu-ve 09:00-17:00; sa 10:00-12:00 inconnu; déc 24-mars 01 fermé; di,PH fermé "Ruhetag"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			got, err := oh.PrettifyValueLocale(tt.locale)
+			if err != nil {
+				t.Fatalf("PrettifyValueLocale(%q): %v", tt.locale, err)
+			}
+			if got != tt.expected {
+				t.Errorf("PrettifyValueLocale(%q) = %q, want %q", tt.locale, got, tt.expected)
+			}
+		})
+	}
+
+	if got, _ := oh.PrettifyValueLocale("en"); got != oh.PrettifyValue() {
+		t.Errorf("PrettifyValueLocale(en) = %q, want PrettifyValue() = %q", got, oh.PrettifyValue())
+	}
+	if _, err := oh.PrettifyValueLocale("xx"); err == nil {
+		t.Error("expected an error for an unsupported locale")
+	}
+}
+
+func TestPrettifyValueLocale_CommaGroupState(t *testing.T) {
+	oh, err := New("Mo-Fr 08:00-18:00, Sa open; off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	got, err := oh.PrettifyValueLocale("de")
+	if err != nil {
+		t.Fatalf("PrettifyValueLocale: %v", err)
+	}
+	if want := "Mo-Fr 08:00-18:00, Sa geöffnet; geschlossen"; got != want {
+		t.Errorf("PrettifyValueLocale(de) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package openinghours
+
+import (
+	"strings"
+	"time"
+)
+
+// Region is the regional context of values, see SetRegion
+type Region struct {
+	Code         string       // ISO 3166-1 alpha-2 country code, e.g. "NO"
+	Latitude     float64      // representative latitude for the default variable times
+	Language     string       // ISO 639-1 language for display, e.g. "de"
+	FirstWeekday time.Weekday // first day of the week in calendars of the region, see GetWeekSchedule
+}
+
+// regions are the regions known to SetRegion by country code
+var regions = map[string]Region{
+	"AR": {Latitude: -34.6, Language: "es", FirstWeekday: time.Monday},
+	"AT": {Latitude: 48.2, Language: "de", FirstWeekday: time.Monday},
+	"AU": {Latitude: -33.9, Language: "en", FirstWeekday: time.Monday},
+	"BE": {Latitude: 50.8, Language: "nl", FirstWeekday: time.Monday},
+	"BR": {Latitude: -23.5, Language: "pt", FirstWeekday: time.Sunday},
+	"CA": {Latitude: 45.4, Language: "en", FirstWeekday: time.Sunday},
+	"CH": {Latitude: 47.4, Language: "de", FirstWeekday: time.Monday},
+	"CZ": {Latitude: 50.1, Language: "cs", FirstWeekday: time.Monday},
+	"DE": {Latitude: 51.2, Language: "de", FirstWeekday: time.Monday},
+	"DK": {Latitude: 55.7, Language: "da", FirstWeekday: time.Monday},
+	"ES": {Latitude: 40.4, Language: "es", FirstWeekday: time.Monday},
+	"FI": {Latitude: 60.2, Language: "fi", FirstWeekday: time.Monday},
+	"FR": {Latitude: 46.6, Language: "fr", FirstWeekday: time.Monday},
+	"GB": {Latitude: 52.5, Language: "en", FirstWeekday: time.Monday},
+	"GR": {Latitude: 38.0, Language: "el", FirstWeekday: time.Monday},
+	"IE": {Latitude: 53.3, Language: "en", FirstWeekday: time.Monday},
+	"IN": {Latitude: 22.0, Language: "en", FirstWeekday: time.Sunday},
+	"IS": {Latitude: 64.1, Language: "is", FirstWeekday: time.Monday},
+	"IT": {Latitude: 42.5, Language: "it", FirstWeekday: time.Monday},
+	"JP": {Latitude: 35.7, Language: "ja", FirstWeekday: time.Sunday},
+	"LU": {Latitude: 49.6, Language: "fr", FirstWeekday: time.Monday},
+	"MX": {Latitude: 19.4, Language: "es", FirstWeekday: time.Sunday},
+	"NL": {Latitude: 52.2, Language: "nl", FirstWeekday: time.Monday},
+	"NO": {Latitude: 60.5, Language: "nb", FirstWeekday: time.Monday},
+	"NZ": {Latitude: -41.3, Language: "en", FirstWeekday: time.Monday},
+	"PL": {Latitude: 52.1, Language: "pl", FirstWeekday: time.Monday},
+	"PT": {Latitude: 39.4, Language: "pt", FirstWeekday: time.Monday},
+	"SE": {Latitude: 59.3, Language: "sv", FirstWeekday: time.Monday},
+	"US": {Latitude: 38.9, Language: "en", FirstWeekday: time.Sunday},
+	"ZA": {Latitude: -26.2, Language: "en", FirstWeekday: time.Sunday},
+}
+
+// LookupRegion returns the region of a country code like "NO" or "no", and
+// whether it is known
+func LookupRegion(countryCode string) (Region, bool) {
+	code := strings.ToUpper(countryCode)
+	region, ok := regions[code]
+	region.Code = code
+	return region, ok
+}
+
+// WithRegion sets the region of the venue, like SetRegion
+func WithRegion(countryCode string) Option {
+	return func(oh *OpeningHours) {
+		oh.SetRegion(countryCode)
+	}
+}
+
+// SetRegion sets the country of the venue by its ISO 3166-1 alpha-2 code, as
+// the regional context when nothing more specific is set:
+//
+//   - Without coordinates, sunrise, sunset, dawn and dusk follow the length of
+//     the day at the latitude of the region around 12:00, instead of the fixed
+//     06:00, 18:00, 05:30 and 18:30. Coordinates set with SetCoordinates are
+//     more accurate and take precedence.
+//   - PrettifyValueLocale("") and GetHumanReadable without WithLocale use the
+//     language of the region if it is supported, and English otherwise.
+//   - GetWeekSchedule and FormatWeek start the week on the first day of the
+//     week of the region instead of Monday.
+//
+// Region returns the region, e.g. to choose a holiday calendar like
+// holidays.New(region.Code). An unknown code removes the region.
+func (oh *OpeningHours) SetRegion(countryCode string) {
+	region, ok := LookupRegion(countryCode)
+	if ok {
+		oh.region = &region
+	} else {
+		oh.region = nil
+	}
+	oh.sunCache.clear()
+}
+
+// Region returns the region set with SetRegion, and whether one is set
+func (oh *OpeningHours) Region() (Region, bool) {
+	if oh.region == nil {
+		return Region{}, false
+	}
+	return *oh.region, true
+}
+
+// firstWeekday returns the first day of the week of the region, or Monday
+// without one
+func (oh *OpeningHours) firstWeekday() time.Weekday {
+	if oh.region == nil {
+		return time.Monday
+	}
+	return oh.region.FirstWeekday
+}
+
+// language returns the language of the region, or "en" without one
+func (oh *OpeningHours) language() string {
+	if oh.region == nil {
+		return "en"
+	}
+	return oh.region.Language
+}
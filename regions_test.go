@@ -0,0 +1,98 @@
+package openinghours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLookupRegion(t *testing.T) {
+	region, ok := LookupRegion("no")
+	if !ok || region.Code != "NO" || region.Language != "nb" || region.FirstWeekday != time.Monday {
+		t.Errorf("LookupRegion(%q) = %+v, %v", "no", region, ok)
+	}
+	if _, ok := LookupRegion("XX"); ok {
+		t.Error("LookupRegion(\"XX\") ok for an unknown code")
+	}
+}
+
+func TestSetRegion_VariableTimes(t *testing.T) {
+	oh, err := New("sunrise-sunset", WithRegion("NO"))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	tests := []struct {
+		at   time.Time
+		open bool
+	}{
+		{time.Date(2024, 6, 21, 5, 0, 0, 0, time.UTC), true},    // long summer days
+		{time.Date(2024, 6, 21, 20, 0, 0, 0, time.UTC), true},   // long summer days
+		{time.Date(2024, 12, 21, 8, 30, 0, 0, time.UTC), false}, // short winter days
+		{time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 12, 21, 15, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := oh.GetState(tt.at); got != tt.open {
+			t.Errorf("GetState(%v) = %v, want %v", tt.at, got, tt.open)
+		}
+	}
+
+	sun := oh.SunTimes(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC))
+	if !sun.Default || sun.Sun.Rise.Hour() != 9 || !sun.SolarNoon.Equal(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("SunTimes() = %+v, want default times around 12:00 rising at 09:xx", sun)
+	}
+
+	// Coordinates take precedence over the region
+	oh.SetCoordinates(-33.9, 151.2)
+	if oh.GetState(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected the coordinates in Sydney to be used, where it is night at 12:00 UTC")
+	}
+}
+
+func TestSetRegion_Language(t *testing.T) {
+	oh, err := New("Mo 10:00-12:00", WithRegion("DE"))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got, want := oh.GetHumanReadable(), oh.GetHumanReadable(WithLocale(GermanLocale())); got != want {
+		t.Errorf("GetHumanReadable() = %q, want %q", got, want)
+	}
+	if got, err := oh.PrettifyValueLocale(""); err != nil || got != "Mo 10:00-12:00" {
+		t.Errorf("PrettifyValueLocale(\"\") = %q, %v", got, err)
+	}
+
+	oh.SetRegion("FR")
+	if got, want := oh.GetHumanReadable(), oh.GetHumanReadable(WithLocale(EnglishLocale())); got != want {
+		t.Errorf("GetHumanReadable() = %q, want English %q without a French locale", got, want)
+	}
+	if got, err := oh.PrettifyValueLocale(""); err != nil || got != "lu 10:00-12:00" {
+		t.Errorf("PrettifyValueLocale(\"\") = %q, %v, want %q", got, err, "lu 10:00-12:00")
+	}
+
+	oh.SetRegion("XX")
+	if _, ok := oh.Region(); ok {
+		t.Error("expected an unknown code to remove the region")
+	}
+}
+
+func TestSetRegion_FirstWeekday(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00", WithRegion("US"))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Wednesday Jan 17, 2024; the US week starts on Sunday Jan 14
+	days := oh.GetWeekSchedule(WithReferenceDate(time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)))
+	if want := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC); !days[0].Date.Equal(want) {
+		t.Errorf("week starts on %v, want %v", days[0].Date, want)
+	}
+	if got := oh.FormatWeek(WithReferenceDate(time.Date(2024, 1, 14, 12, 0, 0, 0, time.UTC))); !strings.HasPrefix(got, "Su off\nMo 09:00-17:00") {
+		t.Errorf("FormatWeek() = %q, want it to start on Sunday", got)
+	}
+
+	oh.SetRegion("DE")
+	days = oh.GetWeekSchedule(WithReferenceDate(time.Date(2024, 1, 14, 12, 0, 0, 0, time.UTC)))
+	if want := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC); !days[0].Date.Equal(want) {
+		t.Errorf("week starts on %v, want Monday %v", days[0].Date, want)
+	}
+}
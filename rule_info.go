@@ -0,0 +1,214 @@
+package openinghours
+
+import (
+	"fmt"
+	"maps"
+	"time"
+)
+
+// RuleInfo describes a parsed rule, e.g. to explain which rule makes a venue
+// open. It is a copy; changing it doesn't affect the OpeningHours.
+type RuleInfo struct {
+	Index    int    // index of the rule within its group; equal to its index in Rules() for primary rules
+	Fallback int    // 0 for primary rules, n for rules of the nth fallback group after "||"
+	Group    int    // rules of the same comma-separated expression share a group; 0 = no group
+	Value    string // the prettified rule, e.g. "Mo-Fr 09:00-17:00"
+
+	Years         *YearRange          // year selector, nil if not set
+	Dates         *DateRange          // full date range like "2025 Jun 01-2026 Sep 30", nil if not set
+	Months        *MonthDayRange      // recurring month or day range like "Dec 24-26", nil if not set
+	Weeks         []WeekRange         // ISO week selectors
+	Weekdays      []time.Weekday      // selected weekdays, nil if the rule has no weekday selector
+	NthWeekdays   []WeekdayOccurrence // weekdays selected by occurrence in the month, like "Su[-1]"
+	PublicHoliday bool                // the rule applies on public holidays
+	HolidayOffset int                 // days from a public holiday, e.g. 1 for "PH +1 day"
+	SchoolHoliday bool                // the rule applies on school holidays
+	HolidayUnion  bool                // weekdays and holidays are listed together ("Mo-Fr,PH"), either matches
+	Easter        *EasterRange        // easter selector, nil if not set
+
+	TimeRanges []TimeRangeInfo // time ranges, empty if the rule applies all day
+	State      State
+	Comment    string
+	Metadata   map[string]string // set by SetRuleMetadata, nil if not set
+}
+
+// YearRange is a year selector like "2024", "2024-2026/2" or "2024+"
+type YearRange struct {
+	Start    int
+	End      int // equal to Start for a single year, 0 for open ranges like "2024+"
+	Interval int // 0 if not set
+}
+
+// DateRange is a full date range. Start and End are midnight UTC of the first and last day.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// MonthDayRange is a recurring range of months or days. The days are 0 for
+// month ranges like "Jun-Aug"; ranges like "Dec 24-Jan 02" wrap the year.
+// Negative days count from the end of the month, -1 being the last day.
+type MonthDayRange struct {
+	StartMonth time.Month
+	StartDay   int
+	EndMonth   time.Month
+	EndDay     int
+	Interval   int  // every nth day like "Jan 01-31/8", 0 if not set
+	Offset     int  // days added to a single date like 3 for "Dec 25 +3 days", 0 if not set
+	EveryMonth bool // the days are in each month from StartMonth to EndMonth, like "Jan-Dec -1"
+}
+
+// WeekRange is an ISO week selector like "week 01-10/2"
+type WeekRange struct {
+	Start    int
+	End      int
+	Interval int // 0 if not set
+}
+
+// WeekdayOccurrence selects occurrences of a weekday in the month, like "Su[-1]"
+// (the last Sunday) or "Mo[1-2]". Occurrences count from the end if negative.
+type WeekdayOccurrence struct {
+	Weekday time.Weekday
+	From    int
+	To      int // 0 for a single occurrence
+	Offset  int // days after the occurrence, like +1 in "Sa[-1] +1 day"
+}
+
+// EasterRange is an easter selector, or a selector of another movable date
+// like "whitsun" (see VariableDateProvider). Offset and OffsetEnd are days from
+// the movable date; OffsetEnd equals Offset unless the selector is a range.
+// Ranges may start or end on a fixed date instead, like "Jan 06-easter".
+type EasterRange struct {
+	Date       string // "easter" or the name of the movable date
+	Offset     int
+	OffsetEnd  int
+	StartMonth time.Month // fixed start like Jan 06 of "Jan 06-easter", 0 if the range starts at Easter
+	StartDay   int
+	EndMonth   time.Month // fixed end like Oct 31 of "easter-Oct 31", 0 if the range ends at Easter
+	EndDay     int
+}
+
+// TimeRangeInfo is a time range of a rule. Times are minutes from midnight and
+// may exceed 1440 for ranges into the next day. Variable times like sunset have
+// StartEvent or EndEvent set, with the offset from the event in StartOffset or
+// EndOffset, and their Start or End is -1.
+type TimeRangeInfo struct {
+	Start       int
+	End         int
+	OpenEnd     bool   // open-ended like "17:00+"
+	MinEnd      int    // open-ended ranges: the end before "+" like 17:00 of "14:00-17:00+", equal to Start for "17:00+"
+	StartEvent  string // "sunrise", "sunset", "dawn" or "dusk", empty for fixed times
+	EndEvent    string
+	StartOffset int // minutes from StartEvent
+	EndOffset   int // minutes from EndEvent
+	Interval    int // minutes between periodic openings like "10:00-16:00/01:30", 0 if not set
+}
+
+// Rules returns the parsed rules: the primary rules in order, followed by the
+// rules of the fallback groups. Rules()[oh.GetMatchingRule(t)] describes the
+// rule that decides the state at t, which may be a fallback rule.
+func (oh *OpeningHours) Rules() []RuleInfo {
+	var infos []RuleInfo
+	for fallback, group := range append([][]rule{oh.rules}, oh.fallbackGroups...) {
+		for i, r := range group {
+			info := r.info()
+			info.Index = i
+			info.Fallback = fallback
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// SetRuleMetadata attaches metadata like the source, confidence or editor of a
+// rule to the rule at index of Rules(), replacing earlier metadata; nil removes
+// it. Metadata is opaque to OpeningHours: it is kept in memory and by Clone,
+// returned by Rules, but not part of the value or its prettified form.
+func (oh *OpeningHours) SetRuleMetadata(index int, metadata map[string]string) error {
+	if index >= 0 {
+		i := index
+		for _, group := range append([][]rule{oh.rules}, oh.fallbackGroups...) {
+			if i < len(group) {
+				group[i].metadata = maps.Clone(metadata)
+				return nil
+			}
+			i -= len(group)
+		}
+	}
+	return fmt.Errorf("invalid rule index: %d", index)
+}
+
+// info returns the description of r
+func (r *rule) info() RuleInfo {
+	info := RuleInfo{
+		Group:         r.ruleGroup,
+		Value:         prettifyRule(*r, prettifyOptions{}),
+		PublicHoliday: r.isPH,
+		HolidayOffset: r.phOffset,
+		SchoolHoliday: r.isSH,
+		HolidayUnion:  r.holidayUnion,
+		State:         r.state,
+		Comment:       r.comment,
+		Metadata:      maps.Clone(r.metadata),
+	}
+
+	if r.dateStart > 0 {
+		info.Dates = &DateRange{Start: dateFromKey(r.dateStart), End: dateFromKey(r.dateEnd)}
+	} else if r.yearStart > 0 {
+		years := &YearRange{Start: r.yearStart, End: r.yearEnd, Interval: r.yearInterval}
+		if years.End == 9999 {
+			years.End = 0
+		}
+		info.Years = years
+	}
+	if r.monthStart > 0 && r.dateStart == 0 {
+		info.Months = &MonthDayRange{
+			StartMonth: time.Month(r.monthStart),
+			StartDay:   r.dayStart,
+			EndMonth:   time.Month(r.monthEnd),
+			EndDay:     r.dayEnd,
+			Interval:   r.dayInterval,
+			Offset:     r.dateOffset,
+			EveryMonth: r.everyMonth,
+		}
+	}
+	for _, wc := range r.weekConstraints {
+		info.Weeks = append(info.Weeks, WeekRange{Start: wc.weekStart, End: wc.weekEnd, Interval: wc.weekInterval})
+	}
+	for wd, selected := range r.weekdays {
+		if selected {
+			info.Weekdays = append(info.Weekdays, time.Weekday(wd))
+		}
+	}
+	for _, c := range r.weekdayConstraints {
+		info.NthWeekdays = append(info.NthWeekdays, WeekdayOccurrence{Weekday: time.Weekday(c.weekday), From: c.nthFrom, To: c.nthTo, Offset: c.offset})
+	}
+	if r.isEaster {
+		easter := &EasterRange{Date: r.easterName(), Offset: r.easterOffset, OffsetEnd: r.easterOffset}
+		if r.isEasterRange {
+			easter.OffsetEnd = r.easterOffsetEnd
+			easter.StartMonth, easter.StartDay = time.Month(r.easterStartDate/100), r.easterStartDate%100
+			easter.EndMonth, easter.EndDay = time.Month(r.easterEndDate/100), r.easterEndDate%100
+		}
+		info.Easter = easter
+	}
+	for _, tr := range r.timeRanges {
+		info.TimeRanges = append(info.TimeRanges, TimeRangeInfo{
+			Start:       tr.start,
+			End:         tr.end,
+			OpenEnd:     tr.openEnd,
+			MinEnd:      tr.minEnd,
+			StartEvent:  tr.startVar,
+			EndEvent:    tr.endVar,
+			StartOffset: tr.startOffset,
+			EndOffset:   tr.endOffset,
+			Interval:    tr.interval,
+		})
+	}
+	return info
+}
+
+// dateFromKey returns midnight UTC of a yyyymmdd date
+func dateFromKey(key int) time.Time {
+	return time.Date(key/10000, time.Month(key/100%100), key%100, 0, 0, 0, 0, time.UTC)
+}
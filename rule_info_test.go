@@ -0,0 +1,170 @@
+package openinghours
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRules_Selectors(t *testing.T) {
+	oh, err := New(`2024+ week 01-10/2 Mo-Fr 09:00-17:00; Dec 24-26 off "Christmas"; easter -2 days-easter +1 day off`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	rules := oh.Rules()
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3", len(rules))
+	}
+
+	expected := RuleInfo{
+		Value:      "2024+ week 01-10/2 Mo-Fr 09:00-17:00",
+		Years:      &YearRange{Start: 2024},
+		Weeks:      []WeekRange{{Start: 1, End: 10, Interval: 2}},
+		Weekdays:   []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		TimeRanges: []TimeRangeInfo{{Start: 9 * 60, End: 17 * 60}},
+		State:      StateOpen,
+	}
+	if !reflect.DeepEqual(rules[0], expected) {
+		t.Errorf("rule 0 = %+v, want %+v", rules[0], expected)
+	}
+
+	expected = RuleInfo{
+		Index:   1,
+		Value:   `Dec 24-26 off "Christmas"`,
+		Months:  &MonthDayRange{StartMonth: time.December, StartDay: 24, EndMonth: time.December, EndDay: 26},
+		State:   StateClosed,
+		Comment: "Christmas",
+	}
+	if !reflect.DeepEqual(rules[1], expected) {
+		t.Errorf("rule 1 = %+v, want %+v", rules[1], expected)
+	}
+
+	if e := rules[2].Easter; e == nil || e.Offset != -2 || e.OffsetEnd != 1 {
+		t.Errorf("rule 2 easter = %+v, want -2 to +1", e)
+	}
+}
+
+func TestRules_HolidaysAndDates(t *testing.T) {
+	oh, err := New("2025 Jun 01-2026 Sep 30 Mo-Fr,PH 10:00-12:00; Su[-1] off; PH +1 day off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	rules := oh.Rules()
+
+	dates := rules[0].Dates
+	if dates == nil || !dates.Start.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) ||
+		!dates.End.Equal(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("rule 0 dates = %+v", dates)
+	}
+	if !rules[0].PublicHoliday || !rules[0].HolidayUnion || len(rules[0].Weekdays) != 5 {
+		t.Errorf("rule 0 = %+v, want weekdays or PH", rules[0])
+	}
+	if !reflect.DeepEqual(rules[1].NthWeekdays, []WeekdayOccurrence{{Weekday: time.Sunday, From: -1}}) {
+		t.Errorf("rule 1 occurrences = %+v", rules[1].NthWeekdays)
+	}
+	if !rules[2].PublicHoliday || rules[2].HolidayOffset != 1 {
+		t.Errorf("rule 2 = %+v, want PH +1 day", rules[2])
+	}
+}
+
+func TestRules_TimeRanges(t *testing.T) {
+	oh, err := New("Mo sunrise-(sunset-01:00); Tu 17:00+; We 10:00-16:00/01:30")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	expected := [][]TimeRangeInfo{
+		{{Start: -1, End: -1, StartEvent: "sunrise", EndEvent: "sunset", EndOffset: -60}},
+		{{Start: 17 * 60, End: oh.Rules()[1].TimeRanges[0].End, OpenEnd: true, MinEnd: 17 * 60}},
+		{{Start: 10 * 60, End: 16 * 60, Interval: 90}},
+	}
+	for i, r := range oh.Rules() {
+		if !reflect.DeepEqual(r.TimeRanges, expected[i]) {
+			t.Errorf("rule %d time ranges = %+v, want %+v", i, r.TimeRanges, expected[i])
+		}
+	}
+}
+
+func TestRules_MatchingRuleAndFallbacks(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00, Sa 10:00-14:00; Fr off || 10:00-12:00 unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	rules := oh.Rules()
+	if len(rules) != 4 {
+		t.Fatalf("got %d rules, want 4", len(rules))
+	}
+	if rules[0].Group == 0 || rules[0].Group != rules[1].Group || rules[2].Group != 0 {
+		t.Errorf("expected the first two rules grouped, got groups %d, %d, %d", rules[0].Group, rules[1].Group, rules[2].Group)
+	}
+	if rules[3].Fallback != 1 || rules[3].Index != 0 || rules[3].State != StateUnknown {
+		t.Errorf("fallback rule = %+v", rules[3])
+	}
+
+	// Friday Jan 19, 2024
+	friday := time.Date(2024, 1, 19, 12, 0, 0, 0, time.UTC)
+	if got := rules[oh.GetMatchingRule(friday)].Value; got != "Fr off" {
+		t.Errorf("matching rule on Friday = %q, want %q", got, "Fr off")
+	}
+
+	// Changing the result doesn't change the rules
+	rules[0].Weekdays[0] = time.Sunday
+	if oh.Rules()[0].Weekdays[0] != time.Monday {
+		t.Errorf("changing RuleInfo changed the rules")
+	}
+}
+
+func TestSetRuleMetadata(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; Sa 10:00-14:00 || unknown")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	value := oh.PrettifyValue()
+
+	source := map[string]string{"source": "survey", "confidence": "0.9"}
+	if err := oh.SetRuleMetadata(0, source); err != nil {
+		t.Fatalf("SetRuleMetadata(0): %v", err)
+	}
+	if err := oh.SetRuleMetadata(2, map[string]string{"editor": "42"}); err != nil {
+		t.Fatalf("SetRuleMetadata(2): %v", err)
+	}
+	for _, index := range []int{-1, 3} {
+		if err := oh.SetRuleMetadata(index, source); err == nil {
+			t.Errorf("SetRuleMetadata(%d): expected error", index)
+		}
+	}
+	source["source"] = "changed"
+
+	rules := oh.Rules()
+	if got := rules[0].Metadata; !reflect.DeepEqual(got, map[string]string{"source": "survey", "confidence": "0.9"}) {
+		t.Errorf("rule 0 metadata = %v", got)
+	}
+	if rules[1].Metadata != nil {
+		t.Errorf("rule 1 metadata = %v, want nil", rules[1].Metadata)
+	}
+	if got := rules[2].Metadata["editor"]; rules[2].Fallback != 1 || got != "42" {
+		t.Errorf("fallback rule metadata = %v", rules[2].Metadata)
+	}
+
+	// The metadata is not part of the value
+	if got := oh.PrettifyValue(); got != value {
+		t.Errorf("PrettifyValue() = %q, want %q", got, value)
+	}
+
+	// Clones keep their own copy
+	clone := oh.Clone()
+	if err := oh.SetRuleMetadata(0, nil); err != nil {
+		t.Fatalf("SetRuleMetadata(0, nil): %v", err)
+	}
+	if oh.Rules()[0].Metadata != nil {
+		t.Errorf("expected metadata removed, got %v", oh.Rules()[0].Metadata)
+	}
+	if got := clone.Rules()[0].Metadata["source"]; got != "survey" {
+		t.Errorf("clone metadata source = %q, want %q", got, "survey")
+	}
+
+	// The metadata of the matching rule tells where a state comes from
+	monday := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if got := clone.Rules()[clone.GetMatchingRule(monday)].Metadata["source"]; got != "survey" {
+		t.Errorf("source of the matching rule on Monday = %q, want %q", got, "survey")
+	}
+}
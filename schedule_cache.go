@@ -0,0 +1,156 @@
+package openinghours
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultScheduleCacheSize is the default maximum number of cached day schedules
+const defaultScheduleCacheSize = 4096
+
+// ScheduleCacheStats reports the usage of the process-level schedule cache
+type ScheduleCacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// scheduleCache shares computed day schedules between OpeningHours instances
+// parsed from identical values. Entries are keyed by (value, day, location,
+// coordinates, options). Instances with holiday checkers or variable dates are
+// never cached because the checkers and providers are arbitrary user code.
+type scheduleCache struct {
+	mu      sync.Mutex
+	maxSize int
+	entries map[string][]Interval
+	hits    uint64
+	misses  uint64
+}
+
+var daySchedules = &scheduleCache{
+	maxSize: defaultScheduleCacheSize,
+	entries: make(map[string][]Interval),
+}
+
+// SetScheduleCacheSize sets the maximum number of day schedules kept in the
+// process-level cache. A size of 0 disables the cache, which is useful for
+// memory-constrained deployments. Changing the size clears the cache.
+func SetScheduleCacheSize(size int) {
+	daySchedules.mu.Lock()
+	defer daySchedules.mu.Unlock()
+	daySchedules.maxSize = size
+	daySchedules.entries = make(map[string][]Interval)
+}
+
+// ResetScheduleCache removes all cached day schedules and resets the statistics
+func ResetScheduleCache() {
+	daySchedules.mu.Lock()
+	defer daySchedules.mu.Unlock()
+	daySchedules.entries = make(map[string][]Interval)
+	daySchedules.hits = 0
+	daySchedules.misses = 0
+}
+
+// GetScheduleCacheStats returns hit/miss counts and the number of cached day schedules
+func GetScheduleCacheStats() ScheduleCacheStats {
+	daySchedules.mu.Lock()
+	defer daySchedules.mu.Unlock()
+	return ScheduleCacheStats{
+		Hits:    daySchedules.hits,
+		Misses:  daySchedules.misses,
+		Entries: len(daySchedules.entries),
+	}
+}
+
+func (c *scheduleCache) get(key string) ([]Interval, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxSize <= 0 {
+		return nil, false
+	}
+	intervals, ok := c.entries[key]
+	if ok {
+		c.hits++
+		incMetric(CounterScheduleCacheHit)
+	} else {
+		c.misses++
+		incMetric(CounterScheduleCacheMiss)
+	}
+	return intervals, ok
+}
+
+func (c *scheduleCache) put(key string, intervals []Interval) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxSize <= 0 {
+		return
+	}
+	// Simple eviction: start over when full
+	if len(c.entries) >= c.maxSize {
+		c.entries = make(map[string][]Interval)
+	}
+	c.entries[key] = intervals
+}
+
+// scheduleCacheKey returns the cache key for the day starting at dayStart,
+// or "" if this instance's schedules must not be cached
+func (oh *OpeningHours) scheduleCacheKey(dayStart time.Time) string {
+	if oh.value == "" || oh.holidayChecker != nil || oh.schoolHolidayChecker != nil || len(oh.closures) > 0 || len(oh.variableDates) > 0 {
+		return ""
+	}
+	// Fixed zones may have no name, so the offset tells them apart
+	_, offset := dayStart.Zone()
+	key := fmt.Sprintf("%s|%s|%s%+d", oh.value, dayStart.Format("2006-01-02"), dayStart.Location(), offset)
+	if oh.hasCoordinates {
+		key += fmt.Sprintf("|%g,%g,%g", oh.latitude, oh.longitude, oh.elevation)
+	} else if oh.region != nil {
+		key += "|" + oh.region.Code
+	}
+	if oh.mergeSplitDates {
+		key += "|ms"
+	}
+	if oh.openEndUnknown {
+		key += "|oe"
+	}
+	if oh.eventDuration > 0 {
+		key += fmt.Sprintf("|ev%d", oh.eventDuration)
+	}
+	if oh.schoolHolidayPolicy != SchoolHolidaysIgnore {
+		key += fmt.Sprintf("|sh%d", oh.schoolHolidayPolicy)
+	}
+	if oh.holidayPolicy != HolidaysIgnore {
+		key += fmt.Sprintf("|ph%d", oh.holidayPolicy)
+	}
+	return key
+}
+
+// GetDaySchedule returns the open and unknown intervals of the day containing t.
+// Schedules are shared through a process-level cache between instances parsed
+// from the same value; see SetScheduleCacheSize.
+func (oh *OpeningHours) GetDaySchedule(t time.Time) DaySchedule {
+	t = oh.inLocation(t)
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	dayEnd := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+
+	key := oh.scheduleCacheKey(dayStart)
+	if key != "" {
+		if cached, ok := daySchedules.get(key); ok {
+			return DaySchedule{Date: dayStart, Intervals: copyIntervals(cached)}
+		}
+	}
+
+	intervals := oh.GetOpenIntervals(dayStart, dayEnd)
+	if key != "" {
+		daySchedules.put(key, copyIntervals(intervals))
+	}
+	return DaySchedule{Date: dayStart, Intervals: intervals}
+}
+
+// copyIntervals returns a copy so callers cannot modify cached schedules
+func copyIntervals(intervals []Interval) []Interval {
+	if intervals == nil {
+		return nil
+	}
+	return append([]Interval(nil), intervals...)
+}
@@ -0,0 +1,159 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleCache_SharedBetweenInstances(t *testing.T) {
+	ResetScheduleCache()
+	defer ResetScheduleCache()
+
+	day := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC) // Monday
+
+	first, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	second, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	a := first.GetDaySchedule(day)
+	b := second.GetDaySchedule(day)
+
+	stats := GetScheduleCacheStats()
+	if stats.Misses != 1 || stats.Hits != 1 || stats.Entries != 1 {
+		t.Errorf("stats = %+v, want 1 miss, 1 hit, 1 entry", stats)
+	}
+
+	if len(a.Intervals) != 1 || len(b.Intervals) != 1 {
+		t.Fatalf("expected 1 interval each, got %v and %v", a.Intervals, b.Intervals)
+	}
+	if !a.Intervals[0].Start.Equal(b.Intervals[0].Start) || !a.Intervals[0].End.Equal(b.Intervals[0].End) {
+		t.Errorf("cached schedule differs: %v vs %v", a.Intervals, b.Intervals)
+	}
+
+	// Modifying a returned schedule must not affect the cache
+	b.Intervals[0].Comment = "modified"
+	if c := first.GetDaySchedule(day); c.Intervals[0].Comment != "" {
+		t.Errorf("cached schedule was modified through a returned slice")
+	}
+}
+
+func TestScheduleCache_DifferentValuesAndDays(t *testing.T) {
+	ResetScheduleCache()
+	defer ResetScheduleCache()
+
+	weekday, _ := New("Mo-Fr 09:00-17:00")
+	weekend, _ := New("Sa-Su 10:00-14:00")
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if len(weekday.GetDaySchedule(monday).Intervals) != 1 {
+		t.Errorf("expected weekday value to be open on Monday")
+	}
+	if len(weekend.GetDaySchedule(monday).Intervals) != 0 {
+		t.Errorf("expected weekend value to be closed on Monday")
+	}
+	if len(weekend.GetDaySchedule(monday.AddDate(0, 0, 5)).Intervals) != 1 {
+		t.Errorf("expected weekend value to be open on Saturday")
+	}
+
+	if stats := GetScheduleCacheStats(); stats.Entries != 3 || stats.Hits != 0 {
+		t.Errorf("stats = %+v, want 3 entries and no hits", stats)
+	}
+}
+
+func TestScheduleCache_UnnamedFixedZones(t *testing.T) {
+	ResetScheduleCache()
+	defer ResetScheduleCache()
+
+	oh, _ := New("Mo-Fr 09:00-17:00")
+	east := time.Date(2024, 1, 15, 0, 0, 0, 0, time.FixedZone("", 2*3600))
+	west := time.Date(2024, 1, 15, 0, 0, 0, 0, time.FixedZone("", -5*3600))
+
+	oh.GetDaySchedule(east)
+	got := oh.GetDaySchedule(west)
+	want := time.Date(2024, 1, 15, 9, 0, 0, 0, west.Location())
+	if len(got.Intervals) != 1 || !got.Intervals[0].Start.Equal(want) {
+		t.Errorf("schedule in UTC-5 = %v, want one interval from %v", got.Intervals, want)
+	}
+	if stats := GetScheduleCacheStats(); stats.Entries != 2 || stats.Hits != 0 {
+		t.Errorf("stats = %+v, want 2 entries and no hits", stats)
+	}
+}
+
+func TestScheduleCache_SkipsHolidayCheckers(t *testing.T) {
+	ResetScheduleCache()
+	defer ResetScheduleCache()
+
+	oh, _ := New("Mo-Fr 09:00-17:00; PH off")
+	oh.SetHolidayChecker(HolidayCheckerFunc(func(t time.Time) bool {
+		return t.Month() == time.January && t.Day() == 1
+	}))
+
+	if len(oh.GetDaySchedule(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).Intervals) != 0 {
+		t.Errorf("expected Jan 1 to be closed (PH)")
+	}
+	if stats := GetScheduleCacheStats(); stats.Hits != 0 || stats.Misses != 0 || stats.Entries != 0 {
+		t.Errorf("stats = %+v, want cache untouched for instances with holiday checkers", stats)
+	}
+}
+
+func TestScheduleCache_ParseOptions(t *testing.T) {
+	ResetScheduleCache()
+	defer ResetScheduleCache()
+
+	day := time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	// The date-only rule takes the "off" of the next rule only when merged
+	merged, _ := New("Dec 24;Dec 25 off", WithMergedSplitDates())
+	split, _ := New("Dec 24;Dec 25 off")
+	if got := merged.GetDaySchedule(day).Intervals; len(got) != 0 {
+		t.Errorf("merged: Dec 24 intervals = %v, want closed", got)
+	}
+	if got := split.GetDaySchedule(day).Intervals; len(got) != 1 {
+		t.Errorf("split: Dec 24 intervals = %v, want open all day", got)
+	}
+
+	// Providers of the same name may resolve to different dates
+	first, _ := New("Mo-Su 10:00-12:00; feast off", WithVariableDates(scheduleCacheFeast{time.December, 24}))
+	second, _ := New("Mo-Su 10:00-12:00; feast off", WithVariableDates(scheduleCacheFeast{time.December, 26}))
+	if got := first.GetDaySchedule(day).Intervals; len(got) != 0 {
+		t.Errorf("first: Dec 24 intervals = %v, want closed", got)
+	}
+	if got := second.GetDaySchedule(day).Intervals; len(got) != 1 {
+		t.Errorf("second: Dec 24 intervals = %v, want open", got)
+	}
+}
+
+// scheduleCacheFeast is a movable date named "feast" on a fixed day
+type scheduleCacheFeast struct {
+	month time.Month
+	day   int
+}
+
+func (scheduleCacheFeast) Name() string { return "feast" }
+
+func (f scheduleCacheFeast) Date(year int) time.Time {
+	return time.Date(year, f.month, f.day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestScheduleCache_Disabled(t *testing.T) {
+	ResetScheduleCache()
+	SetScheduleCacheSize(0)
+	defer func() {
+		SetScheduleCacheSize(defaultScheduleCacheSize)
+		ResetScheduleCache()
+	}()
+
+	oh, _ := New("Mo-Fr 09:00-17:00")
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	oh.GetDaySchedule(day)
+	oh.GetDaySchedule(day)
+
+	if stats := GetScheduleCacheStats(); stats.Entries != 0 || stats.Hits != 0 {
+		t.Errorf("stats = %+v, want empty cache when disabled", stats)
+	}
+}
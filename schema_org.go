@@ -0,0 +1,475 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// schemaDayNames are the schema.org DayOfWeek names, index 0=Sunday
+var schemaDayNames = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// schemaDayOrder lists the weekdays starting on Monday, the order of exported specifications
+var schemaDayOrder = [7]int{1, 2, 3, 4, 5, 6, 0}
+
+// OpeningHoursSpecification is a schema.org OpeningHoursSpecification as used in
+// JSON-LD structured data, see https://schema.org/OpeningHoursSpecification.
+// Specifications with ValidFrom and ValidThrough (dates like "2024-12-24")
+// override the regular hours on the days they cover. A day closed all day has
+// Opens and Closes set to "00:00" and a day open all day closes at "23:59".
+// Hours past midnight close on the next day, e.g. "22:00" to "02:00", and a
+// day open until midnight closes at "00:00".
+type OpeningHoursSpecification struct {
+	Type         string     `json:"@type"`
+	DayOfWeek    DaysOfWeek `json:"dayOfWeek,omitempty"`
+	Opens        string     `json:"opens"`
+	Closes       string     `json:"closes"`
+	ValidFrom    string     `json:"validFrom,omitempty"`
+	ValidThrough string     `json:"validThrough,omitempty"`
+}
+
+// DaysOfWeek is the dayOfWeek property of an OpeningHoursSpecification, e.g.
+// ["Monday", "Tuesday"]. When unmarshaling, a single day like "Monday" is
+// accepted as well.
+type DaysOfWeek []string
+
+// UnmarshalJSON accepts a list of days or a single day
+func (d *DaysOfWeek) UnmarshalJSON(data []byte) error {
+	var days []string
+	if err := json.Unmarshal(data, &days); err != nil {
+		var day string
+		if json.Unmarshal(data, &day) != nil {
+			return err
+		}
+		days = []string{day}
+	}
+	*d = days
+	return nil
+}
+
+// daySpan is an open span of a day in minutes from midnight. end is at most
+// 1440, or up to 2880 for a span continued on the next day, see joinOvernight.
+type daySpan struct {
+	start, end int
+}
+
+// ToOpeningHoursSpecification exports oh as schema.org specifications. The
+// regular week is taken from the rules that only select weekdays and times.
+// Days of the following year whose hours differ from the regular week, e.g.
+// "Dec 25 off" or public holidays of the holiday checker, are exported as
+// specifications with ValidFrom and ValidThrough. Without options the year
+// starts today; see WithReferenceDate. Comments are dropped. Values with
+// unknown states, open ends, periodic times or variable times like sunset
+// cannot be represented and return an error.
+func (oh *OpeningHours) ToOpeningHoursSpecification(opts ...WeekOption) ([]OpeningHoursSpecification, error) {
+	if err := oh.checkSpecificationSupport(); err != nil {
+		return nil, err
+	}
+	o := newWeekOptions(opts)
+	ref := oh.inLocation(o.referenceDate)
+	first := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, ref.Location())
+
+	// Regular week from the rules that only select weekdays
+	weekly := oh.weeklyRules()
+	var week [7][]daySpan
+	monday := weekStart(first, time.Monday)
+	for i := 0; i < 7; i++ {
+		day := monday.AddDate(0, 0, i)
+		spans, err := weekly.daySpans(day)
+		if err != nil {
+			return nil, err
+		}
+		week[day.Weekday()] = spans
+	}
+	raw := week
+	for wd := range week {
+		week[wd] = joinOvernight(raw[(wd+6)%7], raw[wd], raw[(wd+1)%7])
+	}
+	specs := weekSpecs(week, [7]bool{true, true, true, true, true, true, true}, "", "")
+
+	// Days of the following year that differ from the regular week, with the
+	// days around them for the hours past midnight
+	rawDays := make([][]daySpan, 368)
+	for i := range rawDays {
+		spans, err := oh.daySpans(first.AddDate(0, 0, i-1))
+		if err != nil {
+			return nil, err
+		}
+		rawDays[i] = spans
+	}
+	days := make([][]daySpan, 366)
+	var special []int
+	for i := range days {
+		days[i] = joinOvernight(rawDays[i], rawDays[i+1], rawDays[i+2])
+		if !slices.Equal(days[i], week[first.AddDate(0, 0, i).Weekday()]) {
+			special = append(special, i)
+		}
+	}
+
+	// Special days less than a week apart form a period, e.g. "Apr-Sep Mo-Fr"
+	for len(special) > 0 {
+		n := 1
+		for n < len(special) && special[n]-special[n-1] < 7 {
+			n++
+		}
+		specs = append(specs, periodSpecs(first, days, special[0], special[n-1], special[:n])...)
+		special = special[n:]
+	}
+
+	return specs, nil
+}
+
+// checkSpecificationSupport returns an error if a rule uses times that cannot
+// be represented in an OpeningHoursSpecification
+func (oh *OpeningHours) checkSpecificationSupport() error {
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			for _, tr := range r.timeRanges {
+				if tr.startVar != "" || tr.endVar != "" || tr.openEnd || tr.interval > 0 {
+					return fmt.Errorf("time cannot be represented in an OpeningHoursSpecification: %s", prettifyOptions{}.prettifyTimeRange(tr))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// weeklyRules returns a copy of oh with only the rules that select weekdays
+// and times, which make up the regular week
+func (oh *OpeningHours) weeklyRules() *OpeningHours {
+	c := oh.Clone()
+	dated := func(r rule) bool {
+		return r.yearStart != 0 || r.dateStart != 0 || r.monthStart != 0 || r.isPH || r.isSH ||
+			r.holidayUnion || r.isEaster || len(r.weekConstraints) > 0 || len(r.weekdayConstraints) > 0
+	}
+	c.rules = slices.DeleteFunc(c.rules, dated)
+	for i := range c.fallbackGroups {
+		c.fallbackGroups[i] = slices.DeleteFunc(c.fallbackGroups[i], dated)
+	}
+	c.holidayChecker = nil
+	c.schoolHolidayChecker = nil
+	c.orderAllRules()
+	return c
+}
+
+// daySpans returns the open spans of the day starting at day
+func (oh *OpeningHours) daySpans(day time.Time) ([]daySpan, error) {
+	var spans []daySpan
+	for _, iv := range oh.GetOpenIntervals(day, day.AddDate(0, 0, 1)) {
+		if iv.Unknown {
+			return nil, fmt.Errorf("unknown state cannot be represented in an OpeningHoursSpecification: %s", iv.Start.Format("2006-01-02 15:04"))
+		}
+		spans = append(spans, daySpan{
+			start: int(iv.Start.Sub(day) / time.Minute),
+			end:   int(iv.End.Sub(day) / time.Minute),
+		})
+	}
+	return spans, nil
+}
+
+// joinOvernight returns the spans of a day between the days prev and next. A
+// last span until midnight is continued by the first span of next if it starts
+// at midnight and ends before the last span starts, like "22:00-02:00", which
+// then is left out of next.
+func joinOvernight(prev, spans, next []daySpan) []daySpan {
+	if overnightEnd(prev, spans) > 0 {
+		spans = spans[1:]
+	}
+	if end := overnightEnd(spans, next); end > 0 {
+		last := spans[len(spans)-1]
+		spans = append(slices.Clone(spans[:len(spans)-1]), daySpan{last.start, 24*60 + end})
+	}
+	return spans
+}
+
+// overnightEnd returns the end of the first span of next if it continues the
+// last span of spans past midnight, see joinOvernight, or 0
+func overnightEnd(spans, next []daySpan) int {
+	if len(spans) == 0 || len(next) == 0 {
+		return 0
+	}
+	last := spans[len(spans)-1]
+	if last.end != 24*60 || next[0].start != 0 || next[0].end >= last.start {
+		return 0
+	}
+	return next[0].end
+}
+
+// periodSpecs returns the specifications of the days from days[from] to
+// days[through], first being the day of days[0]. If each weekday has the same
+// hours throughout the period, the special weekdays share specifications valid
+// for the whole period, otherwise each special day gets its own.
+func periodSpecs(first time.Time, days [][]daySpan, from, through int, special []int) []OpeningHoursSpecification {
+	var week [7][]daySpan
+	var seen, include [7]bool
+	consistent := true
+	for i := from; i <= through; i++ {
+		wd := first.AddDate(0, 0, i).Weekday()
+		if seen[wd] && !slices.Equal(week[wd], days[i]) {
+			consistent = false
+			break
+		}
+		week[wd] = days[i]
+		seen[wd] = true
+	}
+
+	if !consistent {
+		var specs []OpeningHoursSpecification
+		for _, i := range special {
+			day := first.AddDate(0, 0, i)
+			include = [7]bool{}
+			include[day.Weekday()] = true
+			week[day.Weekday()] = days[i]
+			date := day.Format("2006-01-02")
+			specs = append(specs, weekSpecs(week, include, date, date)...)
+		}
+		return specs
+	}
+
+	for _, i := range special {
+		include[first.AddDate(0, 0, i).Weekday()] = true
+	}
+	return weekSpecs(week, include, first.AddDate(0, 0, from).Format("2006-01-02"), first.AddDate(0, 0, through).Format("2006-01-02"))
+}
+
+// weekSpecs returns the specifications of the included weekdays, grouping
+// weekdays with the same hours. Closed weekdays are only exported for periods
+// (validFrom set); dayOfWeek is omitted for single days and groups of all weekdays.
+func weekSpecs(week [7][]daySpan, include [7]bool, validFrom, validThrough string) []OpeningHoursSpecification {
+	var groups [][]int
+	for _, wd := range schemaDayOrder {
+		if !include[wd] {
+			continue
+		}
+		idx := slices.IndexFunc(groups, func(g []int) bool { return slices.Equal(week[g[0]], week[wd]) })
+		if idx < 0 {
+			groups = append(groups, []int{wd})
+		} else {
+			groups[idx] = append(groups[idx], wd)
+		}
+	}
+
+	var specs []OpeningHoursSpecification
+	for _, group := range groups {
+		var dayNames DaysOfWeek
+		if validFrom == "" || (validFrom != validThrough && len(group) < 7) {
+			for _, wd := range group {
+				dayNames = append(dayNames, schemaDayNames[wd])
+			}
+		}
+		spans := week[group[0]]
+		if len(spans) == 0 {
+			if validFrom == "" {
+				continue
+			}
+			spans = []daySpan{{0, 0}}
+		}
+		for _, span := range spans {
+			// "00:00" to "00:00" is closed, so a day open all day closes at "23:59"
+			closes := formatSchemaTime(span.end)
+			if span == (daySpan{0, 24 * 60}) {
+				closes = "23:59"
+			}
+			specs = append(specs, OpeningHoursSpecification{
+				Type:         "OpeningHoursSpecification",
+				DayOfWeek:    dayNames,
+				Opens:        formatSchemaTime(span.start),
+				Closes:       closes,
+				ValidFrom:    validFrom,
+				ValidThrough: validThrough,
+			})
+		}
+	}
+	return specs
+}
+
+// formatSchemaTime formats minutes from midnight, times on the next day like
+// midnight at the end of the day as the time of that day, e.g. "00:00"
+func formatSchemaTime(minutes int) string {
+	minutes %= 24 * 60
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// FromOpeningHoursSpecification builds OpeningHours from schema.org
+// specifications. Specifications without ValidFrom and ValidThrough make up the
+// regular week; the others become date rules like "2024 Dec 24 off" that
+// override it. Days may be given as "Monday" or "https://schema.org/Monday",
+// times as "09:00" or "09:00:00"; closing at "00:00" or "23:59" means open
+// until midnight, closing before opening means open past midnight.
+func FromOpeningHoursSpecification(specs []OpeningHoursSpecification, opts ...Option) (*OpeningHours, error) {
+	type period struct {
+		from, through time.Time
+		days          [7][]string // time ranges per weekday, "off" if closed
+	}
+	var week [7][]string
+	var periods []*period
+
+	for _, spec := range specs {
+		weekdays, err := parseSchemaDays(spec.DayOfWeek)
+		if err != nil {
+			return nil, err
+		}
+		timeRange, err := parseSchemaTimes(spec.Opens, spec.Closes)
+		if err != nil {
+			return nil, err
+		}
+
+		days := &week
+		if spec.ValidFrom != "" || spec.ValidThrough != "" {
+			from, through, err := parseSchemaValidity(spec.ValidFrom, spec.ValidThrough)
+			if err != nil {
+				return nil, err
+			}
+			idx := slices.IndexFunc(periods, func(p *period) bool { return p.from.Equal(from) && p.through.Equal(through) })
+			if idx < 0 {
+				periods = append(periods, &period{from: from, through: through})
+				idx = len(periods) - 1
+			}
+			days = &periods[idx].days
+		} else if timeRange == "off" {
+			continue
+		}
+
+		for wd, ok := range weekdays {
+			if !ok {
+				continue
+			}
+			switch {
+			case timeRange == "off":
+				if len(days[wd]) == 0 {
+					days[wd] = []string{"off"}
+				}
+			case slices.Equal(days[wd], []string{"off"}):
+				days[wd] = []string{timeRange}
+			default:
+				days[wd] = append(days[wd], timeRange)
+			}
+		}
+	}
+
+	var rules []string
+	if weekly := weekRules(week, ""); len(weekly) > 0 {
+		rules = append(rules, strings.Join(weekly, ", "))
+	}
+	for _, p := range periods {
+		selector := p.from.Format("2006 Jan 02")
+		if !p.through.Equal(p.from) {
+			selector += "-" + p.through.Format("2006 Jan 02")
+		}
+		rules = append(rules, strings.Join(weekRules(p.days, selector), ", "))
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no opening hours in specifications")
+	}
+	return New(strings.Join(rules, "; "), opts...)
+}
+
+// weekRules returns one rule per group of weekdays with the same time ranges,
+// prefixed with selector. A group of all weekdays in a period omits the weekdays.
+// The rules are meant to be joined by ",", so that ranges spanning midnight
+// like "Fr 22:00-02:00" are not hidden by the rule of the following day.
+func weekRules(week [7][]string, selector string) []string {
+	var rules []string
+	done := [7]bool{}
+	for _, wd := range schemaDayOrder {
+		if done[wd] || len(week[wd]) == 0 {
+			continue
+		}
+		weekdays := make([]bool, 7)
+		for _, other := range schemaDayOrder {
+			if slices.Equal(week[other], week[wd]) {
+				weekdays[other] = true
+				done[other] = true
+			}
+		}
+
+		parts := []string{}
+		if selector != "" {
+			parts = append(parts, selector)
+		}
+		if selector == "" || slices.Contains(weekdays, false) {
+			parts = append(parts, prettifyOptions{}.prettifyWeekdays(weekdays, nil))
+		}
+		parts = append(parts, strings.Join(week[wd], ","))
+		rules = append(rules, strings.Join(parts, " "))
+	}
+	return rules
+}
+
+// parseSchemaDays parses schema.org day names, all days if none are given
+func parseSchemaDays(names DaysOfWeek) ([7]bool, error) {
+	var weekdays [7]bool
+	if len(names) == 0 {
+		return [7]bool{true, true, true, true, true, true, true}, nil
+	}
+	for _, name := range names {
+		name = name[strings.LastIndex(name, "/")+1:]
+		idx := slices.IndexFunc(schemaDayNames[:], func(day string) bool { return strings.EqualFold(day, name) })
+		if idx < 0 {
+			return weekdays, fmt.Errorf("invalid day of week: %s", name)
+		}
+		weekdays[idx] = true
+	}
+	return weekdays, nil
+}
+
+// parseSchemaTimes converts opens and closes to a time range like "09:00-17:00",
+// or "off" if both are equal
+func parseSchemaTimes(opens, closes string) (string, error) {
+	start, err := parseSchemaTime(opens)
+	if err != nil {
+		return "", err
+	}
+	end, err := parseSchemaTime(closes)
+	if err != nil {
+		return "", err
+	}
+	if start == end {
+		return "off", nil
+	}
+	if end == 23*60+59 || end == 0 {
+		end = 24 * 60
+	}
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", start/60, start%60, end/60, end%60), nil
+}
+
+// parseSchemaTime parses "09:00" or "09:00:00" to minutes from midnight
+func parseSchemaTime(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		t, err = time.Parse("15:04:05", s)
+	}
+	if err != nil {
+		if s == "24:00" || s == "24:00:00" {
+			return 24 * 60, nil
+		}
+		return 0, fmt.Errorf("invalid time: %s", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseSchemaValidity parses validFrom and validThrough dates, either may be
+// missing for a single day. Times of date-times are ignored.
+func parseSchemaValidity(validFrom, validThrough string) (time.Time, time.Time, error) {
+	if validFrom == "" {
+		validFrom = validThrough
+	}
+	if validThrough == "" {
+		validThrough = validFrom
+	}
+	from, err := time.Parse("2006-01-02", validFrom[:min(len(validFrom), 10)])
+	if err != nil {
+		return from, from, fmt.Errorf("invalid date: %s", validFrom)
+	}
+	through, err := time.Parse("2006-01-02", validThrough[:min(len(validThrough), 10)])
+	if err != nil {
+		return from, through, fmt.Errorf("invalid date: %s", validThrough)
+	}
+	if through.Before(from) {
+		return from, through, fmt.Errorf("validThrough before validFrom: %s", validThrough)
+	}
+	return from, through, nil
+}
@@ -0,0 +1,156 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var schemaReference = WithReferenceDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+func TestToOpeningHoursSpecification(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Mo-Fr 09:00-17:00; Sa 10:00-14:00",
+			`[{"@type":"OpeningHoursSpecification","dayOfWeek":["Monday","Tuesday","Wednesday","Thursday","Friday"],"opens":"09:00","closes":"17:00"},` +
+				`{"@type":"OpeningHoursSpecification","dayOfWeek":["Saturday"],"opens":"10:00","closes":"14:00"}]`},
+		{"24/7", `[{"@type":"OpeningHoursSpecification","dayOfWeek":["Monday","Tuesday","Wednesday","Thursday","Friday","Saturday","Sunday"],"opens":"00:00","closes":"23:59"}]`},
+		// Hours past midnight close on the next day
+		{"Fr 22:00-02:00, Sa 10:00-24:00",
+			`[{"@type":"OpeningHoursSpecification","dayOfWeek":["Friday"],"opens":"22:00","closes":"02:00"},` +
+				`{"@type":"OpeningHoursSpecification","dayOfWeek":["Saturday"],"opens":"10:00","closes":"00:00"}]`},
+		// Date rules become periods overriding the regular week
+		{"Mo 09:00-12:00,13:00-17:00; Dec 23 off",
+			`[{"@type":"OpeningHoursSpecification","dayOfWeek":["Monday"],"opens":"09:00","closes":"12:00"},` +
+				`{"@type":"OpeningHoursSpecification","dayOfWeek":["Monday"],"opens":"13:00","closes":"17:00"},` +
+				`{"@type":"OpeningHoursSpecification","opens":"00:00","closes":"00:00","validFrom":"2024-12-23","validThrough":"2024-12-23"}]`},
+		{"Apr-Sep Mo-Fr 10:00-18:00",
+			`[{"@type":"OpeningHoursSpecification","dayOfWeek":["Monday","Tuesday","Wednesday","Thursday","Friday"],"opens":"10:00","closes":"18:00","validFrom":"2024-04-01","validThrough":"2024-09-30"}]`},
+		{"Mo-Fr 10:00-18:00; Dec 24 10:00-14:00",
+			`[{"@type":"OpeningHoursSpecification","dayOfWeek":["Monday","Tuesday","Wednesday","Thursday","Friday"],"opens":"10:00","closes":"18:00"},` +
+				`{"@type":"OpeningHoursSpecification","opens":"10:00","closes":"14:00","validFrom":"2024-12-24","validThrough":"2024-12-24"}]`},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		specs, err := oh.ToOpeningHoursSpecification(schemaReference)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.value, err)
+		}
+		data, err := json.Marshal(specs)
+		if err != nil {
+			t.Fatalf("%q: failed to marshal: %v", tc.value, err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("%q:\n got %s\nwant %s", tc.value, data, tc.expected)
+		}
+	}
+}
+
+func TestToOpeningHoursSpecification_PublicHolidays(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; PH off")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	oh.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-05-01": true}})
+
+	specs, err := oh.ToOpeningHoursSpecification(schemaReference)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := specs[len(specs)-1]
+	if len(specs) != 2 || last.ValidFrom != "2024-05-01" || last.Opens != "00:00" || last.Closes != "00:00" {
+		t.Errorf("expected the holiday closed, got %+v", specs)
+	}
+}
+
+func TestToOpeningHoursSpecification_Unsupported(t *testing.T) {
+	for _, value := range []string{"Mo-Fr sunrise-sunset", "Mo 17:00+", "Mo-Fr 10:00-16:00/01:30", "Mo unknown"} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", value, err)
+		}
+		if _, err := oh.ToOpeningHoursSpecification(schemaReference); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestFromOpeningHoursSpecification(t *testing.T) {
+	data := `[
+		{"@type": "OpeningHoursSpecification", "dayOfWeek": ["https://schema.org/Monday", "Tuesday"], "opens": "09:00:00", "closes": "17:00:00"},
+		{"@type": "OpeningHoursSpecification", "dayOfWeek": "Saturday", "opens": "22:00", "closes": "02:00"},
+		{"@type": "OpeningHoursSpecification", "dayOfWeek": "Sunday", "opens": "10:00", "closes": "23:59"},
+		{"@type": "OpeningHoursSpecification", "opens": "00:00", "closes": "00:00", "validFrom": "2024-12-24", "validThrough": "2024-12-26"},
+		{"@type": "OpeningHoursSpecification", "dayOfWeek": "Monday", "opens": "10:00", "closes": "12:00", "validFrom": "2024-12-30", "validThrough": "2025-01-05"}
+	]`
+	var specs []OpeningHoursSpecification
+	if err := json.Unmarshal([]byte(data), &specs); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	oh, err := FromOpeningHoursSpecification(specs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "Mo-Tu 09:00-17:00, Sa 22:00-02:00, Su 10:00-24:00; 2024 Dec 24-2024 Dec 26 off; 2024 Dec 30-2025 Jan 05 Mo 10:00-12:00"
+	if got := oh.PrettifyValue(); got != expected {
+		t.Errorf("PrettifyValue = %q, want %q", got, expected)
+	}
+	if oh.GetState(time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected closed on Dec 24")
+	}
+	if oh.GetState(time.Date(2024, 12, 30, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the period to override Monday")
+	}
+}
+
+func TestFromOpeningHoursSpecification_Errors(t *testing.T) {
+	testCases := [][]OpeningHoursSpecification{
+		nil,
+		{{DayOfWeek: DaysOfWeek{"Funday"}, Opens: "09:00", Closes: "17:00"}},
+		{{Opens: "9am", Closes: "17:00"}},
+		{{Opens: "09:00", Closes: "17:00", ValidFrom: "2024-12-26", ValidThrough: "2024-12-24"}},
+	}
+
+	for _, specs := range testCases {
+		if _, err := FromOpeningHoursSpecification(specs); err == nil {
+			t.Errorf("%+v: expected an error", specs)
+		}
+	}
+}
+
+func TestOpeningHoursSpecification_RoundTrip(t *testing.T) {
+	for _, value := range []string{
+		"Mo-Fr 09:00-12:00,13:00-17:00; Sa 10:00-14:00; Dec 25 off",
+		"Mo-Fr 22:00-02:00",
+		"Fr 22:00-02:00; Dec 27 off",
+		"Jul-Aug Sa-Su 10:00-16:00; Mo-Fr 08:00-18:00",
+	} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", value, err)
+		}
+		specs, err := oh.ToOpeningHoursSpecification(schemaReference)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", value, err)
+		}
+		back, err := FromOpeningHoursSpecification(specs)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", value, err)
+		}
+
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		for at := from; at.Before(from.AddDate(1, 0, 0)); at = at.Add(time.Hour) {
+			if oh.GetState(at) != back.GetState(at) {
+				t.Errorf("%q: state differs at %v, round trip %q", value, at, back.PrettifyValue())
+				break
+			}
+		}
+	}
+}
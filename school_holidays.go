@@ -0,0 +1,231 @@
+package openinghours
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchoolHolidayPolicy decides how SH rules are evaluated when no
+// SchoolHolidayChecker is set
+type SchoolHolidayPolicy int
+
+const (
+	// SchoolHolidaysIgnore never matches SH rules, so the regular rules apply
+	// on every day. This is the default.
+	SchoolHolidaysIgnore SchoolHolidayPolicy = iota
+	// SchoolHolidaysUnknown reports the state as unknown on every day an SH
+	// rule could apply to, because the result depends on missing data
+	SchoolHolidaysUnknown
+)
+
+// WithSchoolHolidayPolicy sets the policy for SH rules without a SchoolHolidayChecker
+func WithSchoolHolidayPolicy(p SchoolHolidayPolicy) Option {
+	return func(oh *OpeningHours) {
+		oh.schoolHolidayPolicy = p
+	}
+}
+
+// SetSchoolHolidayPolicy sets the policy for SH rules without a SchoolHolidayChecker
+func (oh *OpeningHours) SetSchoolHolidayPolicy(p SchoolHolidayPolicy) {
+	oh.schoolHolidayPolicy = p
+}
+
+// NeedsSchoolHolidayChecker reports whether the value references school
+// holidays (SH) but no SchoolHolidayChecker is set. Integrators can use it to
+// notice values whose evaluation is incomplete.
+func (oh *OpeningHours) NeedsSchoolHolidayChecker() bool {
+	return oh.schoolHolidayChecker == nil && oh.hasSchoolHolidayRule()
+}
+
+func (oh *OpeningHours) hasSchoolHolidayRule() bool {
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			if r.isSH {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSchoolHolidayRuleFor checks if any SH rule could apply to the given day.
+// A rule like "SH Mo-Fr 10:00-14:00" only takes over school holidays on
+// weekdays, so regular rules still apply on the weekends of school holidays.
+func (oh *OpeningHours) hasSchoolHolidayRuleFor(t time.Time) bool {
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			if r.isSH && r.couldApplyOnSchoolHoliday(t, oh) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schoolHolidaysUnknown checks if the state at t is unknown because an SH rule
+// could apply to the day but there is no checker to tell (SchoolHolidaysUnknown)
+func (oh *OpeningHours) schoolHolidaysUnknown(t time.Time) bool {
+	if oh.schoolHolidayPolicy != SchoolHolidaysUnknown || oh.schoolHolidayChecker != nil {
+		return false
+	}
+
+	groups := append([][]rule{oh.rules}, oh.fallbackGroups...)
+	for _, group := range groups {
+		for _, r := range group {
+			if r.isSH && r.couldApplyOnSchoolHoliday(t, oh) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// couldApplyOnSchoolHoliday checks the selectors of an SH rule other than SH itself,
+// e.g. the weekdays of "SH Mo-Fr 10:00-12:00"
+func (r *rule) couldApplyOnSchoolHoliday(t time.Time, oh *OpeningHours) bool {
+	other := *r
+	other.isSH = false
+	if other.holidayUnion {
+		// "Sa,SH" applies on every school holiday, whatever the weekday
+		other.holidayUnion, other.isPH = false, false
+		other.weekdays, other.weekdayConstraints = nil, nil
+	}
+	if other.weekdays == nil && !other.isPH && !other.isEaster && other.monthStart == 0 &&
+		other.yearStart == 0 && len(other.weekConstraints) == 0 && len(other.weekdayConstraints) == 0 {
+		// Plain "SH ..." could apply on any day
+		return true
+	}
+	return other.matchesSelectorWithOH(t, oh.holidayChecker, oh)
+}
+
+// SchoolHolidayNamer is implemented by school holiday checkers that know the
+// name of the school holiday period of a day. GetComment returns the name for
+// matching SH rules without a comment of their own.
+type SchoolHolidayNamer interface {
+	SchoolHolidayName(t time.Time) string
+}
+
+// SchoolHolidayPeriod is a named range of school holidays
+type SchoolHolidayPeriod struct {
+	Name  string    // e.g. "Sommerferien 2024"
+	Start time.Time // first day, only the date is used
+	End   time.Time // last day (inclusive), only the date is used
+}
+
+// SchoolHolidayTable is a SchoolHolidayChecker and SchoolHolidayNamer backed by
+// a list of named periods. It is safe for concurrent use.
+type SchoolHolidayTable struct {
+	periods []schoolHolidayDays
+}
+
+// schoolHolidayDays is a SchoolHolidayPeriod with its dates as yyyymmdd
+type schoolHolidayDays struct {
+	name       string
+	start, end int
+}
+
+// dateKey returns the calendar date of t (in t's location) as yyyymmdd
+func dateKey(t time.Time) int {
+	return t.Year()*10000 + int(t.Month())*100 + t.Day()
+}
+
+// NewSchoolHolidayTable returns a table of the given periods. If periods
+// overlap, the name of the first one is used.
+func NewSchoolHolidayTable(periods ...SchoolHolidayPeriod) (*SchoolHolidayTable, error) {
+	table := &SchoolHolidayTable{}
+	for _, p := range periods {
+		days := schoolHolidayDays{name: p.Name, start: dateKey(p.Start), end: dateKey(p.End)}
+		if days.end < days.start {
+			return nil, fmt.Errorf("school holiday period %q ends before it starts", p.Name)
+		}
+		table.periods = append(table.periods, days)
+	}
+	return table, nil
+}
+
+var schoolHolidayLinePattern = regexp.MustCompile(`^(.*\b(\d{4}))\s*:\s*([A-Za-z]{3})\s+(\d{1,2})\s*-\s*(?:([A-Za-z]{3})\s+)?(\d{1,2})$`)
+
+// ParseSchoolHolidayTable returns a table of periods written as
+// "<name> <year>: <month> <day>-[<month>] <day>", e.g. "Sommerferien 2024: Jul 20-Sep 2"
+// or "Herbstferien 2024: Oct 28-31". The name includes the year. A period that
+// ends in an earlier month than it starts ends in the following year
+// ("Weihnachtsferien 2024: Dec 23-Jan 4").
+func ParseSchoolHolidayTable(lines []string) (*SchoolHolidayTable, error) {
+	var periods []SchoolHolidayPeriod
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		match := schoolHolidayLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("invalid school holiday period: %s", line)
+		}
+
+		year, _ := strconv.Atoi(match[2])
+		startMonth, ok := monthNames[strings.ToLower(match[3])]
+		if !ok {
+			return nil, fmt.Errorf("invalid month in school holiday period: %s", line)
+		}
+		endMonth := startMonth
+		if match[5] != "" {
+			if endMonth, ok = monthNames[strings.ToLower(match[5])]; !ok {
+				return nil, fmt.Errorf("invalid month in school holiday period: %s", line)
+			}
+		}
+		startDay, _ := strconv.Atoi(match[4])
+		endDay, _ := strconv.Atoi(match[6])
+		if startDay < 1 || startDay > maxDaysInMonth[startMonth] || endDay < 1 || endDay > maxDaysInMonth[endMonth] {
+			return nil, fmt.Errorf("invalid day in school holiday period: %s", line)
+		}
+
+		endYear := year
+		if endMonth < startMonth {
+			endYear++
+		}
+		periods = append(periods, SchoolHolidayPeriod{
+			Name:  strings.TrimSpace(match[1]),
+			Start: time.Date(year, time.Month(startMonth), startDay, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(endYear, time.Month(endMonth), endDay, 0, 0, 0, 0, time.UTC),
+		})
+	}
+	return NewSchoolHolidayTable(periods...)
+}
+
+// IsSchoolHoliday reports whether the calendar date of t (in t's location) is in a period
+func (st *SchoolHolidayTable) IsSchoolHoliday(t time.Time) bool {
+	_, ok := st.period(t)
+	return ok
+}
+
+// SchoolHolidayName returns the name of the period of t, or "" if t is not a school holiday
+func (st *SchoolHolidayTable) SchoolHolidayName(t time.Time) string {
+	name, _ := st.period(t)
+	return name
+}
+
+// period returns the name of the first period containing the date of t
+func (st *SchoolHolidayTable) period(t time.Time) (string, bool) {
+	key := dateKey(t)
+	for _, p := range st.periods {
+		if key >= p.start && key <= p.end {
+			return p.name, true
+		}
+	}
+	return "", false
+}
+
+// schoolHolidayName returns the name of the school holiday period of t, if the
+// school holiday checker is a SchoolHolidayNamer and t is a school holiday
+func (oh *OpeningHours) schoolHolidayName(t time.Time) string {
+	namer, ok := oh.schoolHolidayChecker.(SchoolHolidayNamer)
+	if !ok || !oh.schoolHolidayChecker.IsSchoolHoliday(t) {
+		return ""
+	}
+	return namer.SchoolHolidayName(t)
+}
@@ -374,3 +374,197 @@ func TestSchoolHoliday_GetMatchingRule(t *testing.T) {
 		t.Errorf("expected matching rule index 0 (regular rule) on non-holiday, got %d", ruleIndex)
 	}
 }
+
+func TestSchoolHoliday_NeedsChecker(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; SH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if !oh.NeedsSchoolHolidayChecker() {
+		t.Error("expected NeedsSchoolHolidayChecker without a checker")
+	}
+
+	oh.SetSchoolHolidayChecker(&mockSchoolHolidayChecker{})
+	if oh.NeedsSchoolHolidayChecker() {
+		t.Error("expected NeedsSchoolHolidayChecker to be false with a checker")
+	}
+
+	plain, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if plain.NeedsSchoolHolidayChecker() {
+		t.Error("value without SH must not need a school holiday checker")
+	}
+}
+
+func TestSchoolHoliday_PolicyIgnore(t *testing.T) {
+	// Default: SH rules never match, regular rules apply
+	oh, err := New("Mo-Fr 09:00-17:00; SH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Monday Jan 15, 2024
+	if got := oh.GetStateString(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)); got != "open" {
+		t.Errorf("got %q, want open", got)
+	}
+}
+
+func TestSchoolHoliday_PolicyUnknown(t *testing.T) {
+	tests := []struct {
+		value string
+		time  time.Time
+		want  string
+	}{
+		// Any day could be a school holiday
+		{"Mo-Fr 09:00-17:00; SH off", time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), "unknown"},
+		{"Mo-Fr 09:00-17:00; SH off", time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC), "unknown"},
+		// Only weekdays covered by the SH rule are unknown
+		{"Mo-Su 09:00-17:00; SH Mo-Fr 10:00-12:00", time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC), "unknown"},
+		{"Mo-Su 09:00-17:00; SH Mo-Fr 10:00-12:00", time.Date(2024, 1, 13, 14, 0, 0, 0, time.UTC), "open"},
+		{"Mo-Su 09:00-17:00; SH Mo-Fr 10:00-12:00", time.Date(2024, 1, 13, 18, 0, 0, 0, time.UTC), "closed"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value, WithSchoolHolidayPolicy(SchoolHolidaysUnknown))
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		if got := oh.GetStateString(tt.time); got != tt.want {
+			t.Errorf("%q at %v: got %q, want %q", tt.value, tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestSchoolHoliday_PolicyUnknownWithChecker(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; SH off", WithSchoolHolidayPolicy(SchoolHolidaysUnknown))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetSchoolHolidayChecker(&mockSchoolHolidayChecker{holidays: map[string]bool{"2024-01-16": true}})
+
+	if got := oh.GetStateString(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)); got != "open" {
+		t.Errorf("regular Monday: got %q, want open", got)
+	}
+	if got := oh.GetStateString(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)); got != "closed" {
+		t.Errorf("school holiday: got %q, want closed", got)
+	}
+}
+
+func TestSchoolHoliday_PolicyUnknownIntervals(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00; SH Mo 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetSchoolHolidayPolicy(SchoolHolidaysUnknown)
+
+	// Monday Jan 15 is unknown all day, Tuesday follows the regular rule
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	intervals := oh.GetOpenIntervals(from, from.Add(48*time.Hour))
+	if len(intervals) != 2 {
+		t.Fatalf("got %d intervals %v, want 2", len(intervals), intervals)
+	}
+	if !intervals[0].Unknown || !intervals[0].End.Equal(from.Add(24*time.Hour)) {
+		t.Errorf("expected Monday to be unknown all day, got %+v", intervals[0])
+	}
+	if intervals[1].Unknown || intervals[1].Start.Hour() != 9 {
+		t.Errorf("expected regular Tuesday hours, got %+v", intervals[1])
+	}
+}
+
+func TestSchoolHolidayTable_Parse(t *testing.T) {
+	table, err := ParseSchoolHolidayTable([]string{
+		"Sommerferien 2024: Jul 20-Sep 2",
+		"Herbstferien 2024: Oct 28-31",
+		"Weihnachtsferien 2024: Dec 23-Jan 4",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		date time.Time
+		name string
+	}{
+		{time.Date(2024, 7, 19, 12, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC), "Sommerferien 2024"},
+		{time.Date(2024, 9, 2, 23, 59, 0, 0, time.UTC), "Sommerferien 2024"},
+		{time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 10, 31, 12, 0, 0, 0, time.UTC), "Herbstferien 2024"},
+		{time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC), "Weihnachtsferien 2024"},
+		{time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC), ""},
+	}
+	for _, tc := range testCases {
+		if got := table.SchoolHolidayName(tc.date); got != tc.name {
+			t.Errorf("SchoolHolidayName(%v) = %q, want %q", tc.date, got, tc.name)
+		}
+		if got := table.IsSchoolHoliday(tc.date); got != (tc.name != "") {
+			t.Errorf("IsSchoolHoliday(%v) = %v, want %v", tc.date, got, tc.name != "")
+		}
+	}
+}
+
+func TestSchoolHolidayTable_Errors(t *testing.T) {
+	for _, line := range []string{
+		"Sommerferien: Jul 20-Sep 2",
+		"Sommerferien 2024: Jux 20-Sep 2",
+		"Sommerferien 2024: Feb 30-Mar 2",
+		"Sommerferien 2024 Jul 20-Sep 2",
+	} {
+		if _, err := ParseSchoolHolidayTable([]string{line}); err == nil {
+			t.Errorf("%q: expected error", line)
+		}
+	}
+
+	_, err := NewSchoolHolidayTable(SchoolHolidayPeriod{
+		Name:  "Backwards",
+		Start: time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC),
+	})
+	if err == nil {
+		t.Error("expected error for period ending before it starts")
+	}
+}
+
+func TestSchoolHolidayTable_Comment(t *testing.T) {
+	table, err := ParseSchoolHolidayTable([]string{"Sommerferien 2024: Jul 20-Sep 2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		value   string
+		time    time.Time
+		comment string
+	}{
+		{"Mo-Fr 09:00-17:00; SH off", time.Date(2024, 7, 22, 12, 0, 0, 0, time.UTC), "Sommerferien 2024"},
+		{"Mo-Fr 09:00-17:00; SH 10:00-12:00", time.Date(2024, 7, 22, 11, 0, 0, 0, time.UTC), "Sommerferien 2024"},
+		{`Mo-Fr 09:00-17:00; SH off "summer break"`, time.Date(2024, 7, 22, 12, 0, 0, 0, time.UTC), "summer break"},
+		{"Mo-Fr 09:00-17:00; SH off", time.Date(2024, 9, 3, 12, 0, 0, 0, time.UTC), ""},
+		{"Sa,SH 10:00-12:00", time.Date(2024, 7, 24, 11, 0, 0, 0, time.UTC), "Sommerferien 2024"},
+		{"Sa,SH 10:00-12:00", time.Date(2024, 9, 7, 11, 0, 0, 0, time.UTC), ""},
+		{"Mo-Fr unknown || SH off", time.Date(2024, 7, 22, 12, 0, 0, 0, time.UTC), "Sommerferien 2024"},
+	}
+	for _, tc := range testCases {
+		oh, err := New(tc.value)
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		oh.SetSchoolHolidayChecker(table)
+		if got := oh.GetComment(tc.time); got != tc.comment {
+			t.Errorf("%q at %v: GetComment = %q, want %q", tc.value, tc.time, got, tc.comment)
+		}
+	}
+}
+
+func TestSchoolHolidayPolicy_Unknown_HolidayUnion(t *testing.T) {
+	oh, err := New("Sa,SH 10:00-12:00", WithSchoolHolidayPolicy(SchoolHolidaysUnknown))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	// Any weekday could be a school holiday
+	if !oh.GetUnknown(time.Date(2024, 7, 24, 11, 0, 0, 0, time.UTC)) {
+		t.Error("expected unknown on a Wednesday that could be a school holiday")
+	}
+}
@@ -0,0 +1,184 @@
+package openinghours
+
+import (
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Simplify returns a minimal value that evaluates like oh, e.g. for cleaning up
+// machine-generated values. Rules that only differ in their weekdays are merged
+// ("Mo 09:00-17:00; Tu 09:00-17:00" -> "Mo-Tu 09:00-17:00"), as are rules with
+// the same selectors; adjacent and overlapping time ranges are joined; selectors
+// that select every day like "Mo-Su" or "Jan-Dec" are dropped, so "Mo-Su
+// 00:00-24:00" becomes "24/7"; and "off" rules without a comment that don't
+// change the state are removed. A step is only taken if the result is equal to
+// oh (see IsEqualTo) and has the same open intervals, see evaluatesLike. The
+// result is prettified like PrettifyValue.
+func (oh *OpeningHours) Simplify() string {
+	groups := [][]rule{cloneRules(oh.rules)}
+	for _, group := range oh.fallbackGroups {
+		groups = append(groups, cloneRules(group))
+	}
+
+	evaluatesLike := oh.evaluatesLike()
+	for simplified := true; simplified; {
+		simplified = false
+		for g := range groups {
+			for _, candidate := range simplifications(groups[g]) {
+				next := withRules(groups, g, candidate)
+				if evaluatesLike(next) {
+					groups, simplified = next, true
+					break
+				}
+			}
+		}
+	}
+
+	// The rules are only written differently, but check the value as parsed
+	value := prettifyGroups(groups)
+	if parsed, err := New(value); err != nil || !evaluatesLike(append([][]rule{parsed.rules}, parsed.fallbackGroups...)) {
+		return oh.PrettifyValue()
+	}
+	return value
+}
+
+// evaluatesLike returns a function reporting whether oh with its rules replaced
+// by groups evaluates like oh: equal by IsEqualTo and with the same open
+// intervals over two weeks for week stable values, two years otherwise
+func (oh *OpeningHours) evaluatesLike() func(groups [][]rule) bool {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(2, 0, 0)
+	if oh.IsWeekStable() {
+		to = from.AddDate(0, 0, 14)
+	}
+	want := oh.GetOpenIntervals(from, to)
+
+	return func(groups [][]rule) bool {
+		other := oh.Clone()
+		other.rules, other.fallbackGroups = groups[0], groups[1:]
+		other.value = "" // not cacheable, see scheduleCacheKey
+		other.orderAllRules()
+		return oh.IsEqualTo(other) && slices.EqualFunc(want, other.GetOpenIntervals(from, to), func(a, b Interval) bool {
+			return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.Unknown == b.Unknown && a.Comment == b.Comment
+		})
+	}
+}
+
+// simplifications returns the candidates for simplifying rules by one step,
+// simplest first
+func simplifications(rules []rule) [][]rule {
+	var candidates [][]rule
+	replace := func(i int, r rule) []rule {
+		result := slices.Clone(rules)
+		result[i] = r
+		return result
+	}
+
+	for i, r := range rules {
+		if ranges, ok := mergeTimeRanges(r.timeRanges); ok {
+			r.timeRanges = ranges
+			candidates = append(candidates, replace(i, r))
+		}
+		if r, ok := withoutNoOpSelectors(r); ok {
+			candidates = append(candidates, replace(i, r))
+		}
+	}
+
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			merged, ok := mergeRules(rules[i], rules[j])
+			if !ok {
+				continue
+			}
+			// The merged rule takes the precedence of either rule
+			candidates = append(candidates,
+				removeRule(replace(i, merged), j),
+				removeRule(replace(j, merged), i))
+		}
+	}
+
+	if len(rules) > 1 {
+		for i, r := range rules {
+			if r.state == StateClosed && r.comment == "" && !r.isPH && !r.isSH && !r.isEaster &&
+				r.yearStart == 0 && r.dateStart == 0 {
+				candidates = append(candidates, removeRule(rules, i))
+			}
+		}
+	}
+	return candidates
+}
+
+// mergeRules merges rules that only differ in their weekdays, or that only
+// differ in their time ranges ("Mo 10:00-12:00; Mo 14:00-16:00"), into one rule
+func mergeRules(a, b rule) (rule, bool) {
+	if a.ruleGroup != b.ruleGroup || a.holidayUnion || b.holidayUnion {
+		return rule{}, false
+	}
+
+	if canMergeWeekdays(a, b) && len(a.weekdayConstraints) == 0 && len(b.weekdayConstraints) == 0 {
+		merged := a
+		merged.weekdays = make([]bool, 7)
+		for d := range merged.weekdays {
+			merged.weekdays[d] = a.weekdays[d] || b.weekdays[d]
+		}
+		return merged, true
+	}
+
+	if a.state == StateOpen && len(a.timeRanges) > 0 && len(b.timeRanges) > 0 && sameSelectors(a, b) {
+		merged := a
+		merged.timeRanges = append(slices.Clone(a.timeRanges), b.timeRanges...)
+		return merged, true
+	}
+	return rule{}, false
+}
+
+// sameSelectors reports whether a and b only differ in their time ranges
+func sameSelectors(a, b rule) bool {
+	a.timeRanges, b.timeRanges = nil, nil
+	a.monthList, b.monthList = 0, 0
+	a.metadata, b.metadata = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+// mergeTimeRanges joins overlapping and adjacent fixed time ranges within a
+// day, e.g. "09:00-12:00,12:00-17:00" -> "09:00-17:00". ok is false if nothing
+// was joined.
+func mergeTimeRanges(ranges []timeRange) (merged []timeRange, ok bool) {
+	if len(ranges) < 2 {
+		return ranges, false
+	}
+	for _, tr := range ranges {
+		if tr.startVar != "" || tr.endVar != "" || tr.openEnd || tr.interval > 0 || tr.point ||
+			tr.start < 0 || tr.end <= tr.start || tr.end > 24*60 {
+			return ranges, false
+		}
+	}
+
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b timeRange) int { return a.start - b.start })
+	for _, tr := range sorted {
+		if n := len(merged); n > 0 && tr.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, tr.end)
+			continue
+		}
+		merged = append(merged, tr)
+	}
+	return merged, len(merged) < len(ranges)
+}
+
+// withoutNoOpSelectors returns r without selectors that select every day, like
+// "Mo-Su" or "Jan-Dec", and without a time range of the whole day
+func withoutNoOpSelectors(r rule) (rule, bool) {
+	changed := false
+	if r.weekdays != nil && len(r.weekdayConstraints) == 0 && !r.holidayUnion && !slices.Contains(r.weekdays, false) {
+		r.weekdays, changed = nil, true
+	}
+	if r.monthStart == 1 && r.monthEnd == 12 && r.dayStart == 0 && r.dayEnd == 0 && r.dateStart == 0 {
+		r.monthStart, r.monthEnd, changed = 0, 0, true
+	}
+	if len(r.timeRanges) == 1 && r.timeRanges[0] == (timeRange{start: 0, end: 24 * 60}) {
+		r.timeRanges, changed = nil, true
+	}
+	return r, changed
+}
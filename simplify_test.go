@@ -0,0 +1,51 @@
+package openinghours
+
+import "testing"
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Mo,Tu,We,Th,Fr 09:00-17:00", "Mo-Fr 09:00-17:00"},
+		{"Mo 09:00-17:00; Tu 09:00-17:00; We 09:00-17:00; Th 09:00-17:00", "Mo-Th 09:00-17:00"},
+		{"Mo-Fr 08:00-12:00, Sa 08:00-12:00", "Mo-Sa 08:00-12:00"},
+		{"Mo 10:00-12:00; Tu 10:00-12:00; We 14:00-16:00; Th 10:00-12:00", "Mo-Tu,Th 10:00-12:00; We 14:00-16:00"},
+		// Adjacent and overlapping time ranges
+		{"Mo-Fr 09:00-12:00,12:00-17:00", "Mo-Fr 09:00-17:00"},
+		{"Mo-Fr 09:00-11:00,10:00-17:00", "Mo-Fr 09:00-17:00"},
+		{"Mo-Fr 09:00-12:00; Mo-Fr 12:00-17:00", "Mo-Fr 09:00-17:00"},
+		// Redundant "off" rules
+		{"Mo-Fr 09:00-17:00; Su off", "Mo-Fr 09:00-17:00"},
+		{"Mo-Fr 09:00-17:00; Dec 25 off", "Mo-Fr 09:00-17:00; Dec 25 off"},
+		{"Mo-Fr 09:00-17:00; PH off", "Mo-Fr 09:00-17:00; PH off"},
+		{`Mo-Fr 09:00-17:00; Su off "by appointment"`, `Mo-Fr 09:00-17:00; Su off "by appointment"`},
+		// No-op selectors
+		{"Mo-Su 00:00-24:00", "24/7"},
+		{"Mo-Su 09:00-17:00", "09:00-17:00"},
+		{"Jan-Dec Mo-Fr 09:00-17:00", "Mo-Fr 09:00-17:00"},
+		{"Mo-Fr 09:00-17:00; Sa 09:00-17:00; Su 09:00-17:00", "09:00-17:00"},
+		// The later rule replaces the earlier one on Wednesdays
+		{"Mo-Fr 10:00-18:00; We 12:00-18:00", "Mo-Fr 10:00-18:00; We 12:00-18:00"},
+		{"Mo-Fr 09:00-17:00 || Sa 10:00-12:00; Su 10:00-12:00", "Mo-Fr 09:00-17:00 || Sa-Su 10:00-12:00"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		got := oh.Simplify()
+		if got != tt.want {
+			t.Errorf("%q: Simplify = %q, want %q", tt.value, got, tt.want)
+			continue
+		}
+		simplified, err := New(got)
+		if err != nil {
+			t.Fatalf("%q: simplified value %q doesn't parse: %v", tt.value, got, err)
+		}
+		if !oh.IsEqualTo(simplified) {
+			t.Errorf("%q: simplified value %q is not equal", tt.value, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package openinghours
+
+import (
+	"regexp"
+	"strings"
+)
+
+// splitDatePattern matches a rule that only selects a date or date range,
+// optionally with a year: "Dec 24", "2024 Dec 24-26", "Dec 31-Jan 01"
+var splitDatePattern = regexp.MustCompile(`(?i)^(?:\d{4}\s+)?(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)\s+\d{1,2}(?:\s*-\s*(?:(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)\s+)?\d{1,2})?`)
+
+// splitDateModifierPattern matches what may follow the date of a rule to be
+// shared with preceding date-only rules: a state, a comment or times
+var splitDateModifierPattern = regexp.MustCompile(`(?i)^(?:off|closed|open|unknown|"|\d{1,2}:\d{2})`)
+
+// WithMergedSplitDates accepts date lists split into rules by some editors:
+// date-only rules take the modifier of the following date rule, so
+// "Dec 24;Dec 25 off" is parsed like "Dec 24 off; Dec 25 off" instead of
+// opening all day on Dec 24. A warning is added when rules are merged.
+func WithMergedSplitDates() Option {
+	return func(oh *OpeningHours) {
+		oh.mergeSplitDates = true
+	}
+}
+
+// mergeSplitDateRules appends the modifier of a date rule to the date-only rules
+// directly before it, e.g. ["Dec 24", "Dec 25 off"] -> ["Dec 24 off", "Dec 25 off"]
+func (oh *OpeningHours) mergeSplitDateRules(parts []string) []string {
+	merged := make([]string, len(parts))
+	var pending []int
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		merged[i] = part
+
+		date := splitDatePattern.FindString(part)
+		if date == "" {
+			pending = pending[:0]
+			continue
+		}
+		if date == part {
+			pending = append(pending, i)
+			continue
+		}
+
+		rest := part[len(date):]
+		modifier := strings.TrimSpace(rest)
+		if len(pending) > 0 && modifier != rest && splitDateModifierPattern.MatchString(modifier) {
+			for _, j := range pending {
+				merged[j] += " " + modifier
+			}
+			oh.addWarning(WarnMergedSplitDates, part, "Date-only rules took the modifier of the following rule, e.g. Dec 24;Dec 25 off was read as Dec 24 off; Dec 25 off")
+		}
+		pending = pending[:0]
+	}
+	return merged
+}
@@ -0,0 +1,60 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMergedSplitDates_State tests that date-only rules take the modifier of the following date rule
+func TestMergedSplitDates_State(t *testing.T) {
+	testCases := []struct {
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		{"Dec 24;Dec 25 off", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), false},
+		{"Dec 24;Dec 25 off", time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 10:00-18:00; Dec 24; Dec 31 10:00-14:00", time.Date(2024, 12, 24, 16, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 10:00-18:00; Dec 24; Dec 31 10:00-14:00", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true},
+		{"Mo-Su 10:00-18:00; Dec 24; Dec 31 10:00-14:00", time.Date(2024, 12, 23, 16, 0, 0, 0, time.UTC), true},
+		// Only rules directly followed by a date rule are merged
+		{"Dec 24; Mo off", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true},
+		{"Dec 25 off; Dec 24", time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range testCases {
+		oh, err := New(tc.value, WithMergedSplitDates())
+		if err != nil {
+			t.Fatalf("%q: failed to parse: %v", tc.value, err)
+		}
+		if got := oh.GetState(tc.time); got != tc.expected {
+			t.Errorf("%q at %v: GetState = %v, want %v", tc.value, tc.time, got, tc.expected)
+		}
+	}
+}
+
+// TestMergedSplitDates_Warnings tests that merging is reported and off by default
+func TestMergedSplitDates_Warnings(t *testing.T) {
+	oh, err := New(`Dec 24; Dec 25; Dec 26 closed "holiday"`, WithMergedSplitDates())
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(oh.GetWarnings()) != 1 {
+		t.Errorf("expected 1 warning, got %v", oh.GetWarnings())
+	}
+	expected := `Dec 24 off "holiday"; Dec 25 off "holiday"; Dec 26 off "holiday"`
+	if got := oh.PrettifyValue(); got != expected {
+		t.Errorf("PrettifyValue = %q, want %q", got, expected)
+	}
+
+	oh, err = New("Dec 24;Dec 25 off")
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if !oh.GetState(time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Dec 24 open without WithMergedSplitDates")
+	}
+	if len(oh.GetWarnings()) != 0 {
+		t.Errorf("expected no warnings without WithMergedSplitDates, got %v", oh.GetWarnings())
+	}
+}
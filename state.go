@@ -0,0 +1,60 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// String returns "open", "closed" or "unknown"
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateClosed:
+		return "closed"
+	case StateUnknown:
+		return "unknown"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// MarshalJSON encodes s as its String, e.g. "open"
+func (s State) MarshalJSON() ([]byte, error) {
+	switch s {
+	case StateOpen, StateClosed, StateUnknown:
+		return json.Marshal(s.String())
+	}
+	return nil, fmt.Errorf("invalid state: %d", int(s))
+}
+
+// UnmarshalJSON decodes a state encoded by MarshalJSON
+func (s *State) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for _, state := range []State{StateOpen, StateClosed, StateUnknown} {
+		if name == state.String() {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid state: %q", name)
+}
+
+// StateAt returns the state at t: StateOpen, StateClosed or StateUnknown. Like
+// GetState it evaluates any t, so the error is always nil; StateAtCtx fails
+// when its context is done.
+func (oh *OpeningHours) StateAt(t time.Time) (State, error) {
+	return oh.stateAt(t), nil
+}
+
+// stateAt returns the state at t, like GetState and GetUnknown together
+func (oh *OpeningHours) stateAt(t time.Time) State {
+	incMetric(CounterEvaluation)
+	if oh.weekStates != nil {
+		return oh.weekStates.state(oh.inLocation(t))
+	}
+	return oh.evaluate(oh.inLocation(t)).state
+}
@@ -0,0 +1,54 @@
+package openinghours
+
+import "time"
+
+// StateInfo describes the evaluation at a point in time, see GetStateInfo
+type StateInfo struct {
+	State            State  // StateOpen, StateClosed or StateUnknown, like StateAt
+	Unknown          bool   // like GetUnknown
+	Comment          string // like GetComment
+	MatchedRuleIndex int    // like GetMatchingRule, -1 if no rule matches
+	Point            bool   // t is in a point in time, see Interval.Point
+}
+
+// GetStateInfo returns the state, unknown flag, comment and matching rule at t
+// from a single evaluation of the primary and fallback groups (see evaluate),
+// at the minute of t in the evaluation location. GetOpenIntervals uses it for
+// each span between change times.
+func (oh *OpeningHours) GetStateInfo(t time.Time) StateInfo {
+	incMetric(CounterEvaluation)
+	t = startOfMinute(oh.inLocation(t))
+	e := oh.evaluate(t)
+	return StateInfo{
+		State:            e.state,
+		Unknown:          e.state == StateUnknown,
+		Comment:          e.comment,
+		MatchedRuleIndex: oh.ruleIndex(e),
+		Point:            oh.atPoint(e, t),
+	}
+}
+
+// atPoint reports whether t is in a point in time like "12:00", one of
+// "10:00-16:00/01:30" or the minute of "12:00+" of the rule deciding e
+func (oh *OpeningHours) atPoint(e evaluation, t time.Time) bool {
+	if e.index < 0 {
+		return false
+	}
+	rules, _ := oh.rulesOf(e.group)
+	minute := t.Hour()*60 + t.Minute()
+	for _, tr := range rules[e.index].timeRanges {
+		if tr.point && (minute == tr.start || !tr.openEnd && minute > tr.start && minute < tr.end) {
+			return true
+		}
+		if tr.interval > 0 {
+			length := tr.end - tr.start
+			if length <= 0 {
+				length += 24 * 60
+			}
+			if oh.atPeriodicPoint(tr, minute-tr.start, length) || oh.atPeriodicPoint(tr, minute+24*60-tr.start, length) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package openinghours
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetStateInfo(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00; Mo-Fr 12:00-13:00 open "lunch menu"; Sa unknown "call ahead"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		time time.Time
+		want StateInfo
+	}{
+		{time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), StateInfo{State: StateOpen, MatchedRuleIndex: 0}},
+		{time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC), StateInfo{State: StateOpen, Comment: "lunch menu", MatchedRuleIndex: 1}},
+		{time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC), StateInfo{State: StateClosed, MatchedRuleIndex: -1}},
+		{time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC), StateInfo{State: StateUnknown, Unknown: true, Comment: "call ahead", MatchedRuleIndex: 2}},
+	}
+	for _, tt := range tests {
+		if got := oh.GetStateInfo(tt.time); got != tt.want {
+			t.Errorf("GetStateInfo(%v) = %+v, want %+v", tt.time, got, tt.want)
+		}
+		if got := oh.GetStateInfoCtx(context.Background(), tt.time); got != tt.want {
+			t.Errorf("GetStateInfoCtx(%v) = %+v, want %+v", tt.time, got, tt.want)
+		}
+	}
+}
+
+// TestGetOpenIntervals_CommentChange tests that an open span is split where
+// only the comment changes
+func TestGetOpenIntervals_CommentChange(t *testing.T) {
+	oh, err := New(`Mo-Fr 09:00-17:00; Mo-Fr 12:00-13:00 open "lunch menu"`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	intervals := oh.GetOpenIntervals(from, from.Add(24*time.Hour))
+
+	want := []struct {
+		start, end string
+		comment    string
+	}{
+		{"09:00", "12:00", ""},
+		{"12:00", "13:00", "lunch menu"},
+		{"13:00", "17:00", ""},
+	}
+	if len(intervals) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %v", len(intervals), len(want), intervals)
+	}
+	for i, w := range want {
+		iv := intervals[i]
+		if iv.Start.Format("15:04") != w.start || iv.End.Format("15:04") != w.end || iv.Comment != w.comment {
+			t.Errorf("interval %d = %s-%s %q, want %s-%s %q", i,
+				iv.Start.Format("15:04"), iv.End.Format("15:04"), iv.Comment, w.start, w.end, w.comment)
+		}
+		if info := oh.GetStateInfo(iv.Start); info.Comment != iv.Comment {
+			t.Errorf("interval %d: comment %q, GetStateInfo comment %q", i, iv.Comment, info.Comment)
+		}
+	}
+}
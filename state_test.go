@@ -0,0 +1,94 @@
+package openinghours
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStateAt(t *testing.T) {
+	tests := []struct {
+		value string
+		time  time.Time
+		want  State
+	}{
+		{"Mo-Fr 09:00-17:00", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), StateOpen},
+		{"Mo-Fr 09:00-17:00", time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), StateClosed},
+		{"Mo-Fr 09:00-17:00 unknown", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), StateUnknown},
+		{"Jan 01 10:00-12:00", time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), StateOpen},
+		{"Jan 01 10:00-12:00", time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC), StateClosed},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		got, err := oh.StateAt(tt.time)
+		if err != nil || got != tt.want {
+			t.Errorf("%q at %s: got %v, %v, want %v", tt.value, tt.time.Format(time.RFC3339), got, err, tt.want)
+		}
+		if str := oh.GetStateString(tt.time); str != tt.want.String() {
+			t.Errorf("%q at %s: GetStateString = %q, want %q", tt.value, tt.time.Format(time.RFC3339), str, tt.want)
+		}
+		if got, err := oh.StateAtCtx(context.Background(), tt.time); err != nil || got != tt.want {
+			t.Errorf("%q at %s: StateAtCtx = %v, %v, want %v", tt.value, tt.time.Format(time.RFC3339), got, err, tt.want)
+		}
+	}
+
+	oh, err := New("24/7")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	// The zero time is evaluated like by GetState
+	if got, err := oh.StateAt(time.Time{}); err != nil || got != StateOpen {
+		t.Errorf("zero time: got %v, %v, want open", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := oh.StateAtCtx(ctx, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)); err != context.Canceled {
+		t.Errorf("StateAtCtx with a canceled context: got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestState_String(t *testing.T) {
+	tests := map[State]string{
+		StateOpen:    "open",
+		StateClosed:  "closed",
+		StateUnknown: "unknown",
+		State(7):     "State(7)",
+	}
+	for state, want := range tests {
+		if got := state.String(); got != want {
+			t.Errorf("State(%d).String() = %q, want %q", int(state), got, want)
+		}
+	}
+}
+
+func TestState_JSON(t *testing.T) {
+	data, err := json.Marshal(map[string]State{"bakery": StateOpen, "pharmacy": StateUnknown})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"bakery":"open","pharmacy":"unknown"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var states map[string]State
+	if err := json.Unmarshal(data, &states); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if states["bakery"] != StateOpen || states["pharmacy"] != StateUnknown {
+		t.Errorf("Unmarshal = %v", states)
+	}
+
+	if _, err := json.Marshal(State(7)); err == nil {
+		t.Errorf("expected an error for an invalid state")
+	}
+	var s State
+	if err := json.Unmarshal([]byte(`"maybe"`), &s); err == nil {
+		t.Errorf("expected an error for an unknown state name")
+	}
+}
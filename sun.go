@@ -13,132 +13,254 @@ const (
 	defaultDusk    = 18*60 + 30  // 18:30
 )
 
-// calculateSunrise returns minutes from midnight for sunrise in UTC
-// Uses a simplified astronomical algorithm
-func calculateSunrise(t time.Time, lat, lon float64) int {
-	// Simplified sunrise calculation
-	// For a real implementation, use a proper astronomy library like go-sunrise
-	// This uses a basic approximation
+// Altitudes of the sun's center in degrees at which the sun events occur
+const (
+	sunriseAltitude              = -0.833 // upper limb on the horizon, with atmospheric refraction
+	civilTwilightAltitude        = -6
+	nauticalTwilightAltitude     = -12
+	astronomicalTwilightAltitude = -18
+)
 
-	dayOfYear := t.YearDay()
+// SunCondition tells whether the sun crosses an altitude on a given day
+type SunCondition int
 
-	// Calculate solar declination (simplified)
-	// Uses the formula: δ = 23.45° * sin(2π * (284 + N) / 365)
-	declination := 23.45 * math.Sin(2*math.Pi*(284+float64(dayOfYear))/365)
+const (
+	SunRisesAndSets SunCondition = iota // the sun rises above and sets below the altitude
+	SunAlwaysAbove                      // the sun stays above the altitude all day, e.g. midnight sun
+	SunAlwaysBelow                      // the sun stays below the altitude all day, e.g. polar night
+)
 
-	// Calculate hour angle for sunrise
-	latRad := lat * math.Pi / 180
-	decRad := declination * math.Pi / 180
+// SunCrossing is the rise and set of the sun across an altitude on a day.
+// Rise and Set are only set if Condition is SunRisesAndSets.
+type SunCrossing struct {
+	Rise      time.Time
+	Set       time.Time
+	Condition SunCondition
+}
 
-	// cos(hour angle) = -tan(latitude) * tan(declination)
-	cosHourAngle := -math.Tan(latRad) * math.Tan(decRad)
+// SunTimes holds the sun events of a day, see OpeningHours.SunTimes. Dawn and dusk in
+// values refer to civil twilight.
+type SunTimes struct {
+	Date         time.Time   // Midnight at the start of the day
+	SolarNoon    time.Time   // The sun's highest point
+	Sun          SunCrossing // Sunrise and sunset, corrected for the observer's elevation
+	Civil        SunCrossing // Civil dawn and dusk, the sun 6° below the horizon
+	Nautical     SunCrossing // Nautical dawn and dusk, the sun 12° below the horizon
+	Astronomical SunCrossing // Astronomical dawn and dusk, the sun 18° below the horizon
+	Default      bool        // No coordinates are set: the times are defaults, see SetRegion
+}
 
-	// Handle polar day/night
-	if cosHourAngle < -1 {
-		// Sun never sets (midnight sun)
-		return 0
+// WithElevation sets the observer's elevation in meters, like SetElevation
+func WithElevation(meters float64) Option {
+	return func(oh *OpeningHours) {
+		oh.SetElevation(meters)
 	}
-	if cosHourAngle > 1 {
-		// Sun never rises (polar night) - use noon as fallback
-		return 720
+}
+
+// SetElevation sets the observer's elevation above the surrounding terrain in
+// meters. The horizon dips with elevation, so sunrise is earlier and sunset is
+// later; twilight is not affected.
+func (oh *OpeningHours) SetElevation(meters float64) {
+	oh.elevation = meters
+	oh.sunCache.clear()
+}
+
+// sunriseAltitudeAt returns the altitude of sunrise and sunset for an observer
+// at elevation meters, accounting for the dip of the horizon
+func sunriseAltitudeAt(elevation float64) float64 {
+	if elevation <= 0 {
+		return sunriseAltitude
 	}
+	return sunriseAltitude - 2.076*math.Sqrt(elevation)/60
+}
+
+// sunPosition returns the sun's declination in degrees and the equation of
+// time in minutes at t, following the NOAA solar calculator
+func sunPosition(t time.Time) (declination, eqTime float64) {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
 
-	// Calculate hour angle in degrees
-	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+	julianDay := float64(t.Unix())/86400 + 2440587.5
+	c := (julianDay - 2451545) / 36525 // Julian centuries since J2000
 
-	// Calculate approximate equation of time (in minutes)
-	// This accounts for Earth's elliptical orbit
-	B := 2 * math.Pi * (float64(dayOfYear) - 81) / 365
-	eqTime := 9.87*math.Sin(2*B) - 7.53*math.Cos(B) - 1.5*math.Sin(B)
+	meanLong := math.Mod(280.46646+c*(36000.76983+c*0.0003032), 360)
+	meanAnomaly := 357.52911 + c*(35999.05029-0.0001537*c)
+	eccentricity := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	center := math.Sin(rad(meanAnomaly))*(1.914602-c*(0.004817+0.000014*c)) +
+		math.Sin(rad(2*meanAnomaly))*(0.019993-0.000101*c) +
+		math.Sin(rad(3*meanAnomaly))*0.000289
+	omega := 125.04 - 1934.136*c
+	apparentLong := meanLong + center - 0.00569 - 0.00478*math.Sin(rad(omega))
+	meanObliquity := 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60
+	obliquity := meanObliquity + 0.00256*math.Cos(rad(omega))
+
+	declination = math.Asin(math.Sin(rad(obliquity))*math.Sin(rad(apparentLong))) * 180 / math.Pi
+
+	y := math.Pow(math.Tan(rad(obliquity/2)), 2)
+	l0, m := rad(meanLong), rad(meanAnomaly)
+	eqTime = 4 * (y*math.Sin(2*l0) - 2*eccentricity*math.Sin(m) +
+		4*eccentricity*y*math.Sin(m)*math.Cos(2*l0) -
+		0.5*y*y*math.Sin(4*l0) - 1.25*eccentricity*eccentricity*math.Sin(2*m)) * 180 / math.Pi
+	return declination, eqTime
+}
 
-	// Solar noon at this longitude (in UTC)
-	// Solar noon at prime meridian is at 12:00 UTC
-	// For each degree east, solar noon is 4 minutes earlier
-	solarNoon := 12*60 - lon*4 - eqTime
+// solarNoon returns the solar noon at longitude lon closest to noon on the
+// calendar day of t, in t's location
+func solarNoon(t time.Time, lon float64) time.Time {
+	year, month, day := t.Date()
+	localNoon := time.Date(year, month, day, 12, 0, 0, 0, t.Location())
 
-	// Sunrise is solar noon minus half the day length
-	// Day length in hours = 2 * hourAngle / 15
-	dayLengthMinutes := 2 * hourAngle * 4 // hourAngle in degrees * 4 min/degree
-	sunriseMinutes := int(solarNoon - dayLengthMinutes/2)
+	// Solar noon at the prime meridian is about 12:00 UTC; for each degree east
+	// it is 4 minutes earlier
+	noon := time.Date(year, month, day, 12, 0, 0, 0, time.UTC).Add(time.Duration(-lon * 4 * float64(time.Minute)))
 
-	// Normalize to 0-1440 range
-	for sunriseMinutes < 0 {
-		sunriseMinutes += 1440
+	// Near the date line the calendar date at the longitude can differ from the date
+	// in t's location (e.g. Samoa at 172°W uses UTC+13), so use the solar noon
+	// closest to noon in t's location
+	for noon.Sub(localNoon) > 12*time.Hour {
+		noon = noon.Add(-24 * time.Hour)
 	}
-	for sunriseMinutes >= 1440 {
-		sunriseMinutes -= 1440
+	for localNoon.Sub(noon) > 12*time.Hour {
+		noon = noon.Add(24 * time.Hour)
 	}
 
-	return sunriseMinutes
+	_, eqTime := sunPosition(noon)
+	return noon.Add(time.Duration(-eqTime * float64(time.Minute))).In(t.Location())
 }
 
-// calculateSunset returns minutes from midnight for sunset in UTC
-func calculateSunset(t time.Time, lat, lon float64) int {
-	dayOfYear := t.YearDay()
-
-	// Calculate solar declination (simplified)
-	declination := 23.45 * math.Sin(2*math.Pi*(284+float64(dayOfYear))/365)
-
-	// Calculate hour angle for sunset
-	latRad := lat * math.Pi / 180
-	decRad := declination * math.Pi / 180
+// sunCrossing returns when the sun crosses altitude (degrees) on the day of
+// solar noon noon at latitude lat
+func sunCrossing(noon time.Time, lat, altitude float64) SunCrossing {
+	// hourAngle returns the hour angle in degrees at which the sun is at
+	// altitude, using the sun's position at t
+	hourAngle := func(t time.Time) (float64, SunCondition) {
+		declination, _ := sunPosition(t)
+		latRad, decRad := lat*math.Pi/180, declination*math.Pi/180
+		cosHourAngle := (math.Sin(altitude*math.Pi/180) - math.Sin(latRad)*math.Sin(decRad)) /
+			(math.Cos(latRad) * math.Cos(decRad))
+		switch {
+		case cosHourAngle < -1:
+			return 0, SunAlwaysAbove
+		case cosHourAngle > 1:
+			return 0, SunAlwaysBelow
+		}
+		return math.Acos(cosHourAngle) * 180 / math.Pi, SunRisesAndSets
+	}
+	// The sun moves 1° of hour angle in 4 minutes
+	offset := func(degrees float64) time.Duration {
+		return time.Duration(degrees * 4 * float64(time.Minute))
+	}
 
-	cosHourAngle := -math.Tan(latRad) * math.Tan(decRad)
+	degrees, condition := hourAngle(noon)
+	if condition != SunRisesAndSets {
+		return SunCrossing{Condition: condition}
+	}
 
-	// Handle polar day/night
-	if cosHourAngle < -1 {
-		// Sun never sets (midnight sun)
-		return 1440
+	// Refine with the sun's position at the estimated rise and set
+	crossing := SunCrossing{Rise: noon.Add(-offset(degrees)), Set: noon.Add(offset(degrees))}
+	if degrees, condition := hourAngle(crossing.Rise); condition == SunRisesAndSets {
+		crossing.Rise = noon.Add(-offset(degrees))
 	}
-	if cosHourAngle > 1 {
-		// Sun never rises (polar night) - use noon as fallback
-		return 720
+	if degrees, condition := hourAngle(crossing.Set); condition == SunRisesAndSets {
+		crossing.Set = noon.Add(offset(degrees))
 	}
+	return crossing
+}
 
-	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+// SunTimes returns the sun events on the day of date, in date's location,
+// computed with the NOAA solar calculator algorithm. This is mainly useful for
+// debugging variable times: sunrise and sunset resolve "sunrise" and "sunset"
+// in values, civil twilight "dawn" and "dusk". Without coordinates (see
+// SetCoordinates) the default times are returned and Default is set; with a
+// region (see SetRegion) they are computed for the latitude of the region with
+// solar noon at 12:00.
+func (oh *OpeningHours) SunTimes(date time.Time) SunTimes {
+	date = oh.inLocation(date)
+	year, month, day := date.Date()
+	at := func(minutes int) time.Time {
+		return time.Date(year, month, day, 0, minutes, 0, 0, date.Location())
+	}
 
-	// Calculate approximate equation of time (in minutes)
-	B := 2 * math.Pi * (float64(dayOfYear) - 81) / 365
-	eqTime := 9.87*math.Sin(2*B) - 7.53*math.Cos(B) - 1.5*math.Sin(B)
+	if !oh.hasCoordinates && oh.region != nil {
+		noon := at(12 * 60)
+		return SunTimes{
+			Date:         at(0),
+			SolarNoon:    noon,
+			Sun:          sunCrossing(noon, oh.region.Latitude, sunriseAltitude),
+			Civil:        sunCrossing(noon, oh.region.Latitude, civilTwilightAltitude),
+			Nautical:     sunCrossing(noon, oh.region.Latitude, nauticalTwilightAltitude),
+			Astronomical: sunCrossing(noon, oh.region.Latitude, astronomicalTwilightAltitude),
+			Default:      true,
+		}
+	}
+	if !oh.hasCoordinates {
+		return SunTimes{
+			Date:      at(0),
+			SolarNoon: at(12 * 60),
+			Sun:       SunCrossing{Rise: at(defaultSunrise), Set: at(defaultSunset)},
+			Civil:     SunCrossing{Rise: at(defaultDawn), Set: at(defaultDusk)},
+			Default:   true,
+		}
+	}
 
-	// Solar noon at this longitude (in UTC)
-	solarNoon := 12*60 - lon*4 - eqTime
+	noon := solarNoon(date, oh.longitude)
+	return SunTimes{
+		Date:         at(0),
+		SolarNoon:    noon,
+		Sun:          sunCrossing(noon, oh.latitude, sunriseAltitudeAt(oh.elevation)),
+		Civil:        sunCrossing(noon, oh.latitude, civilTwilightAltitude),
+		Nautical:     sunCrossing(noon, oh.latitude, nauticalTwilightAltitude),
+		Astronomical: sunCrossing(noon, oh.latitude, astronomicalTwilightAltitude),
+	}
+}
 
-	// Sunset is solar noon plus half the day length
-	dayLengthMinutes := 2 * hourAngle * 4 // hourAngle in degrees * 4 min/degree
-	sunsetMinutes := int(solarNoon + dayLengthMinutes/2)
+// variableTimeMinutes returns the minutes from midnight of a variable time on
+// the day of t, and whether the sun doesn't cross its altitude that day. If the
+// sun stays above, rise is at 00:00 and set at 24:00 (1440); if it stays below,
+// both are at solar noon.
+func (oh *OpeningHours) variableTimeMinutes(t time.Time, varType string) (minutes int, polar bool) {
+	altitude := float64(civilTwilightAltitude)
+	if varType == "sunrise" || varType == "sunset" {
+		altitude = sunriseAltitudeAt(oh.elevation)
+	}
+	rise := isRiseEvent(varType)
 
-	// Normalize to 0-1440 range
-	for sunsetMinutes < 0 {
-		sunsetMinutes += 1440
+	var noon time.Time
+	var crossing SunCrossing
+	if oh.hasCoordinates {
+		noon = solarNoon(t, oh.longitude)
+		crossing = sunCrossing(noon, oh.latitude, altitude)
+	} else {
+		// The default times of a region, see SetRegion
+		noon = time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, t.Location())
+		if altitude != civilTwilightAltitude {
+			altitude = sunriseAltitude
+		}
+		crossing = sunCrossing(noon, oh.region.Latitude, altitude)
 	}
-	for sunsetMinutes >= 1440 {
-		sunsetMinutes -= 1440
+	switch crossing.Condition {
+	case SunAlwaysAbove:
+		if rise {
+			return 0, true
+		}
+		return 24 * 60, true
+	case SunAlwaysBelow:
+		return noon.Hour()*60 + noon.Minute(), true
 	}
 
-	return sunsetMinutes
+	event := crossing.Set
+	if rise {
+		event = crossing.Rise
+	}
+	event = event.In(t.Location())
+	return event.Hour()*60 + event.Minute(), false
 }
 
-// calculateDawn returns minutes from midnight for civil dawn
-// Civil dawn is when the sun is 6° below the horizon
-func calculateDawn(t time.Time, lat, lon float64) int {
-	// Simplified: dawn is approximately 30 minutes before sunrise
-	sunrise := calculateSunrise(t, lat, lon)
-	dawn := sunrise - 30
-	if dawn < 0 {
-		dawn += 1440
-	}
-	return dawn
+// isRiseEvent reports whether varType is a rising sun event
+func isRiseEvent(varType string) bool {
+	return varType == "sunrise" || varType == "dawn"
 }
 
-// calculateDusk returns minutes from midnight for civil dusk
-// Civil dusk is when the sun is 6° below the horizon
-func calculateDusk(t time.Time, lat, lon float64) int {
-	// Simplified: dusk is approximately 30 minutes after sunset
-	sunset := calculateSunset(t, lat, lon)
-	dusk := sunset + 30
-	if dusk >= 1440 {
-		dusk -= 1440
-	}
-	return dusk
+// isSetEvent reports whether varType is a setting sun event
+func isSetEvent(varType string) bool {
+	return varType == "sunset" || varType == "dusk"
 }
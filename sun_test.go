@@ -0,0 +1,220 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSunTimes_LocalDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		lat, lon    float64
+		date        time.Time
+		sunriseFrom string // earliest acceptable local sunrise
+		sunriseTo   string
+		sunsetFrom  string
+		sunsetTo    string
+	}{
+		{
+			name: "Sydney summer", lat: -33.8688, lon: 151.2093,
+			date:        time.Date(2024, 12, 21, 0, 0, 0, 0, time.FixedZone("AEDT", 11*3600)),
+			sunriseFrom: "05:15", sunriseTo: "06:15", sunsetFrom: "19:45", sunsetTo: "20:30",
+		},
+		{
+			name: "Buenos Aires winter", lat: -34.6037, lon: -58.3816,
+			date:        time.Date(2024, 6, 21, 0, 0, 0, 0, time.FixedZone("ART", -3*3600)),
+			sunriseFrom: "07:40", sunriseTo: "08:30", sunsetFrom: "17:30", sunsetTo: "18:20",
+		},
+		{
+			// Fiji is just west of the date line
+			name: "Suva", lat: -18.1416, lon: 178.4419,
+			date:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.FixedZone("FJT", 12*3600)),
+			sunriseFrom: "05:30", sunriseTo: "06:20", sunsetFrom: "18:40", sunsetTo: "19:30",
+		},
+		{
+			// Samoa lies east of the date line but uses UTC+13
+			name: "Apia", lat: -13.8333, lon: -171.7667,
+			date:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.FixedZone("WST", 13*3600)),
+			sunriseFrom: "05:50", sunriseTo: "06:40", sunsetFrom: "18:50", sunsetTo: "19:40",
+		},
+	}
+
+	inWindow := func(tm time.Time, from, to string) bool {
+		hhmm := tm.Format("15:04")
+		return hhmm >= from && hhmm <= to
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oh, err := New("sunrise-sunset")
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			oh.SetCoordinates(tt.lat, tt.lon)
+
+			st := oh.SunTimes(tt.date)
+			sunrise, sunset, dawn, dusk := st.Sun.Rise, st.Sun.Set, st.Civil.Rise, st.Civil.Set
+			for _, tm := range []time.Time{sunrise, sunset, dawn, dusk} {
+				if tm.Location() != tt.date.Location() {
+					t.Errorf("%v is not in the location of the requested date", tm)
+				}
+				if tm.Day() != tt.date.Day() {
+					t.Errorf("%v is not on the requested day %v", tm, tt.date.Format("2006-01-02"))
+				}
+			}
+			if !inWindow(sunrise, tt.sunriseFrom, tt.sunriseTo) {
+				t.Errorf("sunrise %s, want between %s and %s", sunrise.Format("15:04"), tt.sunriseFrom, tt.sunriseTo)
+			}
+			if !inWindow(sunset, tt.sunsetFrom, tt.sunsetTo) {
+				t.Errorf("sunset %s, want between %s and %s", sunset.Format("15:04"), tt.sunsetFrom, tt.sunsetTo)
+			}
+			if !dawn.Before(sunrise) || !dusk.After(sunset) {
+				t.Errorf("dawn %v and dusk %v should enclose sunrise %v and sunset %v", dawn, dusk, sunrise, sunset)
+			}
+
+			// Variable times resolve to the same local times
+			noon := time.Date(tt.date.Year(), tt.date.Month(), tt.date.Day(), 12, 0, 0, 0, tt.date.Location())
+			if !oh.GetState(noon) {
+				t.Errorf("expected open at local noon")
+			}
+			if oh.GetState(sunrise.Add(-time.Minute)) {
+				t.Errorf("expected closed one minute before sunrise %v", sunrise)
+			}
+			if oh.GetState(sunset) {
+				t.Errorf("expected closed at sunset %v", sunset)
+			}
+		})
+	}
+}
+
+func TestSunTimes_Berlin(t *testing.T) {
+	oh, err := New("sunrise-sunset", WithCoordinates(52.52, 13.405))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// NOAA solar calculator, 2024-06-21 in UTC
+	st := oh.SunTimes(time.Date(2024, 6, 21, 15, 0, 0, 0, time.UTC))
+	if st.Default {
+		t.Errorf("expected computed times with coordinates")
+	}
+	if got := st.Date.Format("2006-01-02 15:04"); got != "2024-06-21 00:00" {
+		t.Errorf("date %s, want 2024-06-21 00:00", got)
+	}
+	checks := []struct {
+		name string
+		tm   time.Time
+		want string
+	}{
+		{"solar noon", st.SolarNoon, "11:08"},
+		{"sunrise", st.Sun.Rise, "02:43"},
+		{"sunset", st.Sun.Set, "19:33"},
+		{"civil dawn", st.Civil.Rise, "01:53"},
+		{"civil dusk", st.Civil.Set, "20:23"},
+	}
+	for _, c := range checks {
+		// Allow a minute of rounding difference
+		want, _ := time.Parse("15:04", c.want)
+		got, _ := time.Parse("15:04", c.tm.Format("15:04"))
+		if d := got.Sub(want); d < -time.Minute || d > time.Minute {
+			t.Errorf("%s %s, want %s", c.name, c.tm.Format("15:04"), c.want)
+		}
+	}
+
+	// The sun stays above -18° in Berlin around the summer solstice
+	if st.Astronomical.Condition != SunAlwaysAbove {
+		t.Errorf("astronomical condition %v, want SunAlwaysAbove", st.Astronomical.Condition)
+	}
+	if !st.Nautical.Rise.Before(st.Civil.Rise) || !st.Nautical.Set.After(st.Civil.Set) {
+		t.Errorf("nautical twilight %v-%v should enclose civil twilight", st.Nautical.Rise, st.Nautical.Set)
+	}
+}
+
+func TestSunTimes_Polar(t *testing.T) {
+	// Tromsø, Norway
+	oh, err := New("sunrise-sunset", WithCoordinates(69.6492, 18.9553))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	summer := oh.SunTimes(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC))
+	for name, c := range map[string]SunCrossing{"sun": summer.Sun, "civil": summer.Civil, "nautical": summer.Nautical, "astronomical": summer.Astronomical} {
+		if c.Condition != SunAlwaysAbove {
+			t.Errorf("June %s condition %v, want SunAlwaysAbove", name, c.Condition)
+		}
+		if !c.Rise.IsZero() || !c.Set.IsZero() {
+			t.Errorf("June %s should have no rise and set, got %v and %v", name, c.Rise, c.Set)
+		}
+	}
+
+	winter := oh.SunTimes(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC))
+	if winter.Sun.Condition != SunAlwaysBelow {
+		t.Errorf("December sun condition %v, want SunAlwaysBelow", winter.Sun.Condition)
+	}
+	if winter.Civil.Condition != SunRisesAndSets {
+		t.Errorf("December civil condition %v, want SunRisesAndSets", winter.Civil.Condition)
+	}
+
+	// Midnight sun: open all day; polar night: closed all day
+	for h := 0; h < 24; h++ {
+		if !oh.GetState(time.Date(2024, 6, 21, h, 30, 0, 0, time.UTC)) {
+			t.Errorf("expected open at %02d:30 during midnight sun", h)
+		}
+		if oh.GetState(time.Date(2024, 12, 21, h, 30, 0, 0, time.UTC)) {
+			t.Errorf("expected closed at %02d:30 during polar night", h)
+		}
+	}
+
+	// Dawn and dusk still happen during polar night
+	dawnDusk, err := New("dawn-dusk", WithCoordinates(69.6492, 18.9553))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if !dawnDusk.GetState(winter.SolarNoon) {
+		t.Errorf("expected dawn-dusk open at solar noon %v", winter.SolarNoon)
+	}
+}
+
+func TestSunTimes_Elevation(t *testing.T) {
+	date := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	ground, err := New("sunrise-sunset", WithCoordinates(46.5, 8.0))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	summit, err := New("sunrise-sunset", WithCoordinates(46.5, 8.0), WithElevation(2000))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	low, high := ground.SunTimes(date), summit.SunTimes(date)
+	if d := low.Sun.Rise.Sub(high.Sun.Rise); d < 3*time.Minute {
+		t.Errorf("sunrise at 2000m should be several minutes earlier, got %v earlier", d)
+	}
+	if d := high.Sun.Set.Sub(low.Sun.Set); d < 3*time.Minute {
+		t.Errorf("sunset at 2000m should be several minutes later, got %v later", d)
+	}
+	if !low.Civil.Rise.Equal(high.Civil.Rise) {
+		t.Errorf("elevation should not affect civil twilight: %v vs %v", low.Civil.Rise, high.Civil.Rise)
+	}
+	if ground.GetState(high.Sun.Rise) || !summit.GetState(high.Sun.Rise.Add(time.Minute)) {
+		t.Errorf("sunrise variable time should follow the elevation")
+	}
+}
+
+func TestSunTimes_NoCoordinates(t *testing.T) {
+	oh, err := New("sunrise-sunset")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	st := oh.SunTimes(time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC))
+	if !st.Default {
+		t.Errorf("expected Default without coordinates")
+	}
+	if got := st.Sun.Rise.Format("15:04") + "-" + st.Sun.Set.Format("15:04"); got != "06:00-18:00" {
+		t.Errorf("sun %s, want 06:00-18:00", got)
+	}
+	if got := st.Civil.Rise.Format("15:04") + "-" + st.Civil.Set.Format("15:04"); got != "05:30-18:30" {
+		t.Errorf("civil %s, want 05:30-18:30", got)
+	}
+}
@@ -0,0 +1,43 @@
+package openinghours
+
+import "time"
+
+// WithEventDuration sets how long points in time last, like the departure
+// "12:00" or the feedings of "10:00-16:00/01:30" at 10:00, 11:30, 13:00, 14:30
+// and 16:00. Points in time last one minute by default; d is rounded down to
+// whole minutes, at least one. The start of events like "17:00+" always lasts
+// one minute, see WithOpenEndUnknown.
+func WithEventDuration(d time.Duration) Option {
+	return func(oh *OpeningHours) {
+		oh.eventDuration = max(int(d/time.Minute), 1)
+	}
+}
+
+// eventMinutes returns the minutes a point in time lasts, see WithEventDuration
+func (oh *OpeningHours) eventMinutes() int {
+	if oh == nil || oh.eventDuration == 0 {
+		return 1
+	}
+	return oh.eventDuration
+}
+
+// atPeriodicPoint reports whether the minute offset minutes after the start of
+// the periodic range tr, which lasts length minutes, is in one of its points in
+// time. The points are every interval from the start up to and including the end.
+func (oh *OpeningHours) atPeriodicPoint(tr timeRange, offset, length int) bool {
+	if offset < 0 || length < 0 {
+		return false
+	}
+	last := min(offset, length) / tr.interval * tr.interval
+	return offset < last+oh.eventMinutes()
+}
+
+// periodicPoints returns the minutes after the start of the periodic range tr,
+// which lasts length minutes, at which its points in time start
+func periodicPoints(tr timeRange, length int) []int {
+	var points []int
+	for offset := 0; offset <= length; offset += tr.interval {
+		points = append(points, offset)
+	}
+	return points
+}
@@ -0,0 +1,79 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodicPoints_Intervals(t *testing.T) {
+	oh, err := New("Mo 10:00-16:00/01:30")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	intervals := oh.GetOpenIntervals(monday, monday.AddDate(0, 0, 1))
+
+	want := []int{10 * 60, 11*60 + 30, 13 * 60, 14*60 + 30, 16 * 60}
+	if len(intervals) != len(want) {
+		t.Fatalf("got %d intervals %+v, want %d", len(intervals), intervals, len(want))
+	}
+	for i, minute := range want {
+		start := monday.Add(time.Duration(minute) * time.Minute)
+		iv := intervals[i]
+		if !iv.Start.Equal(start) || !iv.End.Equal(start.Add(time.Minute)) || !iv.Point {
+			t.Errorf("interval %d = %+v, want the point %s", i, iv, start.Format("15:04"))
+		}
+	}
+}
+
+func TestPeriodicPoints_EventDuration(t *testing.T) {
+	oh, err := New("Mo 10:00-12:00/00:30; Tu 09:00", WithEventDuration(10*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		open bool
+	}{
+		{monday.Add(10*time.Hour + 9*time.Minute), true},
+		{monday.Add(10*time.Hour + 10*time.Minute), false},
+		{monday.Add(12*time.Hour + 9*time.Minute), true}, // the point at the end
+		{monday.Add(12*time.Hour + 30*time.Minute), false},
+		{monday.Add(33*time.Hour + 9*time.Minute), true}, // Tuesday 09:09
+		{monday.Add(33*time.Hour + 10*time.Minute), false},
+	}
+	for _, tt := range tests {
+		info := oh.GetStateInfo(tt.at)
+		if (info.State == StateOpen) != tt.open || info.Point != tt.open {
+			t.Errorf("%v: GetStateInfo() = %+v, want open and point %v", tt.at, info, tt.open)
+		}
+	}
+	if open, _ := oh.GetOpenDuration(monday, monday.AddDate(0, 0, 7)); open != 60*time.Minute {
+		t.Errorf("GetOpenDuration() = %v, want 1h", open)
+	}
+}
+
+func TestPeriodicPoints_AcrossMidnight(t *testing.T) {
+	oh, err := New("Fr 23:00-01:00/00:40")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	friday := time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)
+	intervals := oh.GetOpenIntervals(friday, friday.AddDate(0, 0, 2))
+
+	want := []time.Time{
+		friday.Add(23 * time.Hour),
+		friday.Add(23*time.Hour + 40*time.Minute),
+		friday.Add(24*time.Hour + 20*time.Minute),
+		friday.Add(25 * time.Hour), // the end of the range on Saturday
+	}
+	if len(intervals) != len(want) {
+		t.Fatalf("got %d intervals %+v, want %d", len(intervals), intervals, len(want))
+	}
+	for i, start := range want {
+		if !intervals[i].Start.Equal(start) || intervals[i].End.Sub(start) != time.Minute {
+			t.Errorf("interval %d = %v-%v, want the point %v", i, intervals[i].Start, intervals[i].End, start)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package openinghours
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadBerlin(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("could not load timezone: %v", err)
+	}
+	return loc
+}
+
+func TestSetTimezone_ConvertsEvaluatedTimes(t *testing.T) {
+	berlin := loadBerlin(t)
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// 08:30 UTC is 09:30 in Berlin (CET) on Monday Jan 15, 2024
+	tm := time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC)
+	if oh.GetState(tm) {
+		t.Error("without timezone 08:30 UTC should be closed")
+	}
+
+	oh.SetTimezone(berlin)
+	if !oh.GetState(tm) {
+		t.Error("with Berlin timezone 08:30 UTC (09:30 local) should be open")
+	}
+
+	next := oh.GetNextChange(tm)
+	want := time.Date(2024, 1, 15, 17, 0, 0, 0, berlin)
+	if !next.Equal(want) || next.Location() != berlin {
+		t.Errorf("GetNextChange = %v, want %v", next, want)
+	}
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	intervals := oh.GetOpenIntervals(from, from.Add(24*time.Hour))
+	if len(intervals) != 1 {
+		t.Fatalf("got %d intervals, want 1", len(intervals))
+	}
+	if intervals[0].Start.Location() != berlin || intervals[0].Start.Hour() != 9 || intervals[0].End.Hour() != 17 {
+		t.Errorf("got interval %v-%v, want 09:00-17:00 Berlin time", intervals[0].Start, intervals[0].End)
+	}
+
+	oh.SetTimezone(nil)
+	if oh.GetState(tm) {
+		t.Error("after SetTimezone(nil) 08:30 UTC should be closed again")
+	}
+}
+
+func TestSetTimezone_SpringForward(t *testing.T) {
+	berlin := loadBerlin(t)
+	oh, err := New("02:00-03:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetTimezone(berlin)
+
+	// On March 31, 2024 clocks skip from 02:00 to 03:00, so the range does not exist
+	from := time.Date(2024, 3, 31, 0, 0, 0, 0, berlin)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, berlin)
+	if intervals := oh.GetOpenIntervals(from, to); len(intervals) != 0 {
+		t.Errorf("expected no open interval on the spring-forward night, got %v", intervals)
+	}
+
+	next := oh.GetNextChange(from)
+	want := time.Date(2024, 4, 1, 2, 0, 0, 0, berlin)
+	if !next.Equal(want) {
+		t.Errorf("GetNextChange = %v, want %v", next, want)
+	}
+}
+
+func TestSetTimezone_FallBack(t *testing.T) {
+	berlin := loadBerlin(t)
+	oh, err := New("02:00-03:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetTimezone(berlin)
+
+	// On October 27, 2024 clocks go back from 03:00 CEST to 02:00 CET,
+	// so 02:00-03:00 wall clock time lasts two hours
+	from := time.Date(2024, 10, 27, 0, 0, 0, 0, berlin)
+	to := time.Date(2024, 10, 28, 0, 0, 0, 0, berlin)
+
+	intervals := oh.GetOpenIntervals(from, to)
+	if len(intervals) != 1 {
+		t.Fatalf("got %d intervals %v, want 1", len(intervals), intervals)
+	}
+	wantStart := time.Date(2024, 10, 27, 0, 0, 0, 0, time.UTC) // 02:00 CEST
+	wantEnd := time.Date(2024, 10, 27, 2, 0, 0, 0, time.UTC)   // 03:00 CET
+	if !intervals[0].Start.Equal(wantStart) || !intervals[0].End.Equal(wantEnd) {
+		t.Errorf("got interval %v-%v, want %v-%v", intervals[0].Start, intervals[0].End, wantStart, wantEnd)
+	}
+
+	open, _ := oh.GetOpenDuration(from, to)
+	if open != 2*time.Hour {
+		t.Errorf("open duration = %v, want 2h", open)
+	}
+}
+
+// localHolidayChecker keys holidays on the local date of the checked time and
+// records checked times that are not in loc
+type localHolidayChecker struct {
+	loc      *time.Location
+	holidays map[string]bool
+	foreign  []time.Time
+}
+
+func (c *localHolidayChecker) IsHoliday(t time.Time) bool {
+	if t.Location() != c.loc {
+		c.foreign = append(c.foreign, t)
+	}
+	return c.holidays[t.Format("2006-01-02")]
+}
+
+func (c *localHolidayChecker) IsSchoolHoliday(t time.Time) bool {
+	return c.IsHoliday(t)
+}
+
+func TestSetTimezone_HolidaysAroundMidnight(t *testing.T) {
+	berlin := loadBerlin(t)
+
+	tests := []struct {
+		value    string
+		time     time.Time // UTC, one hour behind Berlin in winter
+		expected bool
+	}{
+		// 23:30 UTC on Dec 24 is 00:30 on Christmas in Berlin
+		{"Mo-Su 00:00-24:00; PH off", time.Date(2024, 12, 24, 23, 30, 0, 0, time.UTC), false},
+		{"Mo-Su 00:00-24:00; PH off", time.Date(2024, 12, 24, 22, 30, 0, 0, time.UTC), true},
+		// 23:30 UTC on the holiday is already the next day in Berlin
+		{"Mo-Su 00:00-24:00; PH off", time.Date(2024, 12, 25, 23, 30, 0, 0, time.UTC), true},
+		{"PH 18:00-24:00", time.Date(2024, 12, 25, 22, 30, 0, 0, time.UTC), true},
+		{"PH 18:00-24:00", time.Date(2024, 12, 25, 23, 30, 0, 0, time.UTC), false},
+		// Offsets count local days
+		{"Mo-Su 10:00-24:00; PH +1 day off", time.Date(2024, 12, 25, 23, 30, 0, 0, time.UTC), false},
+		{"Mo-Su 00:00-24:00; PH -1 day off", time.Date(2024, 12, 23, 23, 30, 0, 0, time.UTC), false},
+		{"Mo-Su 00:00-24:00; PH -1 day off", time.Date(2024, 12, 24, 23, 30, 0, 0, time.UTC), true},
+		// School holidays as well
+		{"Mo-Su 00:00-24:00; SH off", time.Date(2024, 12, 24, 23, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		hc := &localHolidayChecker{loc: berlin, holidays: map[string]bool{"2024-12-25": true}}
+		if strings.Contains(tt.value, "SH") {
+			oh.SetSchoolHolidayChecker(hc)
+		} else {
+			oh.SetHolidayChecker(hc)
+		}
+		oh.SetTimezone(berlin)
+
+		if got := oh.GetState(tt.time); got != tt.expected {
+			t.Errorf("%q at %v: GetState = %v, want %v", tt.value, tt.time, got, tt.expected)
+		}
+		if len(hc.foreign) > 0 {
+			t.Errorf("%q: checker called with times outside the venue timezone: %v", tt.value, hc.foreign[0])
+		}
+	}
+}
+
+func TestSetTimezone_HolidayBoundariesAreLocalMidnights(t *testing.T) {
+	berlin := loadBerlin(t)
+	oh, err := New("Mo-Su 00:00-24:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	hc := &localHolidayChecker{loc: berlin, holidays: map[string]bool{"2024-12-25": true}}
+	oh.SetHolidayChecker(hc)
+	oh.SetTimezone(berlin)
+
+	from := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	wantClose := time.Date(2024, 12, 25, 0, 0, 0, 0, berlin)
+	if next := oh.GetNextChange(from); !next.Equal(wantClose) {
+		t.Errorf("GetNextChange = %v, want %v", next, wantClose)
+	}
+
+	closed := oh.GetClosedIntervals(from, from.AddDate(0, 0, 2))
+	wantOpen := time.Date(2024, 12, 26, 0, 0, 0, 0, berlin)
+	if len(closed) != 1 || !closed[0].Start.Equal(wantClose) || !closed[0].End.Equal(wantOpen) {
+		t.Errorf("GetClosedIntervals = %v, want %v-%v", closed, wantClose, wantOpen)
+	}
+	if len(closed) == 1 && closed[0].Reason != ClosedByRule {
+		t.Errorf("closed reason = %v, want the PH rule", closed[0].Reason)
+	}
+	if len(hc.foreign) > 0 {
+		t.Errorf("checker called with times outside the venue timezone: %v", hc.foreign[0])
+	}
+}
+
+func TestHolidays_TimeLocationWithoutTimezone(t *testing.T) {
+	berlin := loadBerlin(t)
+	oh, err := New("Mo-Su 00:00-24:00; PH off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.SetHolidayChecker(&mockHolidayChecker{holidays: map[string]bool{"2024-12-25": true}})
+
+	// Without SetTimezone each time is evaluated in its own location
+	instant := time.Date(2024, 12, 24, 23, 30, 0, 0, time.UTC)
+	if !oh.GetState(instant) {
+		t.Errorf("expected open on Dec 24 in UTC")
+	}
+	if oh.GetState(instant.In(berlin)) {
+		t.Errorf("expected closed on Dec 25 in Berlin")
+	}
+}
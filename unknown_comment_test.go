@@ -0,0 +1,91 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnknownComment_GetStateString(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00 unknown \"call ahead\"")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Jan 15, 2024 is Monday
+	inside := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if got := oh.GetStateString(inside); got != "unknown" {
+		t.Errorf("Mo 12:00: GetStateString = %q, want %q", got, "unknown")
+	}
+	if got := oh.GetComment(inside); got != "call ahead" {
+		t.Errorf("Mo 12:00: GetComment = %q, want %q", got, "call ahead")
+	}
+
+	outside := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+	if got := oh.GetStateString(outside); got != "closed" {
+		t.Errorf("Mo 18:00: GetStateString = %q, want %q", got, "closed")
+	}
+}
+
+func TestUnknownComment_FallbackResolves(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00 unknown \"call ahead\" || Mo-Fr 10:00-16:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	resolved := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if got := oh.GetStateString(resolved); got != "open" {
+		t.Errorf("Mo 12:00: GetStateString = %q, want %q", got, "open")
+	}
+
+	// The fallback group does not cover 09:30, so the primary unknown stands
+	unresolved := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	if got := oh.GetStateString(unresolved); got != "unknown" {
+		t.Errorf("Mo 09:30: GetStateString = %q, want %q", got, "unknown")
+	}
+}
+
+func TestUnknownComment_FallbackDoesNotMatch(t *testing.T) {
+	oh, err := New("Mo 10:00-12:00 unknown \"call ahead\" || Tu 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	intervals := oh.GetOpenIntervals(from, to)
+	if len(intervals) != 1 {
+		t.Fatalf("expected 1 interval, got %d: %v", len(intervals), intervals)
+	}
+
+	iv := intervals[0]
+	if !iv.Unknown {
+		t.Errorf("expected Unknown=true")
+	}
+	if iv.Comment != "call ahead" {
+		t.Errorf("expected comment %q, got %q", "call ahead", iv.Comment)
+	}
+	wantStart := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if !iv.Start.Equal(wantStart) || !iv.End.Equal(wantEnd) {
+		t.Errorf("interval = %v-%v, want %v-%v", iv.Start, iv.End, wantStart, wantEnd)
+	}
+}
+
+func TestUnknownComment_AllFallbacksUnknown(t *testing.T) {
+	oh, err := New("Mo unknown \"first\" || Mo unknown \"second\"")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	monday := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if !oh.GetUnknown(monday) {
+		t.Errorf("Mo 12:00: expected unknown when every fallback is unknown")
+	}
+	if got := oh.GetStateString(monday); got != "unknown" {
+		t.Errorf("Mo 12:00: GetStateString = %q, want %q", got, "unknown")
+	}
+	if got := oh.GetComment(monday); got != "first" {
+		t.Errorf("Mo 12:00: GetComment = %q, want %q", got, "first")
+	}
+}
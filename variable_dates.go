@@ -0,0 +1,132 @@
+package openinghours
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// VariableDateProvider provides a movable date that values can select like
+// "easter", e.g. "whitsun +1 day off" or "Jan 06-whitsun off". Name is the word
+// used in values, matched case-insensitively; Date returns the date in year.
+type VariableDateProvider interface {
+	Name() string
+	Date(year int) time.Time
+}
+
+// variableDate is a built-in VariableDateProvider: a date relative to Easter
+// Sunday, or the nth Sunday of Advent
+type variableDate struct {
+	name         string
+	easterOffset int // days from Easter Sunday
+	advent       int // 1-4 for the Sundays of Advent, 0 = relative to Easter
+}
+
+func (d variableDate) Name() string {
+	return d.name
+}
+
+func (d variableDate) Date(year int) time.Time {
+	if d.advent > 0 {
+		// The fourth Sunday of Advent is the last Sunday before Christmas
+		christmas := time.Date(year, time.December, 25, 0, 0, 0, 0, time.UTC)
+		daysBack := int(christmas.Weekday())
+		if daysBack == 0 {
+			daysBack = 7
+		}
+		return christmas.AddDate(0, 0, -daysBack-7*(4-d.advent))
+	}
+	return calculateEaster(year).AddDate(0, 0, d.easterOffset)
+}
+
+// DefaultVariableDates returns the built-in movable dates besides "easter":
+// "goodfriday", "ascension", "whitsun" (also "pentecost"), "whitmonday" and
+// "advent1" to "advent4"
+func DefaultVariableDates() []VariableDateProvider {
+	return []VariableDateProvider{
+		variableDate{name: "goodfriday", easterOffset: -2},
+		variableDate{name: "ascension", easterOffset: 39},
+		variableDate{name: "whitsun", easterOffset: 49},
+		variableDate{name: "pentecost", easterOffset: 49},
+		variableDate{name: "whitmonday", easterOffset: 50},
+		variableDate{name: "advent1", advent: 1},
+		variableDate{name: "advent2", advent: 2},
+		variableDate{name: "advent3", advent: 3},
+		variableDate{name: "advent4", advent: 4},
+	}
+}
+
+// WithVariableDates adds movable dates, e.g. regional feasts. They take
+// precedence over DefaultVariableDates of the same name; "easter" can't be replaced.
+func WithVariableDates(providers ...VariableDateProvider) Option {
+	return func(oh *OpeningHours) {
+		oh.variableDates = append(oh.variableDates, providers...)
+	}
+}
+
+// variableDateProvider returns the provider of the movable date name, or nil
+func (oh *OpeningHours) variableDateProvider(name string) VariableDateProvider {
+	var providers []VariableDateProvider
+	if oh != nil {
+		providers = oh.variableDates
+	}
+	for _, p := range append(providers, DefaultVariableDates()...) {
+		if strings.EqualFold(p.Name(), name) {
+			return p
+		}
+	}
+	return nil
+}
+
+var selectorWordPattern = regexp.MustCompile(`\p{L}[\p{L}\d]*`)
+
+// parseVariableDate replaces the movable date names in s by "easter", so that
+// they are parsed like Easter selectors, and sets the provider of r. A rule can
+// only use one movable date.
+func parseVariableDate(r *rule, s string, oh *OpeningHours) (string, error) {
+	var provider VariableDateProvider
+	usesEaster := false
+	result := selectorWordPattern.ReplaceAllStringFunc(s, func(word string) string {
+		if strings.EqualFold(word, "easter") {
+			usesEaster = true
+			return word
+		}
+		p := oh.variableDateProvider(word)
+		if p == nil {
+			return word
+		}
+		if provider == nil {
+			provider = p
+		} else if !strings.EqualFold(provider.Name(), p.Name()) {
+			usesEaster = true // reported as a combination below
+		}
+		return "easter"
+	})
+	if provider == nil {
+		return s, nil
+	}
+	if usesEaster {
+		return s, fmt.Errorf("a rule can only use one movable date: %s", s)
+	}
+	r.variableDate = provider
+	return result, nil
+}
+
+// easterDate returns the movable date of the rule's Easter selector in year as
+// midnight UTC
+func (r *rule) easterDate(year int) time.Time {
+	if r.variableDate == nil {
+		return calculateEaster(year)
+	}
+	d := r.variableDate.Date(year)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// easterName returns the name of the rule's movable date, "easter" by default
+func (r *rule) easterName() string {
+	if r.variableDate == nil {
+		return "easter"
+	}
+	return strings.ToLower(r.variableDate.Name())
+}
@@ -0,0 +1,123 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultVariableDates(t *testing.T) {
+	want := map[string][2]string{
+		"goodfriday": {"2024-03-29", "2025-04-18"},
+		"ascension":  {"2024-05-09", "2025-05-29"},
+		"whitsun":    {"2024-05-19", "2025-06-08"},
+		"pentecost":  {"2024-05-19", "2025-06-08"},
+		"whitmonday": {"2024-05-20", "2025-06-09"},
+		"advent1":    {"2024-12-01", "2025-11-30"},
+		"advent4":    {"2024-12-22", "2025-12-21"},
+	}
+
+	for _, p := range DefaultVariableDates() {
+		dates, ok := want[p.Name()]
+		if !ok {
+			continue
+		}
+		for i, year := range []int{2024, 2025} {
+			if got := p.Date(year).Format("2006-01-02"); got != dates[i] {
+				t.Errorf("%s in %d: got %s, want %s", p.Name(), year, got, dates[i])
+			}
+		}
+		delete(want, p.Name())
+	}
+	for name := range want {
+		t.Errorf("missing built-in variable date %q", name)
+	}
+}
+
+func TestVariableDates_Selectors(t *testing.T) {
+	tests := []struct {
+		value string
+		date  time.Time
+		want  bool
+	}{
+		{"Mo-Su 10:00-18:00; whitsun off", time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 10:00-18:00; whitsun off", time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC), true},
+		{"Mo-Su 10:00-18:00; Pentecost +1 day off", time.Date(2025, 6, 9, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 10:00-18:00; goodfriday +3 days off", time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), false},
+		{"Mo-Su 10:00-18:00; goodfriday off", time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC), false},
+		{"ascension 10:00-12:00", time.Date(2024, 5, 9, 11, 0, 0, 0, time.UTC), true},
+		{"ascension 10:00-12:00", time.Date(2024, 5, 10, 11, 0, 0, 0, time.UTC), false},
+		// Christmas markets: from the first Sunday of Advent to Dec 23
+		{"advent1-Dec 23 16:00-21:00", time.Date(2024, 11, 30, 18, 0, 0, 0, time.UTC), false},
+		{"advent1-Dec 23 16:00-21:00", time.Date(2024, 12, 1, 18, 0, 0, 0, time.UTC), true},
+		{"advent1-Dec 23 16:00-21:00", time.Date(2025, 11, 30, 18, 0, 0, 0, time.UTC), true},
+		{"advent1-Dec 23 Sa 16:00-21:00", time.Date(2024, 12, 9, 18, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%q on %s: got %v, want %v", tt.value, tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestVariableDates_Prettify(t *testing.T) {
+	tests := map[string]string{
+		"Whitsun +1 day off":            "whitsun +1 day off",
+		"advent1-Dec 23 16:00-21:00":    "advent1-Dec 23 16:00-21:00",
+		`ascension off "Ascension Day"`: `ascension off "Ascension Day"`,
+	}
+	for value, want := range tests {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if got := oh.PrettifyValue(); got != want {
+			t.Errorf("%q: PrettifyValue = %q, want %q", value, got, want)
+		}
+	}
+
+	oh, err := New("whitsun +1 day off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got := oh.Rules()[0].Easter; got == nil || got.Date != "whitsun" || got.Offset != 1 {
+		t.Errorf("Easter = %+v, want whitsun +1 day", got)
+	}
+}
+
+// corpusChristi is Corpus Christi, 60 days after Easter
+type corpusChristi struct{}
+
+func (corpusChristi) Name() string { return "corpuschristi" }
+
+func (corpusChristi) Date(year int) time.Time { return calculateEaster(year).AddDate(0, 0, 60) }
+
+func TestVariableDates_Custom(t *testing.T) {
+	value := "Mo-Sa 09:00-18:00; corpuschristi off"
+	if _, err := New(value); err == nil {
+		t.Errorf("expected an error for an unknown movable date without WithVariableDates")
+	}
+
+	oh, err := New(value, WithVariableDates(corpusChristi{}))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	// Corpus Christi 2024 is Thursday, May 30
+	if oh.GetState(time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected closed on Corpus Christi")
+	}
+	if !oh.GetState(time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected open the day after Corpus Christi")
+	}
+	if got := oh.Clone().PrettifyValue(); got != value {
+		t.Errorf("PrettifyValue = %q, want %q", got, value)
+	}
+
+	if _, err := New("whitsun-advent1 off"); err == nil {
+		t.Errorf("expected an error for a rule combining movable dates")
+	}
+}
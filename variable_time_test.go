@@ -16,8 +16,8 @@ func TestVariableTime_SunriseToSunset(t *testing.T) {
 	oh.SetCoordinates(52.52, 13.405)
 
 	// Test on a known date: June 21, 2024 (summer solstice)
-	// Actual calculated sunrise in Berlin: 02:50 UTC
-	// Actual calculated sunset in Berlin: 19:25 UTC
+	// Actual calculated sunrise in Berlin: 02:43 UTC
+	// Actual calculated sunset in Berlin: 19:33 UTC
 
 	tests := []struct {
 		name     string
@@ -31,7 +31,7 @@ func TestVariableTime_SunriseToSunset(t *testing.T) {
 			hour:     2,
 			minute:   0,
 			wantOpen: false,
-			desc:     "02:00 should be closed (before sunrise at 02:50)",
+			desc:     "02:00 should be closed (before sunrise at 02:43)",
 		},
 		{
 			name:     "during daylight morning",
@@ -94,8 +94,8 @@ func TestVariableTime_WithOffset(t *testing.T) {
 	oh.SetCoordinates(52.52, 13.405)
 
 	// Test on June 21, 2024
-	// Sunrise is 02:50, then sunrise+01:00 is 03:50
-	// Sunset is 19:25, then sunset-01:00 is 18:25
+	// Sunrise is 02:43, then sunrise+01:00 is 03:43
+	// Sunset is 19:33, then sunset-01:00 is 18:33
 	testDate := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
@@ -167,8 +167,8 @@ func TestVariableTime_DawnDusk(t *testing.T) {
 	// Test on June 21, 2024
 	// Civil dawn is when sun is 6° below horizon (before sunrise)
 	// Civil dusk is when sun is 6° below horizon (after sunset)
-	// Sunrise: 02:50, so dawn: 02:20
-	// Sunset: 19:25, so dusk: 19:55
+	// Sunrise: 02:43, civil dawn: 01:53
+	// Sunset: 19:33, civil dusk: 20:23
 
 	tests := []struct {
 		name     string
@@ -179,17 +179,17 @@ func TestVariableTime_DawnDusk(t *testing.T) {
 	}{
 		{
 			name:     "very early morning",
-			hour:     2,
-			minute:   0,
+			hour:     1,
+			minute:   45,
 			wantOpen: false,
-			desc:     "02:00 should be closed (before civil dawn at 02:20)",
+			desc:     "01:45 should be closed (before civil dawn at 01:53)",
 		},
 		{
 			name:     "during civil twilight morning",
 			hour:     2,
 			minute:   30,
 			wantOpen: true,
-			desc:     "02:30 should be open (after dawn at 02:20)",
+			desc:     "02:30 should be open (after civil dawn at 01:53)",
 		},
 		{
 			name:     "during daylight",
@@ -203,14 +203,14 @@ func TestVariableTime_DawnDusk(t *testing.T) {
 			hour:     19,
 			minute:   45,
 			wantOpen: true,
-			desc:     "19:45 should be open (before dusk at 19:55)",
+			desc:     "19:45 should be open (before civil dusk at 20:23)",
 		},
 		{
 			name:     "late night",
 			hour:     20,
 			minute:   30,
 			wantOpen: false,
-			desc:     "20:30 should be closed (after civil dusk at 19:55)",
+			desc:     "20:30 should be closed (after civil dusk at 20:23)",
 		},
 	}
 
@@ -236,7 +236,7 @@ func TestVariableTime_Overnight(t *testing.T) {
 	oh.SetCoordinates(52.52, 13.405)
 
 	// Test on June 21, 2024
-	// Sunset is 19:25, next sunrise is 02:50 next day
+	// Sunset is 19:33, next sunrise is 02:43 next day
 	// Night time should be open, day time should be closed
 
 	tests := []struct {
@@ -265,7 +265,7 @@ func TestVariableTime_Overnight(t *testing.T) {
 			hour:     20,
 			minute:   0,
 			wantOpen: true,
-			desc:     "20:00 should be open (after sunset at 19:25)",
+			desc:     "20:00 should be open (after sunset at 19:33)",
 		},
 		{
 			name:     "midnight",
@@ -279,7 +279,7 @@ func TestVariableTime_Overnight(t *testing.T) {
 			hour:     2,
 			minute:   0,
 			wantOpen: true,
-			desc:     "02:00 should be open (before sunrise at 02:50)",
+			desc:     "02:00 should be open (before sunrise at 02:43)",
 		},
 	}
 
@@ -455,9 +455,9 @@ func TestVariableTime_MultipleOffsets(t *testing.T) {
 	oh.SetCoordinates(52.52, 13.405)
 
 	// Test on June 21, 2024
-	// Sunset is 19:25 UTC:
-	// - Start: sunset-00:30 = 18:55
-	// - End: sunset+02:00 = 21:25
+	// Sunset is 19:33 UTC:
+	// - Start: sunset-00:30 = 19:03
+	// - End: sunset+02:00 = 21:33
 	testDate := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
@@ -472,14 +472,14 @@ func TestVariableTime_MultipleOffsets(t *testing.T) {
 			hour:     18,
 			minute:   30,
 			wantOpen: false,
-			desc:     "18:30 should be closed (before sunset-00:30 at 18:55)",
+			desc:     "18:30 should be closed (before sunset-00:30 at 19:03)",
 		},
 		{
 			name:     "at range start",
-			hour:     18,
-			minute:   55,
+			hour:     19,
+			minute:   3,
 			wantOpen: true,
-			desc:     "18:55 should be open (at sunset-00:30)",
+			desc:     "19:03 should be open (at sunset-00:30)",
 		},
 		{
 			name:     "during range",
@@ -493,14 +493,14 @@ func TestVariableTime_MultipleOffsets(t *testing.T) {
 			hour:     21,
 			minute:   0,
 			wantOpen: true,
-			desc:     "21:00 should be open (before sunset+02:00 at 21:25)",
+			desc:     "21:00 should be open (before sunset+02:00 at 21:33)",
 		},
 		{
 			name:     "after range end",
 			hour:     21,
-			minute:   30,
+			minute:   40,
 			wantOpen: false,
-			desc:     "21:30 should be closed (after sunset+02:00 at 21:25)",
+			desc:     "21:40 should be closed (after sunset+02:00 at 21:33)",
 		},
 	}
 
@@ -528,7 +528,7 @@ func TestVariableTime_NegativeOffset(t *testing.T) {
 	oh.SetCoordinates(52.52, 13.405)
 
 	// Test on June 21, 2024
-	// Sunrise is 02:50, then sunrise-01:00 is 01:50
+	// Sunrise is 02:43, then sunrise-01:00 is 01:43
 	testDate := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
@@ -543,21 +543,21 @@ func TestVariableTime_NegativeOffset(t *testing.T) {
 			hour:     1,
 			minute:   0,
 			wantOpen: false,
-			desc:     "01:00 should be closed (before sunrise-01:00 at 01:50)",
+			desc:     "01:00 should be closed (before sunrise-01:00 at 01:43)",
 		},
 		{
 			name:     "during range",
 			hour:     2,
 			minute:   0,
 			wantOpen: true,
-			desc:     "02:00 should be open (between sunrise-01:00 at 01:50 and sunrise at 02:50)",
+			desc:     "02:00 should be open (between sunrise-01:00 at 01:43 and sunrise at 02:43)",
 		},
 		{
 			name:     "at sunrise",
 			hour:     2,
 			minute:   50,
 			wantOpen: false,
-			desc:     "02:50 should be closed (at sunrise, end of range)",
+			desc:     "02:43 should be closed (at sunrise, end of range)",
 		},
 		{
 			name:     "after range",
@@ -760,3 +760,128 @@ func TestVariableTime_EdgeCases(t *testing.T) {
 		_ = oh.GetState(testTime)
 	})
 }
+
+// TestVariableTime_OvernightRanges tests that ranges from a setting to a rising
+// sun event span midnight, with weekday selectors and in polar days and nights
+func TestVariableTime_OvernightRanges(t *testing.T) {
+	day := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC) // a Friday
+
+	tests := []struct {
+		value string
+		from  func(st SunTimes) time.Time // first opening after day
+		to    func(st SunTimes) time.Time // its end, on the next day
+	}{
+		{
+			value: "sunset-sunrise",
+			from:  func(st SunTimes) time.Time { return st.Sun.Set },
+			to:    func(st SunTimes) time.Time { return st.Sun.Rise },
+		},
+		{
+			value: "Fr dusk-dawn",
+			from:  func(st SunTimes) time.Time { return st.Civil.Set },
+			to:    func(st SunTimes) time.Time { return st.Civil.Rise },
+		},
+		{
+			value: "Fr (sunset+01:00)-(sunrise-01:00)",
+			from:  func(st SunTimes) time.Time { return st.Sun.Set.Add(time.Hour) },
+			to:    func(st SunTimes) time.Time { return st.Sun.Rise.Add(-time.Hour) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value, WithCoordinates(52.52, 13.405))
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			today, tomorrow := oh.SunTimes(day), oh.SunTimes(day.AddDate(0, 0, 1))
+			from, to := tt.from(today).Truncate(time.Minute), tt.to(tomorrow).Truncate(time.Minute)
+
+			intervals := oh.GetOpenIntervals(day.Add(12*time.Hour), day.Add(36*time.Hour))
+			if len(intervals) != 1 || !intervals[0].Start.Equal(from) || !intervals[0].End.Equal(to) {
+				t.Fatalf("got intervals %v, want %v to %v", intervals, from, to)
+			}
+			if !oh.GetState(day.AddDate(0, 0, 1)) {
+				t.Errorf("expected open at midnight after Friday")
+			}
+			if oh.GetState(to) {
+				t.Errorf("expected closed at %v", to)
+			}
+		})
+	}
+
+	t.Run("polar", func(t *testing.T) {
+		// Tromsø: the sun doesn't set in June and doesn't rise in December
+		oh, err := New("sunset-sunrise", WithCoordinates(69.6492, 18.9553))
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		for h := 0; h < 24; h++ {
+			if oh.GetState(time.Date(2024, 6, 21, h, 0, 0, 0, time.UTC)) {
+				t.Errorf("expected closed at %02d:00 during midnight sun", h)
+			}
+			if !oh.GetState(time.Date(2024, 12, 21, h, 0, 0, 0, time.UTC)) {
+				t.Errorf("expected open at %02d:00 during polar night", h)
+			}
+		}
+	})
+}
+
+// TestVariableTime_OvernightRangesPerDay tests that the end of an overnight
+// range is the event of the day it ends on, around the equinox when sunrise
+// moves by minutes from day to day
+func TestVariableTime_OvernightRangesPerDay(t *testing.T) {
+	day := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		from  func(st SunTimes) time.Time // first opening after day
+	}{
+		{"sunset-sunrise", func(st SunTimes) time.Time { return st.Sun.Set }},
+		{"Mar sunset-sunrise", func(st SunTimes) time.Time { return st.Sun.Set }},
+		{"We sunset-sunrise", func(st SunTimes) time.Time { return st.Sun.Set }},
+		{"18:00-sunrise", func(SunTimes) time.Time { return day.Add(18 * time.Hour) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value, WithCoordinates(52.52, 13.405))
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			today, tomorrow := oh.SunTimes(day), oh.SunTimes(day.AddDate(0, 0, 1))
+			from, to := tt.from(today).Truncate(time.Minute), tomorrow.Sun.Rise.Truncate(time.Minute)
+			if today.Sun.Rise.Truncate(time.Minute).Equal(to) {
+				t.Fatalf("sunrise is at %v on both days", to)
+			}
+
+			intervals := oh.GetOpenIntervals(day.Add(12*time.Hour), day.Add(36*time.Hour))
+			if len(intervals) != 1 || !intervals[0].Start.Equal(from) || !intervals[0].End.Equal(to) {
+				t.Fatalf("got intervals %v, want %v to %v", intervals, from, to)
+			}
+			if next := oh.GetNextChange(from); !next.Equal(to) {
+				t.Errorf("GetNextChange(%v) = %v, want %v", from, next, to)
+			}
+			if !oh.GetState(to.Add(-time.Minute)) || oh.GetState(to) {
+				t.Errorf("expected the range to end at %v", to)
+			}
+		})
+	}
+}
+
+func TestVariableTime_FixedStartVariableEnd(t *testing.T) {
+	oh, err := New("08:00-(sunset-01:00)", WithCoordinates(52.52, 13.405))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	day := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	end := oh.SunTimes(day).Sun.Set.Add(-time.Hour).Truncate(time.Minute)
+
+	intervals := oh.GetOpenIntervals(day, day.AddDate(0, 0, 1))
+	if len(intervals) != 1 || !intervals[0].Start.Equal(day.Add(8*time.Hour)) || !intervals[0].End.Equal(end) {
+		t.Errorf("got intervals %v, want 08:00 to %v", intervals, end)
+	}
+	if got := oh.PrettifyValue(); got != "08:00-(sunset-01:00)" {
+		t.Errorf("PrettifyValue() = %q", got)
+	}
+}
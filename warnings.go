@@ -0,0 +1,87 @@
+package openinghours
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// WarningCode identifies the kind of a Warning. Codes and their names are
+// stable, so they can be filtered and counted without matching messages.
+type WarningCode int
+
+const (
+	WarnAbbreviatedTime        WarningCode = iota // "10-12" instead of "10:00-12:00"
+	WarnFullWidth                                 // full-width digits, colons or hyphens
+	WarnMissingSpace                              // "Mo-Fr09:00-17:00" without a space before the times
+	WarnSpacedRange                               // "Mo - Fr" with spaces around the hyphen
+	WarnMissingSemicolon                          // rules not separated by semicolons
+	WarnMergedSplitDates                          // date-only rules took a modifier, see WithMergedSplitDates
+	WarnRedundant247                              // "24/7" followed by rules that override parts of it
+	WarnSpaceSeparatedWeekdays                    // "Mo[1] Tu[2]" instead of "Mo[1],Tu[2]"
+	WarnEmptyComment                              // `""`
+	WarnOverlappingTimes                          // "10:00-14:00,12:00-16:00"
+	WarnEqualStartEnd                             // "22:00-22:00", interpreted as 24 hours
+)
+
+var warningCodeNames = [...]string{
+	WarnAbbreviatedTime:        "abbreviated_time",
+	WarnFullWidth:              "full_width",
+	WarnMissingSpace:           "missing_space",
+	WarnSpacedRange:            "spaced_range",
+	WarnMissingSemicolon:       "missing_semicolon",
+	WarnMergedSplitDates:       "merged_split_dates",
+	WarnRedundant247:           "redundant_24_7",
+	WarnSpaceSeparatedWeekdays: "space_separated_weekdays",
+	WarnEmptyComment:           "empty_comment",
+	WarnOverlappingTimes:       "overlapping_times",
+	WarnEqualStartEnd:          "equal_start_end",
+}
+
+// String returns the name of the code, e.g. "overlapping_times"
+func (c WarningCode) String() string {
+	if c >= 0 && int(c) < len(warningCodeNames) {
+		return warningCodeNames[c]
+	}
+	return fmt.Sprintf("WarningCode(%d)", int(c))
+}
+
+// MarshalJSON encodes c as its String, e.g. "overlapping_times"
+func (c WarningCode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// Warning is an issue found while parsing a value that was parsed anyway
+type Warning struct {
+	Code      WarningCode `json:"code"`
+	Message   string      `json:"message"`
+	RuleIndex int         `json:"rule_index"`     // rule the warning is about like GetMatchingRule, -1 for the whole value
+	Span      string      `json:"span,omitempty"` // part of the value the warning is about, like "22:00-22:00"
+}
+
+// String returns the message of the warning
+func (w Warning) String() string {
+	return w.Message
+}
+
+// GetWarnings returns the messages of any warnings that were collected during
+// parsing, see GetWarningDetails
+func (oh *OpeningHours) GetWarnings() []string {
+	var messages []string
+	for _, w := range oh.warnings {
+		messages = append(messages, w.Message)
+	}
+	return messages
+}
+
+// GetWarningDetails returns a copy of any warnings that were collected during
+// parsing, with their codes, rules and spans
+func (oh *OpeningHours) GetWarningDetails() []Warning {
+	return slices.Clone(oh.warnings)
+}
+
+// addWarning adds a warning about span, a part of the value, to the warnings
+// list. Warnings added while parsing a rule are about that rule.
+func (oh *OpeningHours) addWarning(code WarningCode, span, msg string) {
+	oh.warnings = append(oh.warnings, Warning{Code: code, Message: msg, RuleIndex: oh.parsingRule, Span: span})
+}
@@ -1,7 +1,9 @@
 package openinghours
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestWarnings_NoWarnings(t *testing.T) {
@@ -16,6 +18,18 @@ func TestWarnings_NoWarnings(t *testing.T) {
 	}
 }
 
+func TestWarnings_NoShortTimeFormatForDayAndWeekRanges(t *testing.T) {
+	for _, value := range []string{"week 01-10 Mo 10:00-12:00", "Jan 01-15 10:00-12:00", "Apr 05-Sep 10 Mo 10:00-12:00"} {
+		oh, err := New(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if warnings := oh.GetWarnings(); len(warnings) != 0 {
+			t.Errorf("%q: expected no warnings, got %v", value, warnings)
+		}
+	}
+}
+
 func TestWarnings_ShortTimeFormat(t *testing.T) {
 	oh, err := New("10-12")
 	if err != nil {
@@ -237,6 +251,148 @@ func TestWarnings_NoOverlapAdjacentRanges(t *testing.T) {
 	}
 }
 
+func TestWarnings_EqualStartAndEnd(t *testing.T) {
+	oh, err := New("We 22:00-22:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	warnings := oh.GetWarnings()
+	if len(warnings) != 1 || !containsAny(warnings[0], []string{"24 hours"}) {
+		t.Errorf("expected one warning about a 24 hour range, got warnings: %v", warnings)
+	}
+
+	// The range still covers 24 hours starting Wednesday 22:00
+	// Jan 17, 2024 is Wednesday
+	if !oh.GetState(time.Date(2024, 1, 18, 21, 59, 0, 0, time.UTC)) {
+		t.Errorf("Th 21:59: expected open within the 24 hour range")
+	}
+	if oh.GetState(time.Date(2024, 1, 18, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("Th 22:00: expected closed after the 24 hour range")
+	}
+}
+
+func TestWarnings_EqualStartAndEnd_NextDayRules(t *testing.T) {
+	// Jan 17, 2024 is Wednesday
+	wed := func(day, hour int) time.Time { return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		value string
+		want  [][2]time.Time
+	}{
+		// Off rules for Thursday close only their own times of the continuation
+		{"We 22:00-22:00; Th 10:00-12:00 off", [][2]time.Time{{wed(17, 22), wed(18, 10)}, {wed(18, 12), wed(18, 22)}}},
+		// Off rules for other times of Thursday leave the continuation open
+		{"We 22:00-22:00; Th 22:00-23:00 off", [][2]time.Time{{wed(17, 22), wed(18, 22)}}},
+		// Open rules for Thursday replace the continuation from midnight
+		{"We 22:00-22:00; Th 14:00-16:00", [][2]time.Time{{wed(17, 22), wed(18, 0)}, {wed(18, 14), wed(18, 16)}}},
+		// Additional rules only add times
+		{"We 22:00-22:00, Th 10:00-12:00", [][2]time.Time{{wed(17, 22), wed(18, 22)}}},
+		{"Tu-We 22:00-22:00", [][2]time.Time{{wed(16, 22), wed(18, 22)}}},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		intervals := oh.GetOpenIntervals(wed(16, 0), wed(19, 0))
+		if len(intervals) != len(tt.want) {
+			t.Errorf("%q: expected %d intervals, got %v", tt.value, len(tt.want), intervals)
+			continue
+		}
+		for i, iv := range intervals {
+			if !iv.Start.Equal(tt.want[i][0]) || !iv.End.Equal(tt.want[i][1]) {
+				t.Errorf("%q: interval %d is %v - %v, want %v - %v", tt.value, i, iv.Start, iv.End, tt.want[i][0], tt.want[i][1])
+			}
+		}
+
+		// The next change is the next boundary of an interval, from before,
+		// at and inside each of them
+		for _, iv := range intervals {
+			for _, at := range []time.Time{iv.Start.Add(-time.Minute), iv.Start, iv.End.Add(-time.Minute)} {
+				want := iv.Start
+				if !at.Before(iv.Start) {
+					want = iv.End
+				}
+				if got := oh.GetNextChange(at); !got.Equal(want) {
+					t.Errorf("%q: GetNextChange(%v) = %v, want %v", tt.value, at, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestWarnings_FullDayRangeNoWarning(t *testing.T) {
+	oh, err := New("Mo-Fr 00:00-24:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if warnings := oh.GetWarnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for 00:00-24:00, got warnings: %v", warnings)
+	}
+}
+
+func TestWarnings_Structured(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Warning
+	}{
+		{"Mo 10-12", Warning{Code: WarnAbbreviatedTime, RuleIndex: -1, Span: "10-12"}},
+		{"24/7; Su off", Warning{Code: WarnRedundant247, RuleIndex: 0, Span: "24/7"}},
+		{"Mo-Fr １０：００－１９：００", Warning{Code: WarnFullWidth, RuleIndex: -1, Span: "１０：００－１９：００"}},
+		{"Mo - Fr 10:00-12:00", Warning{Code: WarnSpacedRange, RuleIndex: -1, Span: "Mo - Fr"}},
+		{"Mo 08:00-12:00; Tu 10:00-14:00,12:00-16:00", Warning{Code: WarnOverlappingTimes, RuleIndex: 1, Span: "10:00-14:00,12:00-16:00"}},
+		{`Mo 10:00-12:00; Tu 10:00-12:00 ""`, Warning{Code: WarnEmptyComment, RuleIndex: 1, Span: `""`}},
+		{"Mo 10:00-12:00 || Tu 22:00-22:00", Warning{Code: WarnEqualStartEnd, RuleIndex: 1, Span: "22:00-22:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			warnings := oh.GetWarningDetails()
+			if len(warnings) != 1 {
+				t.Fatalf("expected one warning, got %v", warnings)
+			}
+			got := warnings[0]
+			if got.Message == "" || oh.GetWarnings()[0] != got.Message {
+				t.Errorf("expected the message of GetWarnings, got %q", got.Message)
+			}
+			got.Message = ""
+			if got != tt.want {
+				t.Errorf("GetWarningDetails()[0] = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarnings_DetailsAreCopied(t *testing.T) {
+	oh, err := New("24/7; Su off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	oh.GetWarningDetails()[0].Message = "changed"
+	if got := oh.GetWarnings()[0]; got == "changed" {
+		t.Errorf("changing the result of GetWarningDetails changed the warnings")
+	}
+}
+
+func TestWarningCode_String(t *testing.T) {
+	if got := WarnOverlappingTimes.String(); got != "overlapping_times" {
+		t.Errorf("String() = %q, want %q", got, "overlapping_times")
+	}
+	data, err := json.Marshal(Warning{Code: WarnRedundant247, Message: "m", RuleIndex: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"code":"redundant_24_7","message":"m","rule_index":0}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
 // Helper function to check if a string contains any of the given substrings (case-insensitive)
 func containsAny(s string, substrs []string) bool {
 	lower := toLower(s)
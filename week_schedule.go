@@ -0,0 +1,117 @@
+package openinghours
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DaySchedule holds the open and unknown intervals of a single day
+type DaySchedule struct {
+	Date      time.Time  // Midnight at the start of the day
+	Intervals []Interval // Open/unknown intervals, clipped to the day
+}
+
+// WeekOption configures display-oriented functions like GetWeekSchedule and FormatWeek
+type WeekOption func(*weekOptions)
+
+type weekOptions struct {
+	referenceDate time.Time
+}
+
+// WithReferenceDate selects the week to render. The week is the week
+// containing t, evaluated in t's location, from Monday to Sunday or from the
+// first day of the week of the region set with SetRegion. This matters for
+// values that are not week stable, e.g. seasonal rules like "Apr-Sep Mo 10:00-18:00".
+func WithReferenceDate(t time.Time) WeekOption {
+	return func(o *weekOptions) {
+		o.referenceDate = t
+	}
+}
+
+func newWeekOptions(opts []WeekOption) weekOptions {
+	o := weekOptions{referenceDate: time.Now()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// weekStart returns midnight of the first day of the week containing t, for
+// weeks starting on first
+func weekStart(t time.Time, first time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(first) + 7) % 7 // days since the first day
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// GetWeekSchedule returns the schedule for each day of a week, starting on
+// Monday or on the first day of the week of the region set with SetRegion,
+// like Sunday in the US. Without options the current week is used; see
+// WithReferenceDate.
+func (oh *OpeningHours) GetWeekSchedule(opts ...WeekOption) []DaySchedule {
+	o := newWeekOptions(opts)
+	start := weekStart(oh.inLocation(o.referenceDate), oh.firstWeekday())
+
+	days := make([]DaySchedule, 7)
+	for i := range days {
+		days[i] = oh.GetDaySchedule(start.AddDate(0, 0, i))
+	}
+	return days
+}
+
+// FormatWeek renders the week schedule as one line per day, e.g. "Mo 09:00-17:00".
+// Closed days are rendered as "off". Without options the current week is used;
+// see WithReferenceDate.
+func (oh *OpeningHours) FormatWeek(opts ...WeekOption) string {
+	names := []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+	var lines []string
+
+	for _, day := range oh.GetWeekSchedule(opts...) {
+		var ranges []string
+		for _, iv := range day.Intervals {
+			ranges = append(ranges, formatDayInterval(day.Date, iv))
+		}
+		if len(ranges) == 0 {
+			ranges = append(ranges, "off")
+		}
+		lines = append(lines, names[day.Date.Weekday()]+" "+strings.Join(ranges, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatDayInterval formats an interval clipped to the day starting at dayStart
+func formatDayInterval(dayStart time.Time, iv Interval) string {
+	startMin := int(iv.Start.Sub(dayStart) / time.Minute)
+	endMin := int(iv.End.Sub(dayStart) / time.Minute)
+
+	result := fmt.Sprintf("%02d:%02d-%02d:%02d", startMin/60, startMin%60, endMin/60, endMin%60)
+	if iv.Unknown {
+		result += " unknown"
+	}
+	if iv.Comment != "" {
+		result += fmt.Sprintf(" \"%s\"", iv.Comment)
+	}
+	return result
+}
+
+// NextOpenDays returns midnight of the next n days, starting with the day of
+// from, that are open at some time at or after from, e.g. to offer appointments
+// only on open days. Days that are only unknown are skipped. Days are searched
+// up to a year ahead, so fewer than n days are returned for values like
+// "Dec 24 10:00-12:00".
+func (oh *OpeningHours) NextOpenDays(from time.Time, n int) []time.Time {
+	from = oh.inLocation(from)
+	var days []time.Time
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for len(days) < n && day.Sub(from) < iteratorSearchLimit {
+		for _, iv := range oh.GetDaySchedule(day).Intervals {
+			if !iv.Unknown && iv.End.After(from) {
+				days = append(days, day)
+				break
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return days
+}
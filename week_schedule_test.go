@@ -0,0 +1,104 @@
+package openinghours
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWeekSchedule_WeekStable(t *testing.T) {
+	oh, err := New("Mo-Fr 09:00-17:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	// Wednesday Jan 17, 2024 -> week of Monday Jan 15
+	ref := time.Date(2024, 1, 17, 15, 30, 0, 0, time.UTC)
+	days := oh.GetWeekSchedule(WithReferenceDate(ref))
+
+	if len(days) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(days))
+	}
+
+	wantMonday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if !days[0].Date.Equal(wantMonday) {
+		t.Errorf("first day = %v, want %v", days[0].Date, wantMonday)
+	}
+
+	for i, day := range days {
+		wantOpen := i < 5
+		if wantOpen != (len(day.Intervals) == 1) {
+			t.Errorf("day %d (%v): got %d intervals", i, day.Date.Weekday(), len(day.Intervals))
+		}
+	}
+}
+
+func TestWeekSchedule_ReferenceDateSelectsSeason(t *testing.T) {
+	oh, err := New("Jan-Mar Mo 10:00-12:00; Apr-Dec Mo 14:00-16:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	winter := oh.FormatWeek(WithReferenceDate(time.Date(2024, 2, 7, 0, 0, 0, 0, time.UTC)))
+	wantWinter := "Mo 10:00-12:00\nTu off\nWe off\nTh off\nFr off\nSa off\nSu off"
+	if winter != wantWinter {
+		t.Errorf("FormatWeek (February):\n%s\nwant:\n%s", winter, wantWinter)
+	}
+
+	summer := oh.FormatWeek(WithReferenceDate(time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)))
+	wantSummer := "Mo 14:00-16:00\nTu off\nWe off\nTh off\nFr off\nSa off\nSu off"
+	if summer != wantSummer {
+		t.Errorf("FormatWeek (June):\n%s\nwant:\n%s", summer, wantSummer)
+	}
+}
+
+func TestWeekSchedule_FormatMidnightAndUnknown(t *testing.T) {
+	oh, err := New("Sa 20:00-24:00; Su 10:00-12:00 unknown \"call\"")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	got := oh.FormatWeek(WithReferenceDate(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	want := "Mo off\nTu off\nWe off\nTh off\nFr off\nSa 20:00-24:00\nSu 10:00-12:00 unknown \"call\""
+	if got != want {
+		t.Errorf("FormatWeek:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNextOpenDays(t *testing.T) {
+	hc := &mockHolidayChecker{holidays: map[string]bool{"2024-01-17": true}}
+	tests := []struct {
+		value    string
+		from     time.Time
+		n        int
+		expected []int // days of January 2024
+	}{
+		{"Mo-Fr 09:00-17:00; PH off", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), 4, []int{15, 16, 18, 19}},
+		// Today is skipped after closing
+		{"Mo-Fr 09:00-17:00", time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC), 2, []int{16, 17}},
+		// Opening past midnight counts for the day after
+		{"Sa 22:00-02:00", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), 2, []int{20, 21}},
+		{"Mo-Fr 09:00-17:00 unknown; We 10:00-12:00", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), 2, []int{17, 24}},
+		{"off", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), 2, nil},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		oh.SetHolidayChecker(hc)
+
+		got := oh.NextOpenDays(tt.from, tt.n)
+		var days []int
+		for _, d := range got {
+			if d.Hour() != 0 || d.Minute() != 0 || d.Month() != time.January {
+				t.Errorf("%q: expected midnight in January, got %v", tt.value, d)
+			}
+			days = append(days, d.Day())
+		}
+		if !slices.Equal(days, tt.expected) {
+			t.Errorf("%q: NextOpenDays = %v, want %v", tt.value, days, tt.expected)
+		}
+	}
+}
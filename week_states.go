@@ -0,0 +1,152 @@
+package openinghours
+
+import (
+	"iter"
+	"math/bits"
+	"time"
+)
+
+// weekStates is the precomputed evaluation of a week stable value: whether it
+// is open or unknown at each minute of the week, indexed like WeeklyBitmap from
+// Monday 00:00. It answers GetState, GetUnknown and GetOpenDuration without
+// evaluating the rules, e.g. for routing engines checking thousands of POIs.
+type weekStates struct {
+	open    weekBits
+	unknown weekBits
+}
+
+// computeWeekStates evaluates oh at the change times of the canonical week, or
+// returns nil if the evaluation doesn't repeat every week: the value isn't week
+// stable (see IsWeekStable) or has times like sunset that vary by day.
+func (oh *OpeningHours) computeWeekStates() *weekStates {
+	if !oh.IsWeekStable() || len(oh.closures) > 0 {
+		return nil
+	}
+	for _, group := range append([][]rule{oh.rules}, oh.fallbackGroups...) {
+		for _, r := range group {
+			for _, tr := range r.timeRanges {
+				if tr.startVar != "" || tr.endVar != "" {
+					return nil
+				}
+			}
+		}
+	}
+
+	w := &weekStates{}
+	end := weeklyBitmapStart.Add(MinutesPerWeek * time.Minute)
+	times := append([]time.Time{weeklyBitmapStart}, oh.changeTimes(weeklyBitmapStart, end)...)
+	for i, t := range times {
+		next := end
+		if i+1 < len(times) {
+			next = times[i+1]
+		}
+		from, to := int(t.Sub(weeklyBitmapStart)/time.Minute), int(next.Sub(weeklyBitmapStart)/time.Minute)
+		switch oh.evaluate(t).state {
+		case StateOpen:
+			setBits(&w.open, from, to)
+		case StateUnknown:
+			setBits(&w.unknown, from, to)
+		}
+	}
+	return w
+}
+
+// weekMinute returns the minute of the week of the wall clock of t, from Monday 00:00
+func weekMinute(t time.Time) int {
+	return (int(t.Weekday())+6)%7*24*60 + t.Hour()*60 + t.Minute()
+}
+
+// state returns the state at the wall clock of t
+func (w *weekStates) state(t time.Time) State {
+	m := weekMinute(t)
+	switch {
+	case w.open[m/64]&(1<<(m%64)) != 0:
+		return StateOpen
+	case w.unknown[m/64]&(1<<(m%64)) != 0:
+		return StateUnknown
+	}
+	return StateClosed
+}
+
+// duration returns the open and unknown duration between from and to, which
+// must be in the evaluation location. Days with a zone transition are left to
+// GetOpenIntervals, the others take a few bit counts each.
+func (w *weekStates) duration(oh *OpeningHours, from, to time.Time) (openDuration, unknownDuration time.Duration) {
+	for t := from; t.Before(to); {
+		end := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		if to.Before(end) {
+			end = to
+		}
+		if zoneOffset(t) != zoneOffset(end) {
+			for _, iv := range oh.GetOpenIntervals(t, end) {
+				if iv.Unknown {
+					unknownDuration += iv.End.Sub(iv.Start)
+				} else {
+					openDuration += iv.End.Sub(iv.Start)
+				}
+			}
+			t = end
+			continue
+		}
+
+		// Whole minutes from the minute of t, without the part of it before t,
+		// and the part of the minute of end before end
+		start := startOfMinute(t)
+		lo := weekMinute(t)
+		hi := lo + int(end.Sub(start)/time.Minute)
+		before, after := t.Sub(start), end.Sub(start)%time.Minute
+		for _, s := range []struct {
+			bits     *weekBits
+			duration *time.Duration
+		}{{&w.open, &openDuration}, {&w.unknown, &unknownDuration}} {
+			*s.duration += time.Duration(countBits(s.bits, lo, hi)) * time.Minute
+			if s.bits[lo/64]&(1<<(lo%64)) != 0 {
+				*s.duration -= before
+			}
+			if after > 0 && s.bits[hi/64]&(1<<(hi%64)) != 0 {
+				*s.duration += after
+			}
+		}
+		t = end
+	}
+	return openDuration, unknownDuration
+}
+
+// weekBits is a bit per minute of the week
+type weekBits = [(MinutesPerWeek + 63) / 64]uint64
+
+// setBits sets the bits in [from, to)
+func setBits(b *weekBits, from, to int) {
+	for word, mask := range bitMasks(from, to) {
+		b[word] |= mask
+	}
+}
+
+// countBits returns the number of set bits in [from, to)
+func countBits(b *weekBits, from, to int) int {
+	n := 0
+	for word, mask := range bitMasks(from, to) {
+		n += bits.OnesCount64(b[word] & mask)
+	}
+	return n
+}
+
+// bitMasks yields the words covering the bits in [from, to) with the masks of
+// those bits
+func bitMasks(from, to int) iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		for from < to {
+			word, offset := from/64, from%64
+			mask := ^uint64(0) << offset
+			if width := to - from; width < 64-offset {
+				mask &= (1<<width - 1) << offset
+				from = to
+			} else {
+				from += 64 - offset
+			}
+			if !yield(word, mask) {
+				return
+			}
+		}
+	}
+}
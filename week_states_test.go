@@ -0,0 +1,98 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStates_MatchEvaluation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	values := []string{
+		"Mo-Fr 09:00-17:00",
+		"Mo-Fr 09:00-12:00,13:00-17:30; Sa 10:00-14:00",
+		"Fr-Sa 22:00-04:00",
+		"Su-Tu 11:00-01:00, We-Th 11:00-03:00",
+		"Mo-Fr 09:00-17:00; We 12:00-14:00 off",
+		`Mo-Fr 09:00-17:00; Sa unknown "call ahead"`,
+		"Mo-Fr 17:00+",
+		"24/7",
+		"off",
+		"10:00-16:00/01:30",
+		"Mo-Fr 09:00-17:00 || Sa 10:00-12:00",
+	}
+
+	for _, value := range values {
+		oh, err := New(value, WithTimezone(berlin))
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if oh.weekStates == nil {
+			t.Fatalf("%q: week stable value without precomputed week", value)
+		}
+
+		// Through both DST transitions of 2024, at uneven steps
+		from := time.Date(2024, 3, 20, 0, 0, 0, 0, berlin)
+		to := time.Date(2024, 11, 5, 0, 0, 0, 0, berlin)
+		for tm := from; tm.Before(to); tm = tm.Add(37*time.Minute + 13*time.Second) {
+			want := oh.evaluate(oh.inLocation(tm)).state
+			if got := oh.weekStates.state(oh.inLocation(tm)); got != want {
+				t.Fatalf("%q at %v: got %v, want %v", value, tm, got, want)
+			}
+		}
+
+		for _, span := range [][2]time.Time{
+			{from, to},
+			{time.Date(2024, 3, 30, 8, 59, 30, 0, berlin), time.Date(2024, 4, 2, 17, 0, 45, 0, berlin)},
+			{time.Date(2024, 10, 27, 0, 0, 0, 0, berlin), time.Date(2024, 10, 28, 0, 0, 0, 0, berlin)},
+			{time.Date(2024, 6, 3, 9, 0, 10, 0, berlin), time.Date(2024, 6, 3, 9, 0, 50, 0, berlin)},
+			{time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC), time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)},
+		} {
+			var wantOpen, wantUnknown time.Duration
+			for _, iv := range oh.GetOpenIntervals(span[0], span[1]) {
+				if iv.Unknown {
+					wantUnknown += iv.End.Sub(iv.Start)
+				} else {
+					wantOpen += iv.End.Sub(iv.Start)
+				}
+			}
+			open, unknown := oh.GetOpenDuration(span[0], span[1])
+			if open != wantOpen || unknown != wantUnknown {
+				t.Errorf("%q from %v to %v: GetOpenDuration = %v, %v, want %v, %v",
+					value, span[0], span[1], open, unknown, wantOpen, wantUnknown)
+			}
+		}
+	}
+}
+
+func TestWeekStates_NotWeekStable(t *testing.T) {
+	for _, value := range []string{
+		"Mo-Fr 09:00-17:00; PH off",
+		"Jun-Aug Mo-Fr 09:00-17:00",
+		"Mo-Fr sunrise-sunset",
+		"week 01-10 Mo-Fr 09:00-17:00",
+	} {
+		oh, err := New(value, WithCoordinates(52.5, 13.4))
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", value, err)
+		}
+		if oh.weekStates != nil {
+			t.Errorf("%q: expected no precomputed week", value)
+		}
+	}
+}
+
+func BenchmarkGetStateWeekStable(b *testing.B) {
+	oh, err := New("Mo-Fr 08:00-12:00,13:00-17:30; Sa 10:00-14:00; We 12:00-14:00 off")
+	if err != nil {
+		b.Fatalf("unexpected parse error: %v", err)
+	}
+
+	t := time.Date(2024, 6, 5, 12, 30, 0, 0, time.UTC)
+	for b.Loop() {
+		oh.GetState(t)
+	}
+}
@@ -0,0 +1,78 @@
+package openinghours
+
+import "time"
+
+// WeekStats summarizes the open and unknown times of a week, see WeeklyStats
+type WeekStats struct {
+	Open           time.Duration // total open time
+	Unknown        time.Duration // total unknown time
+	OpenPercentage float64       // share of the week that is open or unknown, 0 to 100
+	OpenDays       int           // days with open or unknown time
+	EarliestOpen   time.Duration // earliest opening as time of day, e.g. 9h for 09:00
+	LatestClose    time.Duration // latest closing after midnight of the opening day, e.g. 26h for 02:00 the next day
+	LongestOpen    time.Duration // longest continuous open or unknown stretch within the week
+}
+
+// WeeklyStats returns statistics for the ISO week (Monday to Sunday) containing
+// t, evaluated in the timezone of oh. Open and unknown times count as
+// open; adjacent intervals differing only in comment form one stretch.
+//
+// EarliestOpen and LatestClose are taken from the stretches opening during the
+// week, a stretch continuing from the previous week doesn't open in it. Values
+// open all week like "24/7" have an EarliestOpen of 0 and a LatestClose of 24h;
+// closed weeks have zero stats.
+func (oh *OpeningHours) WeeklyStats(t time.Time) WeekStats {
+	start := weekStart(oh.inLocation(t), time.Monday)
+	end := start.AddDate(0, 0, 7)
+
+	// One more day shows when the stretches of Sunday night close
+	var stretches []Interval
+	for _, iv := range oh.GetOpenIntervals(start, end.AddDate(0, 0, 1)) {
+		if n := len(stretches); n > 0 && stretches[n-1].End.Equal(iv.Start) {
+			stretches[n-1].End = iv.End
+		} else {
+			stretches = append(stretches, iv)
+		}
+	}
+
+	var stats WeekStats
+	stats.Open, stats.Unknown = oh.GetOpenDuration(start, end)
+	stats.OpenPercentage = float64(stats.Open+stats.Unknown) / float64(end.Sub(start)) * 100
+
+	openDays := make(map[int]bool)
+	opens := false
+	for _, s := range stretches {
+		if !s.Start.Before(end) {
+			break
+		}
+		midnight := time.Date(s.Start.Year(), s.Start.Month(), s.Start.Day(), 0, 0, 0, 0, start.Location())
+		clippedEnd := s.End
+		if clippedEnd.After(end) {
+			clippedEnd = end
+		}
+		if d := clippedEnd.Sub(s.Start); d > stats.LongestOpen {
+			stats.LongestOpen = d
+		}
+		for day := midnight; day.Before(clippedEnd); day = day.AddDate(0, 0, 1) {
+			openDays[dayKey(day)] = true
+		}
+
+		if s.Start.Equal(start) && oh.stateAt(start.Add(-time.Minute)) != StateClosed {
+			continue // opened in the previous week
+		}
+		openAt, closeAt := s.Start.Sub(midnight), s.End.Sub(midnight)
+		if !opens || openAt < stats.EarliestOpen {
+			stats.EarliestOpen = openAt
+		}
+		if !opens || closeAt > stats.LatestClose {
+			stats.LatestClose = closeAt
+		}
+		opens = true
+	}
+	stats.OpenDays = len(openDays)
+
+	if !opens && len(openDays) > 0 {
+		stats.EarliestOpen, stats.LatestClose = 0, 24*time.Hour
+	}
+	return stats
+}
@@ -0,0 +1,78 @@
+package openinghours
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWeeklyStats(t *testing.T) {
+	wednesday := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC) // week of Mon Jan 15
+	tests := []struct {
+		value string
+		want  WeekStats
+	}{
+		{
+			"Mo-Fr 09:00-17:00",
+			WeekStats{Open: 40 * time.Hour, OpenPercentage: 40.0 / 168 * 100, OpenDays: 5,
+				EarliestOpen: 9 * time.Hour, LatestClose: 17 * time.Hour, LongestOpen: 8 * time.Hour},
+		},
+		{
+			"Mo-Fr 08:00-12:00,13:00-18:00; Sa 10:00-14:00",
+			WeekStats{Open: 49 * time.Hour, OpenPercentage: 49.0 / 168 * 100, OpenDays: 6,
+				EarliestOpen: 8 * time.Hour, LatestClose: 18 * time.Hour, LongestOpen: 5 * time.Hour},
+		},
+		{
+			// Sunday night closes in the next week, Monday night's range continues from Sunday
+			"Fr-Su 20:00-02:00",
+			WeekStats{Open: 18 * time.Hour, OpenPercentage: 18.0 / 168 * 100, OpenDays: 4,
+				EarliestOpen: 20 * time.Hour, LatestClose: 26 * time.Hour, LongestOpen: 6 * time.Hour},
+		},
+		{
+			"Mo 10:00-12:00 open; Mo 12:00-14:00 unknown",
+			WeekStats{Open: 2 * time.Hour, Unknown: 2 * time.Hour, OpenPercentage: 4.0 / 168 * 100, OpenDays: 1,
+				EarliestOpen: 10 * time.Hour, LatestClose: 14 * time.Hour, LongestOpen: 4 * time.Hour},
+		},
+		{
+			"24/7",
+			WeekStats{Open: 168 * time.Hour, OpenPercentage: 100, OpenDays: 7,
+				EarliestOpen: 0, LatestClose: 24 * time.Hour, LongestOpen: 168 * time.Hour},
+		},
+		{
+			"Jan off",
+			WeekStats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			oh, err := New(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			got := oh.WeeklyStats(wednesday)
+			if math.Abs(got.OpenPercentage-tt.want.OpenPercentage) > 1e-9 {
+				t.Errorf("OpenPercentage = %v, want %v", got.OpenPercentage, tt.want.OpenPercentage)
+			}
+			got.OpenPercentage = tt.want.OpenPercentage
+			if got != tt.want {
+				t.Errorf("WeeklyStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeeklyStats_ContinuesFromPreviousWeek(t *testing.T) {
+	oh, err := New("Su 20:00-02:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	got := oh.WeeklyStats(time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC))
+	// Monday 00:00-02:00 belongs to the previous Sunday, only Sunday opens
+	if got.EarliestOpen != 20*time.Hour || got.LatestClose != 26*time.Hour {
+		t.Errorf("EarliestOpen, LatestClose = %v, %v, want 20h, 26h", got.EarliestOpen, got.LatestClose)
+	}
+	if got.Open != 6*time.Hour || got.OpenDays != 2 || got.LongestOpen != 4*time.Hour {
+		t.Errorf("WeeklyStats() = %+v, want 6h open on 2 days, longest 4h", got)
+	}
+}
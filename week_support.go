@@ -47,8 +47,46 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// matchesWeeks reports whether the ISO week of t is selected by one of the
+// week constraints of r
+func (r *rule) matchesWeeks(t time.Time) bool {
+	year, week := t.ISOWeek()
+	for _, wc := range r.weekConstraints {
+		if wc.matches(year, week) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether week of the ISO year is in wc. Ranges like "week
+// 50-03" wrap into the next year. Intervals step from the range start, also
+// across the wrap: "week 52-02/2" selects week 52, week 01 if the year before
+// has 53 weeks and week 02 otherwise.
+func (wc weekConstraint) matches(year, week int) bool {
+	var offset int
+	switch {
+	case week >= wc.weekStart && week <= wc.weekEnd:
+		offset = week - wc.weekStart
+	case wc.weekEnd < wc.weekStart && week >= wc.weekStart:
+		offset = week - wc.weekStart
+	case wc.weekEnd < wc.weekStart && week <= wc.weekEnd:
+		offset = isoWeeksIn(year-1) - wc.weekStart + week
+	default:
+		return false
+	}
+	return wc.weekInterval == 0 || offset%wc.weekInterval == 0
+}
+
+// isoWeeksIn returns the number of ISO weeks of year, 52 or 53
+func isoWeeksIn(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
 // parseWeekNumbers extracts week number information from the start of the string
 // Returns: remaining string, week constraints slice, error
 // Supports comma-separated week specifications like "week 01,10,20" or "week 52-53,01-02"
@@ -1,6 +1,7 @@
 package openinghours
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -362,3 +363,189 @@ func TestWeekNumber_ComplexInterval(t *testing.T) {
 		}
 	}
 }
+
+func TestWeekNumber_WithMonths(t *testing.T) {
+	// Week, month and weekday selectors intersect.
+	// 2024: Mar 04 is in week 10, Apr 08 in week 15, Apr 15 in week 16,
+	// May 06 in week 19 and Jun 03 in week 23 (all Mondays).
+	days := []time.Time{
+		time.Date(2024, 3, 4, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 8, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 15, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 6, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 3, 11, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		value    string
+		want     []bool
+		prettify string
+	}{
+		{"week 02-20 Apr-Sep Mo 10:00-12:00", []bool{false, true, true, true, false},
+			"week 02-20 Apr-Sep Mo 10:00-12:00"},
+		{"Apr-Sep week 02-20 Mo 10:00-12:00", []bool{false, true, true, true, false},
+			"week 02-20 Apr-Sep Mo 10:00-12:00"},
+		{"week 02-20/2 Apr-Sep Mo 10:00-12:00", []bool{false, false, true, false, false},
+			"week 02-20/2 Apr-Sep Mo 10:00-12:00"},
+		{"week 10,15,19 Mar-May Mo 10:00-12:00", []bool{true, true, false, true, false},
+			"week 10,15,19 Mar-May Mo 10:00-12:00"},
+		{"week 02-20 Apr,Jun Mo 10:00-12:00", []bool{false, true, true, false, false},
+			"week 02-20 Apr,Jun Mo 10:00-12:00"},
+		{"2024 week 10-23 Mar,Jun Mo 10:00-12:00", []bool{true, false, false, false, true},
+			"2024 week 10-23 Mar,Jun Mo 10:00-12:00"},
+		{"week 10-20 Apr 10-May 31 Mo 10:00-12:00", []bool{false, false, true, true, false},
+			"week 10-20 Apr 10-May 31 Mo 10:00-12:00"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if w := oh.GetWarnings(); len(w) != 0 {
+			t.Errorf("%q: unexpected warnings %v", tt.value, w)
+		}
+		for i, day := range days {
+			if got := oh.GetState(day); got != tt.want[i] {
+				t.Errorf("%q at %s: got %v, want %v", tt.value, day.Format("2006-01-02"), got, tt.want[i])
+			}
+		}
+
+		prettified := oh.PrettifyValue()
+		if prettified != tt.prettify {
+			t.Errorf("%q: PrettifyValue = %q, want %q", tt.value, prettified, tt.prettify)
+		}
+		again, err := New(prettified)
+		if err != nil {
+			t.Fatalf("%q: prettified value does not parse: %v", prettified, err)
+		}
+		if !again.IsEqualTo(oh) {
+			t.Errorf("%q: prettified value %q is not equal", tt.value, prettified)
+		}
+	}
+}
+
+func TestWeekNumber_IntervalsAcrossMonthsAndYears(t *testing.T) {
+	// Intervals and changes of week rules must match GetState over long windows,
+	// including 2020 with its ISO week 53
+	values := []string{
+		"week 01-53/2 Mo 10:00-12:00",
+		"week 01-53/2 Mo-We 22:00-02:00",
+		"week 10-20/3 Sa,Su 10:00-12:00; PH off",
+		"week 01-53/2 Mo 10:00-12:00, week 02-53/2 Tu 10:00-12:00",
+		"Mo-Fr 09:00-17:00; week 05-07 Mo off",
+	}
+	from := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(2, 0, 0)
+
+	for _, value := range values {
+		t.Run(value, func(t *testing.T) {
+			oh, err := New(value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			var expected []Interval
+			for c := from; c.Before(to); c = c.Add(30 * time.Minute) {
+				open := oh.GetState(c)
+				last := len(expected) - 1
+				switch {
+				case open && (last < 0 || !expected[last].End.IsZero()):
+					expected = append(expected, Interval{Start: c})
+				case !open && last >= 0 && expected[last].End.IsZero():
+					expected[last].End = c
+				}
+			}
+			if last := len(expected) - 1; last >= 0 && expected[last].End.IsZero() {
+				expected[last].End = to
+			}
+
+			intervals := oh.GetOpenIntervals(from, to)
+			if len(intervals) != len(expected) {
+				t.Fatalf("got %d intervals, want %d", len(intervals), len(expected))
+			}
+			for i, iv := range intervals {
+				if !iv.Start.Equal(expected[i].Start) || !iv.End.Equal(expected[i].End) {
+					t.Fatalf("interval %d = %v-%v, want %v-%v", i, iv.Start, iv.End, expected[i].Start, expected[i].End)
+				}
+			}
+
+			// Every change is the start or end of an interval
+			var changes []time.Time
+			for c := oh.GetNextChange(from); !c.IsZero() && c.Before(to); c = oh.GetNextChange(c) {
+				changes = append(changes, c)
+			}
+			if len(changes) != 2*len(expected) {
+				t.Fatalf("got %d changes, want %d", len(changes), 2*len(expected))
+			}
+			for i, c := range changes {
+				want := expected[i/2].Start
+				if i%2 == 1 {
+					want = expected[i/2].End
+				}
+				if !c.Equal(want) {
+					t.Fatalf("change %d = %v, want %v", i, c, want)
+				}
+			}
+		})
+	}
+
+	// Week 53 of 2020 and week 01 of 2021 are both odd
+	oh, err := New("week 01-53/2 Mo 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	intervals := oh.GetOpenIntervals(time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC))
+	var days []string
+	for _, iv := range intervals {
+		days = append(days, iv.Start.Format("2006-01-02"))
+	}
+	if got := strings.Join(days, ","); got != "2020-12-28,2021-01-04,2021-01-18" {
+		t.Errorf("open Mondays = %s", got)
+	}
+}
+
+func TestWeekNumber_WrappingRange(t *testing.T) {
+	tests := []struct {
+		value string
+		date  time.Time
+		want  bool
+	}{
+		// Week 50 of 2024 starts on Monday, Dec 9
+		{"week 50-03 Mo 10:00-12:00", time.Date(2024, 12, 2, 11, 0, 0, 0, time.UTC), false},
+		{"week 50-03 Mo 10:00-12:00", time.Date(2024, 12, 9, 11, 0, 0, 0, time.UTC), true},
+		{"week 50-03 Mo 10:00-12:00", time.Date(2024, 12, 30, 11, 0, 0, 0, time.UTC), true},
+		{"week 50-03 Mo 10:00-12:00", time.Date(2025, 1, 13, 11, 0, 0, 0, time.UTC), true},
+		{"week 50-03 Mo 10:00-12:00", time.Date(2025, 1, 20, 11, 0, 0, 0, time.UTC), false},
+		{"week 50-03 Mo 10:00-12:00", time.Date(2025, 6, 2, 11, 0, 0, 0, time.UTC), false},
+		// Steps count from week 52 across the wrap: 2024 has 52 weeks
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2024, 12, 23, 11, 0, 0, 0, time.UTC), true},
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2024, 12, 30, 11, 0, 0, 0, time.UTC), false},
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2025, 1, 6, 11, 0, 0, 0, time.UTC), true},
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2025, 1, 20, 11, 0, 0, 0, time.UTC), true},
+		// 2020 has 53 weeks
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2020, 12, 21, 11, 0, 0, 0, time.UTC), true},
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2020, 12, 28, 11, 0, 0, 0, time.UTC), false},
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2021, 1, 4, 11, 0, 0, 0, time.UTC), true},
+		{"week 52-04/2 Mo 10:00-12:00", time.Date(2021, 1, 11, 11, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if got := oh.GetState(tt.date); got != tt.want {
+			year, week := tt.date.ISOWeek()
+			t.Errorf("%q on %s (ISO week %d-%02d): got %v, want %v", tt.value, tt.date.Format("2006-01-02"), year, week, got, tt.want)
+		}
+	}
+
+	oh, err := New("week 50-03 Mo 10:00-12:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got := oh.PrettifyValue(); got != "week 50-03 Mo 10:00-12:00" {
+		t.Errorf("PrettifyValue = %q", got)
+	}
+}
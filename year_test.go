@@ -323,3 +323,142 @@ func TestYear_MultipleYears(t *testing.T) {
 		}
 	}
 }
+
+func TestYear_MultiYearFullDateRange(t *testing.T) {
+	// A one-off event window spanning two year boundaries
+	oh, err := New("Mo-Fr 09:00-17:00; 2024 Dec 01-2026 Jan 31 Sa 10:00-14:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		date time.Time
+		want bool
+		desc string
+	}{
+		{time.Date(2024, 11, 30, 11, 0, 0, 0, time.UTC), false, "Nov 30, 2024 (Saturday) - before the window"},
+		{time.Date(2024, 12, 7, 11, 0, 0, 0, time.UTC), true, "Dec 7, 2024 (Saturday) - first month of the window"},
+		{time.Date(2025, 7, 12, 11, 0, 0, 0, time.UTC), true, "Jul 12, 2025 (Saturday) - year inside the window"},
+		{time.Date(2025, 7, 14, 10, 0, 0, 0, time.UTC), true, "Jul 14, 2025 (Monday) - regular hours"},
+		{time.Date(2026, 1, 31, 11, 0, 0, 0, time.UTC), true, "Jan 31, 2026 (Saturday) - last day of the window"},
+		{time.Date(2026, 2, 7, 11, 0, 0, 0, time.UTC), false, "Feb 7, 2026 (Saturday) - after the window"},
+	}
+
+	for _, tt := range tests {
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestYear_FullDateRangeWithConstrainedWeekday(t *testing.T) {
+	// First Monday of each month during the window only
+	oh, err := New("2025 Jun 01-2025 Sep 30 Mo[1] 08:00-20:00")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		date time.Time
+		want bool
+		desc string
+	}{
+		{time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC), true, "Jun 2, 2025 - first Monday in the window"},
+		{time.Date(2025, 6, 9, 12, 0, 0, 0, time.UTC), false, "Jun 9, 2025 - second Monday"},
+		{time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC), true, "Sep 1, 2025 - first Monday in the window"},
+		{time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC), false, "Oct 6, 2025 - first Monday after the window"},
+		{time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), false, "Jun 1, 2026 - first Monday a year later"},
+	}
+
+	for _, tt := range tests {
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+
+	if got, want := oh.PrettifyValue(), "2025 Jun 01-2025 Sep 30 Mo[1] 08:00-20:00"; got != want {
+		t.Errorf("PrettifyValue: got %q, want %q", got, want)
+	}
+	if oh.IsWeekStable() {
+		t.Errorf("expected a full date range not to be week stable")
+	}
+}
+
+func TestYear_FullDateRangeEndsBeforeStart(t *testing.T) {
+	if _, err := New("2026 Jan 01-2025 Dec 31 10:00-12:00"); err == nil {
+		t.Errorf("expected an error for a date range ending before it starts")
+	}
+}
+
+func TestYear_WrappingMonthDayRange(t *testing.T) {
+	// The year applies to the start: the range ends in the following year
+	oh, err := New("Mo-Su 10:00-18:00; 2024 Dec 24-Jan 05 off")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	tests := []struct {
+		date time.Time
+		want bool
+		desc string
+	}{
+		{time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), true, "Jan 3, 2024 - before the range"},
+		{time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC), true, "Dec 23, 2024 - day before the range"},
+		{time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), false, "Dec 24, 2024 - first day"},
+		{time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC), false, "Jan 5, 2025 - last day"},
+		{time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC), true, "Jan 6, 2025 - after the range"},
+		{time.Date(2025, 12, 27, 12, 0, 0, 0, time.UTC), true, "Dec 27, 2025 - a year later"},
+	}
+
+	for _, tt := range tests {
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+
+	if got, want := oh.PrettifyValue(), "Mo-Su 10:00-18:00; 2024 Dec 24-2025 Jan 05 off"; got != want {
+		t.Errorf("PrettifyValue: got %q, want %q", got, want)
+	}
+}
+
+func TestYear_DateOffset(t *testing.T) {
+	tests := []struct {
+		value    string
+		date     time.Time
+		want     bool
+		desc     string
+		prettify string
+	}{
+		{"Mo-Su 10:00-18:00; Dec 25 +3 days off", time.Date(2024, 12, 28, 12, 0, 0, 0, time.UTC), false,
+			"Dec 28 is 3 days after Dec 25", "Mo-Su 10:00-18:00; Dec 25 +3 days off"},
+		{"Mo-Su 10:00-18:00; Dec 25 +3 days off", time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), true,
+			"Dec 25 itself is not selected", "Mo-Su 10:00-18:00; Dec 25 +3 days off"},
+		{"Dec 25 -1 day 10:00-12:00", time.Date(2024, 12, 24, 11, 0, 0, 0, time.UTC), true,
+			"Dec 24 is a day before Dec 25", "Dec 25 -1 day 10:00-12:00"},
+		// Offsets are counted in days, so they follow leap years
+		{"Mo-Su 10:00-18:00; Feb 28 +1 day off", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), false,
+			"Feb 29 in a leap year", "Mo-Su 10:00-18:00; Feb 28 +1 day off"},
+		{"Mo-Su 10:00-18:00; Feb 28 +1 day off", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), true,
+			"Mar 1 in a leap year", "Mo-Su 10:00-18:00; Feb 28 +1 day off"},
+		{"Mo-Su 10:00-18:00; Feb 28 +1 day off", time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), false,
+			"Mar 1 in a common year", "Mo-Su 10:00-18:00; Feb 28 +1 day off"},
+		// The year applies to the date before the offset
+		{"Mo-Su 10:00-18:00; 2024 Dec 31 +1 day off", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), false,
+			"Jan 1, 2025 is a day after Dec 31, 2024", "Mo-Su 10:00-18:00; 2024 Dec 31 +1 day off"},
+		{"Mo-Su 10:00-18:00; 2024 Dec 31 +1 day off", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), true,
+			"Jan 1, 2024 is a day after Dec 31, 2023", "Mo-Su 10:00-18:00; 2024 Dec 31 +1 day off"},
+	}
+
+	for _, tt := range tests {
+		oh, err := New(tt.value)
+		if err != nil {
+			t.Fatalf("%q: unexpected parse error: %v", tt.value, err)
+		}
+		if got := oh.GetState(tt.date); got != tt.want {
+			t.Errorf("%q, %s: got %v, want %v", tt.value, tt.desc, got, tt.want)
+		}
+		if got := oh.PrettifyValue(); got != tt.prettify {
+			t.Errorf("%q: PrettifyValue = %q, want %q", tt.value, got, tt.prettify)
+		}
+	}
+}